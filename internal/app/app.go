@@ -4,26 +4,38 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"auction-platform/internal/config"
 	httpapi "auction-platform/internal/controller/http/v1"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/auth"
 	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/delivery"
+	"auction-platform/internal/infrastruct/idempotency"
 	kafkaclient "auction-platform/internal/infrastruct/kafka"
 	kd "auction-platform/internal/infrastruct/kafka/dto"
+	"auction-platform/internal/infrastruct/ratelimit"
 	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/infrastruct/storage"
+	"auction-platform/internal/infrastruct/webhook"
 	"auction-platform/internal/metrics"
 	"auction-platform/internal/repo"
 	"auction-platform/internal/service"
 	"auction-platform/internal/worker"
 	"auction-platform/pkg/httpserver"
 	"auction-platform/pkg/logger"
+	"auction-platform/pkg/money"
 	"auction-platform/pkg/postgres"
 	"auction-platform/pkg/validator"
 
 	errutils "auction-platform/pkg/errors"
 
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	trmmanager "github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
 	k "github.com/segmentio/kafka-go"
@@ -82,6 +94,14 @@ func Run() {
 			return float64(counts.TotalFailures)/float64(counts.Requests) >= cfg.CircuitBreaker.FailureRatio
 		},
 	})
+	cb.Register("redis_ratelimit", gobreaker.Settings{
+		MaxRequests: 1,
+		Interval:    30 * time.Second,
+		Timeout:     10 * time.Second,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures > 3
+		},
+	})
 	cb.Register("kafka_producer", gobreaker.Settings{
 		MaxRequests: 3,
 		Interval:    30 * time.Second,
@@ -99,26 +119,87 @@ func Run() {
 		Multiplier:  cfg.Retry.Multiplier,
 	}, m)
 
+	webhookRetryer := retry.New(retry.Config{
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		InitialWait: cfg.Webhooks.InitialWait,
+		MaxWait:     cfg.Webhooks.MaxWait,
+		Multiplier:  cfg.Webhooks.Multiplier,
+	}, m)
+
 	// Repos
 	repositories := repo.NewRepositories(pg)
+	txManager := trmmanager.Must(trmpgx.NewDefaultFactory(pg.Pool))
 
 	// Kafka Producer
-	kafkaTopics := []string{cfg.Kafka.BidPlacedTopic, cfg.Kafka.BidResultTopic, cfg.Kafka.AuctionEndTopic}
+	kafkaTopics := []string{cfg.Kafka.BidPlacedTopic, cfg.Kafka.BidResultTopic, cfg.Kafka.AuctionEndTopic, cfg.Kafka.OrderEventsTopic}
 	producer := kafkaclient.NewProducer(cfg.Kafka.Brokers, kafkaTopics, cb, retryer, m)
 	defer producer.Close()
 
+	// Media storage
+	mediaStorage, err := storage.NewLocalStorage(cfg.Media.Dir, cfg.Media.BaseURL)
+	if err != nil {
+		log.Fatal(errutils.WrapPathErr(err))
+	}
+
 	// Services
 	services := service.NewServices(service.ServicesDependencies{
 		Repos:       repositories,
+		TxManager:   txManager,
 		Redis:       rdb,
 		Breaker:     cb,
 		Retryer:     retryer,
 		Producer:    producer,
 		Metrics:     m,
+		Deliverer:   delivery.NewLogDeliverer(),
+		Storage:     mediaStorage,
 		BidTopic:    cfg.Kafka.BidPlacedTopic,
 		ResultTopic: cfg.Kafka.BidResultTopic,
+
+		WebhookSender:  webhook.NewSender(cfg.Webhooks.Timeout),
+		WebhookRetryer: webhookRetryer,
+		WebhookBreakerSettings: gobreaker.Settings{
+			MaxRequests: 1,
+			Interval:    cfg.Webhooks.BreakerOpenDelay,
+			Timeout:     cfg.Webhooks.BreakerOpenDelay,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= cfg.Webhooks.BreakerFailures
+			},
+		},
+
+		APIKeyDefaultRPS:   cfg.APIKeys.DefaultRPS,
+		APIKeyDefaultBurst: cfg.APIKeys.DefaultBurst,
+
+		HoldPercent: cfg.Wallet.HoldPercent,
+
+		OrderTopic:         cfg.Kafka.OrderEventsTopic,
+		OrderPaymentWindow: cfg.Orders.PaymentWindow,
+		OrderOfferWindow:   cfg.Orders.OfferWindow,
+		FeePolicy:          feePolicy(cfg.Fees),
+
+		IncrementPolicy: incrementPolicy(cfg.Increments),
+
+		FraudPolicy: fraudPolicy(cfg.Fraud),
+
+		BanCacheTTL: cfg.Redis.BanTTL,
+
+		MediaPolicy: e.MediaPolicy{
+			MaxSize:       cfg.Media.MaxSize,
+			MaxPerAuction: cfg.Media.MaxPerAuction,
+			ThumbnailSize: cfg.Media.ThumbnailSize,
+		},
+
+		ImportBatchSize: cfg.Import.BatchSize,
+		ImportMaxRows:   cfg.Import.MaxRows,
 	})
 
+	// FX rates
+	if cfg.FX.RatesFile != "" {
+		if err := services.FX.LoadRatesFile(context.Background(), cfg.FX.RatesFile); err != nil {
+			log.Fatal(errutils.WrapPathErr(err))
+		}
+		log.Infof("FX rates loaded from %s", cfg.FX.RatesFile)
+	}
+
 	// Kafka Consumer
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -140,18 +221,166 @@ func Run() {
 	defer bidConsumer.Close()
 	go bidConsumer.Start(ctx)
 
+	bidResultConsumer := kafkaclient.NewConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.BidResultTopic,
+		cfg.Kafka.NotifierGroupID,
+		func(ctx context.Context, msg k.Message) error {
+			event, err := kafkaclient.ParseMessage[kd.BidResultEvent](msg)
+			if err != nil {
+				log.Errorf("Failed to parse bid result event: %v", err)
+				return err
+			}
+			return services.Notifications.HandleBidResult(ctx, event)
+		},
+		m,
+	)
+	defer bidResultConsumer.Close()
+	go bidResultConsumer.Start(ctx)
+
+	auctionEndedConsumer := kafkaclient.NewConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.AuctionEndTopic,
+		cfg.Kafka.NotifierGroupID,
+		func(ctx context.Context, msg k.Message) error {
+			event, err := kafkaclient.ParseMessage[kd.AuctionEndedEvent](msg)
+			if err != nil {
+				log.Errorf("Failed to parse auction ended event: %v", err)
+				return err
+			}
+			return services.Notifications.HandleAuctionEnded(ctx, event)
+		},
+		m,
+	)
+	defer auctionEndedConsumer.Close()
+	go auctionEndedConsumer.Start(ctx)
+
+	webhookResultConsumer := kafkaclient.NewConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.BidResultTopic,
+		cfg.Webhooks.GroupID,
+		func(ctx context.Context, msg k.Message) error {
+			event, err := kafkaclient.ParseMessage[kd.BidResultEvent](msg)
+			if err != nil {
+				log.Errorf("Failed to parse bid result event: %v", err)
+				return err
+			}
+			return services.Webhooks.Dispatch(ctx, e.WebhookEventBidResult, event)
+		},
+		m,
+	)
+	defer webhookResultConsumer.Close()
+	go webhookResultConsumer.Start(ctx)
+
+	webhookEndedConsumer := kafkaclient.NewConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.AuctionEndTopic,
+		cfg.Webhooks.GroupID,
+		func(ctx context.Context, msg k.Message) error {
+			event, err := kafkaclient.ParseMessage[kd.AuctionEndedEvent](msg)
+			if err != nil {
+				log.Errorf("Failed to parse auction ended event: %v", err)
+				return err
+			}
+			return services.Webhooks.Dispatch(ctx, e.WebhookEventAuctionEnded, event)
+		},
+		m,
+	)
+	defer webhookEndedConsumer.Close()
+	go webhookEndedConsumer.Start(ctx)
+
+	fraudBidConsumer := kafkaclient.NewConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.BidPlacedTopic,
+		cfg.Fraud.GroupID,
+		func(ctx context.Context, msg k.Message) error {
+			event, err := kafkaclient.ParseMessage[kd.BidPlacedEvent](msg)
+			if err != nil {
+				log.Errorf("Failed to parse bid event: %v", err)
+				return err
+			}
+			return services.Fraud.HandleBidPlaced(ctx, event)
+		},
+		m,
+	)
+	defer fraudBidConsumer.Close()
+	go fraudBidConsumer.Start(ctx)
+
+	fraudResultConsumer := kafkaclient.NewConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.BidResultTopic,
+		cfg.Fraud.GroupID,
+		func(ctx context.Context, msg k.Message) error {
+			event, err := kafkaclient.ParseMessage[kd.BidResultEvent](msg)
+			if err != nil {
+				log.Errorf("Failed to parse bid result event: %v", err)
+				return err
+			}
+			return services.Fraud.HandleBidResult(ctx, event)
+		},
+		m,
+	)
+	defer fraudResultConsumer.Close()
+	go fraudResultConsumer.Start(ctx)
+
 	// Worker auction expiry checker
 	bidProcessor := worker.NewBidProcessor(
-		services.Bids, repositories.Auctions, repositories.Bids,
+		services.Auctions, services.Bids, services.Wallets, services.Orders, repositories.Auctions, repositories.Bids,
 		producer, m, cfg.Kafka.AuctionEndTopic,
 	)
 	go bidProcessor.StartExpiryChecker(ctx)
 
+	// Worker ending soon notifications
+	endingSoonNotifier := worker.NewEndingSoonNotifier(
+		services.Notifications,
+		cfg.Notifications.EndingSoonWindow,
+		cfg.Notifications.ScanInterval,
+	)
+	go endingSoonNotifier.Start(ctx)
+
+	// Worker order payment deadlines
+	orderDeadlineWatcher := worker.NewOrderDeadlineWatcher(services.Orders, cfg.Orders.ScanInterval)
+	go orderDeadlineWatcher.Start(ctx)
+
+	// Auth
+	jwtValidator, err := auth.NewJWTValidator(cfg.Auth.SignKey, cfg.Auth.JWKSPath, cfg.Auth.Issuer, cfg.Auth.Audience)
+	if err != nil {
+		log.Fatal(errutils.WrapPathErr(err))
+	}
+
+	// Rate limiter
+	localLimiter := ratelimit.NewLocalLimiter(cfg.RateLimiter.IdleTTL)
+	go localLimiter.Start(ctx)
+
+	policies := make([]mw.RateLimitPolicy, 0, len(cfg.RateLimiter.Policies))
+	for _, p := range cfg.RateLimiter.Policies {
+		policies = append(policies, mw.RateLimitPolicy(p))
+	}
+	rateLimiter := mw.NewRateLimiter(
+		ratelimit.NewRedisLimiter(rdb, cfg.RateLimiter.RedisPrefix), localLimiter, cb,
+		cfg.RateLimiter.RPS, cfg.RateLimiter.Burst, policies, m,
+	)
+
+	// Idempotency
+	idem := mw.NewIdempotency(
+		idempotency.NewStore(rdb, cfg.Idempotency.RedisPrefix),
+		cfg.Idempotency.TTL, cfg.Idempotency.LockTTL,
+	)
+
 	// Echo handler
 	log.Info("Initializing handlers and routes")
 	handler := echo.New()
 	handler.Validator = validator.NewCustomValidator()
-	httpapi.ConfigureRouter(handler, services, m, pg.Pool, rdb, cfg.RateLimiter.RPS, cfg.RateLimiter.Burst)
+	authenticator := mw.NewAuthenticator(
+		jwtValidator, services.APIKeys, localLimiter,
+		cfg.APIKeys.LookupRPS, cfg.APIKeys.LookupBurst, cfg.APIKeys.CacheTTL,
+	)
+	httpapi.ConfigureRouter(handler, services, m, pg.Pool, rdb, authenticator, rateLimiter, idem)
+
+	// A base URL on another host means something else serves the media files.
+	if strings.HasPrefix(cfg.Media.BaseURL, "/") {
+		handler.Static(cfg.Media.BaseURL, cfg.Media.Dir)
+	}
 
 	// HTTP server
 	log.Info("Starting http server")
@@ -177,3 +406,54 @@ func Run() {
 		log.Error(errutils.WrapPathErr(err))
 	}
 }
+
+func feePolicy(cfg config.Fees) e.FeePolicy {
+	tiers := func(in []config.FeeTier) []e.FeeTier {
+		out := make([]e.FeeTier, 0, len(in))
+		for _, t := range in {
+			out = append(out, e.FeeTier(t))
+		}
+		return out
+	}
+
+	policy := e.FeePolicy{
+		Default:    e.FeeSchedule{ListingFee: cfg.ListingFee, FinalValueTiers: tiers(cfg.FinalValueTiers)},
+		Categories: make(map[string]e.FeeSchedule, len(cfg.Categories)),
+	}
+	for _, c := range cfg.Categories {
+		policy.Categories[c.Category] = e.FeeSchedule{ListingFee: c.ListingFee, FinalValueTiers: tiers(c.FinalValueTiers)}
+	}
+	return policy
+}
+
+func incrementPolicy(cfg config.Increments) e.IncrementPolicy {
+	policy := e.IncrementPolicy{Categories: make(map[string]e.IncrementTable, len(cfg.Categories))}
+	for _, c := range cfg.Categories {
+		table := make(e.IncrementTable, 0, len(c.Tiers))
+		for _, t := range c.Tiers {
+			table = append(table, e.IncrementTier{
+				Below: money.FromFloat(t.Below, money.DefaultCurrency),
+				Step:  money.FromFloat(t.Step, money.DefaultCurrency),
+			})
+		}
+		policy.Categories[c.Category] = table
+	}
+	return policy
+}
+
+func fraudPolicy(cfg config.Fraud) e.FraudPolicy {
+	return e.FraudPolicy{
+		Weights: map[e.FraudSignal]int{
+			e.FraudSignalSharedDevice: cfg.Weights.SharedDevice,
+			e.FraudSignalSingleSeller: cfg.Weights.SingleSeller,
+			e.FraudSignalRetractions:  cfg.Weights.Retractions,
+			e.FraudSignalBidAndLose:   cfg.Weights.BidAndLose,
+		},
+		FlagScore:               cfg.FlagScore,
+		HoldScore:               cfg.HoldScore,
+		HoldBids:                cfg.HoldBids,
+		MinSingleSellerAuctions: cfg.MinSingleSellerAuctions,
+		RetractionThreshold:     cfg.RetractionThreshold,
+		LostAuctionThreshold:    cfg.LostAuctionThreshold,
+	}
+}
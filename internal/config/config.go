@@ -22,6 +22,19 @@ type (
 		RateLimiter    `yaml:"rate_limiter"`
 		Retry          `yaml:"retry"`
 		CircuitBreaker `yaml:"circuit_breaker"`
+		Notifications  `yaml:"notifications"`
+		Webhooks       `yaml:"webhooks"`
+		Auth           `yaml:"auth"`
+		APIKeys        `yaml:"api_keys"`
+		Idempotency    `yaml:"idempotency"`
+		Wallet         `yaml:"wallet"`
+		Orders         `yaml:"orders"`
+		Fees           `yaml:"fees"`
+		FX             `yaml:"fx"`
+		Increments     `yaml:"increments"`
+		Fraud          `yaml:"fraud"`
+		Media          `yaml:"media"`
+		Import         `yaml:"import"`
 	}
 
 	App struct {
@@ -33,6 +46,103 @@ type (
 		Address string `env-required:"true" env:"SERVER_ADDRESS"`
 	}
 
+	Auth struct {
+		SignKey  string `env:"JWT_SIGN_KEY"`
+		JWKSPath string `yaml:"jwks_path" env:"JWT_JWKS_PATH"`
+		Issuer   string `yaml:"issuer" env:"JWT_ISSUER"`
+		Audience string `yaml:"audience" env:"JWT_AUDIENCE"`
+	}
+
+	APIKeys struct {
+		DefaultRPS   float64       `yaml:"default_rps" env-default:"5"`
+		DefaultBurst int           `yaml:"default_burst" env-default:"10"`
+		LookupRPS    float64       `yaml:"lookup_rps" env-default:"1"`
+		LookupBurst  int           `yaml:"lookup_burst" env-default:"20"`
+		CacheTTL     time.Duration `yaml:"cache_ttl" env-default:"30s"`
+	}
+
+	Idempotency struct {
+		TTL         time.Duration `yaml:"ttl" env-default:"24h"`
+		LockTTL     time.Duration `yaml:"lock_ttl" env-default:"30s"`
+		RedisPrefix string        `yaml:"redis_prefix" env-default:"idempotency"`
+	}
+
+	Wallet struct {
+		HoldPercent float64 `yaml:"hold_percent" env-default:"100"`
+	}
+
+	Orders struct {
+		PaymentWindow time.Duration `yaml:"payment_window" env-default:"48h"`
+		OfferWindow   time.Duration `yaml:"offer_window" env-default:"24h"`
+		ScanInterval  time.Duration `yaml:"scan_interval" env-default:"1m"`
+	}
+
+	Fees struct {
+		ListingFee      float64        `yaml:"listing_fee"`
+		FinalValueTiers []FeeTier      `yaml:"final_value_tiers"`
+		Categories      []CategoryFees `yaml:"categories"`
+	}
+
+	CategoryFees struct {
+		Category        string    `yaml:"category"`
+		ListingFee      float64   `yaml:"listing_fee"`
+		FinalValueTiers []FeeTier `yaml:"final_value_tiers"`
+	}
+
+	FeeTier struct {
+		UpTo    float64 `yaml:"up_to"`
+		Percent float64 `yaml:"percent"`
+	}
+
+	FX struct {
+		RatesFile string `yaml:"rates_file" env:"FX_RATES_FILE"`
+	}
+
+	Increments struct {
+		Categories []CategoryIncrements `yaml:"categories"`
+	}
+
+	CategoryIncrements struct {
+		Category string          `yaml:"category"`
+		Tiers    []IncrementTier `yaml:"tiers"`
+	}
+
+	IncrementTier struct {
+		Below float64 `yaml:"below"`
+		Step  float64 `yaml:"step"`
+	}
+
+	Fraud struct {
+		GroupID                 string       `yaml:"group_id" env-default:"fraud-detector"`
+		HoldBids                bool         `yaml:"hold_bids" env:"FRAUD_HOLD_BIDS"`
+		FlagScore               int          `yaml:"flag_score" env-default:"50"`
+		HoldScore               int          `yaml:"hold_score" env-default:"80"`
+		MinSingleSellerAuctions int          `yaml:"min_single_seller_auctions" env-default:"3"`
+		RetractionThreshold     int          `yaml:"retraction_threshold" env-default:"3"`
+		LostAuctionThreshold    int          `yaml:"lost_auction_threshold" env-default:"3"`
+		Weights                 FraudWeights `yaml:"weights"`
+	}
+
+	Media struct {
+		Dir           string `yaml:"dir" env:"MEDIA_DIR" env-default:"data/media"`
+		BaseURL       string `yaml:"base_url" env:"MEDIA_BASE_URL" env-default:"/media"`
+		MaxSize       int64  `yaml:"max_size" env-default:"10485760"`
+		MaxPerAuction int    `yaml:"max_per_auction" env-default:"20"`
+		ThumbnailSize int    `yaml:"thumbnail_size" env-default:"320"`
+	}
+
+	Import struct {
+		BatchSize int `yaml:"batch_size" env-default:"100"`
+		MaxRows   int `yaml:"max_rows" env-default:"1000"`
+	}
+
+	FraudWeights struct {
+		SharedDevice int `yaml:"shared_device" env-default:"60"`
+		SingleSeller int `yaml:"single_seller" env-default:"30"`
+		Retractions  int `yaml:"retractions" env-default:"20"`
+		BidAndLose   int `yaml:"bid_and_lose" env-default:"30"`
+	}
+
 	Log struct {
 		Level string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
 	}
@@ -43,11 +153,13 @@ type (
 	}
 
 	Kafka struct {
-		Brokers         []string `env:"KAFKA_BROKERS" env-default:"localhost:9092"`
-		BidPlacedTopic  string   `yaml:"bid_placed_topic"`
-		BidResultTopic  string   `yaml:"bid_result_topic"`
-		AuctionEndTopic string   `yaml:"auction_ended_topic"`
-		GroupID         string   `yaml:"group_id"`
+		Brokers          []string `env:"KAFKA_BROKERS" env-default:"localhost:9092"`
+		BidPlacedTopic   string   `yaml:"bid_placed_topic"`
+		BidResultTopic   string   `yaml:"bid_result_topic"`
+		AuctionEndTopic  string   `yaml:"auction_ended_topic"`
+		OrderEventsTopic string   `yaml:"order_events_topic" env-default:"order.events"`
+		GroupID          string   `yaml:"group_id"`
+		NotifierGroupID  string   `yaml:"notifier_group_id"`
 	}
 
 	Redis struct {
@@ -55,11 +167,24 @@ type (
 		Password string        `env:"REDIS_PASSWORD"`
 		DB       int           `env:"REDIS_DB" env-default:"0"`
 		CacheTTL time.Duration `yaml:"cache_ttl" env-default:"5m"`
+		BanTTL   time.Duration `yaml:"ban_cache_ttl" env-default:"1m"`
 	}
 
 	RateLimiter struct {
-		RPS   float64 `yaml:"rps" env:"RATE_RPS"`
-		Burst int     `yaml:"burst" env:"RATE_BURST"`
+		RPS         float64           `yaml:"rps" env:"RATE_RPS"`
+		Burst       int               `yaml:"burst" env:"RATE_BURST"`
+		RedisPrefix string            `yaml:"redis_prefix" env-default:"ratelimit"`
+		IdleTTL     time.Duration     `yaml:"idle_ttl" env-default:"10m"`
+		Policies    []RateLimitPolicy `yaml:"policies"`
+	}
+
+	RateLimitPolicy struct {
+		Name     string  `yaml:"name"`
+		Method   string  `yaml:"method"`
+		Path     string  `yaml:"path"`
+		Identity string  `yaml:"identity"`
+		RPS      float64 `yaml:"rps"`
+		Burst    int     `yaml:"burst"`
 	}
 
 	Retry struct {
@@ -76,6 +201,22 @@ type (
 		MinRequests  uint32        `yaml:"min_requests"`
 		FailureRatio float64       `yaml:"failure_ratio"`
 	}
+
+	Notifications struct {
+		EndingSoonWindow time.Duration `yaml:"ending_soon_window" env-default:"15m"`
+		ScanInterval     time.Duration `yaml:"scan_interval" env-default:"1m"`
+	}
+
+	Webhooks struct {
+		GroupID          string        `yaml:"group_id" env-default:"webhook-dispatcher"`
+		Timeout          time.Duration `yaml:"timeout" env-default:"5s"`
+		MaxAttempts      int           `yaml:"max_attempts" env-default:"5"`
+		InitialWait      time.Duration `yaml:"initial_wait" env-default:"500ms"`
+		MaxWait          time.Duration `yaml:"max_wait" env-default:"30s"`
+		Multiplier       float64       `yaml:"multiplier" env-default:"2"`
+		BreakerFailures  uint32        `yaml:"breaker_failures" env-default:"5"`
+		BreakerOpenDelay time.Duration `yaml:"breaker_open_delay" env-default:"60s"`
+	}
 )
 
 func New() (*Config, error) {
@@ -0,0 +1,187 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const defaultAdminRejectReason = "rejected by administrator"
+
+type adminRoutes struct {
+	adminService  service.Admin
+	bidService    service.Bids
+	apiKeyService service.APIKeys
+}
+
+func newAdminRoutes(g *echo.Group, adminServ service.Admin, bServ service.Bids, kServ service.APIKeys) {
+	r := &adminRoutes{adminService: adminServ, bidService: bServ, apiKeyService: kServ}
+
+	g.POST("/auction/finish", r.finishAuction)
+	g.POST("/auction/cancel", r.cancelAuction)
+	g.POST("/bid/reject", r.rejectBid)
+	g.POST("/user/ban", r.banUser)
+	g.DELETE("/user/ban", r.unbanUser)
+	g.GET("/user/bans", r.listBans)
+	g.GET("/breakers", r.breakers)
+	g.POST("/apikey/quota", r.setAPIKeyQuota)
+}
+
+func (r *adminRoutes) finishAuction(c echo.Context) error {
+	var input hd.AdminAuctionInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	if err := r.adminService.ForceFinishAuction(c.Request().Context(), input.AuctionID); err != nil {
+		return adminAuctionErr(c, err)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+func (r *adminRoutes) cancelAuction(c echo.Context) error {
+	var input hd.AdminAuctionInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	if err := r.adminService.CancelAuction(c.Request().Context(), input.AuctionID); err != nil {
+		return adminAuctionErr(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func adminAuctionErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundAuction):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrAuctionNotActive):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionEnded, err.Error())
+	default:
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+}
+
+func (r *adminRoutes) rejectBid(c echo.Context) error {
+	var input hd.AdminRejectBidInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	if input.Reason == "" {
+		input.Reason = defaultAdminRejectReason
+	}
+
+	if err := r.bidService.RejectBid(c.Request().Context(), input.BidID, input.Reason); err != nil {
+		switch {
+		case errors.Is(err, se.ErrNotFoundBid), errors.Is(err, se.ErrNotFoundAuction):
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+		case errors.Is(err, se.ErrAuctionNotActive):
+			return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionEnded, err.Error())
+		default:
+			return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *adminRoutes) banUser(c echo.Context) error {
+	var input hd.BanUserInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	id, _ := mw.GetIdentity(c)
+	ban, err := r.adminService.BanUser(c.Request().Context(), sd.BanUserInput{
+		UserID:    input.UserID,
+		Reason:    input.Reason,
+		BannedBy:  id.Subject,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, se.ErrInvalidBanExpiry) {
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusCreated, hd.BanUserOutput{Ban: hmap.ToBanDTO(ban)})
+}
+
+func (r *adminRoutes) unbanUser(c echo.Context) error {
+	var input hd.UnbanUserInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	if err := r.adminService.UnbanUser(c.Request().Context(), input.UserID); err != nil {
+		if errors.Is(err, se.ErrNotFoundBan) {
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *adminRoutes) listBans(c echo.Context) error {
+	bans, err := r.adminService.ListBans(c.Request().Context())
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+	return c.JSON(http.StatusOK, hd.ListBansOutput{Bans: hmap.ToBanDTOs(bans)})
+}
+
+func (r *adminRoutes) breakers(c echo.Context) error {
+	return c.JSON(http.StatusOK, hd.CircuitBreakerStatesOutput{
+		Breakers: hmap.ToCircuitBreakerStateDTOs(r.adminService.CircuitBreakerStates()),
+	})
+}
+
+func (r *adminRoutes) setAPIKeyQuota(c echo.Context) error {
+	var input hd.SetAPIKeyQuotaInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	k, err := r.apiKeyService.SetQuota(c.Request().Context(), sd.SetAPIKeyQuotaInput{
+		KeyID: input.KeyID,
+		RPS:   input.RPS,
+		Burst: input.Burst,
+	})
+	if err != nil {
+		return apiKeyErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.APIKeyOutput{APIKey: hmap.ToAPIKeyDTO(k)})
+}
@@ -0,0 +1,199 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type allowlistRoutes struct {
+	allowlistService service.Allowlists
+}
+
+func newAllowlistRoutes(seller *echo.Group, alServ service.Allowlists) {
+	r := &allowlistRoutes{allowlistService: alServ}
+
+	seller.PUT("/auctions/visibility", r.setVisibility)
+	seller.GET("/auctions/allowlist", r.getAllowlist)
+	seller.PATCH("/auctions/allowlist", r.updateAllowlist)
+	seller.POST("/groups", r.createGroup)
+	seller.GET("/groups", r.listGroups)
+	seller.DELETE("/groups", r.deleteGroup)
+	seller.PATCH("/groups/members", r.updateGroupMembers)
+}
+
+func allowlistErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundAuction), errors.Is(err, se.ErrNotFoundGroup):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrGroupAlreadyExists):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAlreadyExists, err.Error())
+	case errors.Is(err, se.ErrInvalidVisibility):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidVisibility, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
+func (r *allowlistRoutes) setVisibility(c echo.Context) error {
+	var input hd.SetVisibilityInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	auction, err := r.allowlistService.SetVisibility(c.Request().Context(), sd.SetVisibilityInput{
+		SellerID:   sellerID,
+		AuctionID:  input.AuctionID,
+		Visibility: e.AuctionVisibility(input.Visibility),
+	})
+	if err != nil {
+		return allowlistErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.SetVisibilityOutput{Auction: hmap.ToAuctionDTO(auction)})
+}
+
+func (r *allowlistRoutes) getAllowlist(c echo.Context) error {
+	var input hd.GetAllowlistInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	list, err := r.allowlistService.GetAllowlist(c.Request().Context(), sellerID, input.AuctionID)
+	if err != nil {
+		return allowlistErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.AllowlistOutput{Allowlist: hmap.ToAllowlistDTO(list)})
+}
+
+func (r *allowlistRoutes) updateAllowlist(c echo.Context) error {
+	var input hd.UpdateAllowlistInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	list, err := r.allowlistService.UpdateAllowlist(c.Request().Context(), hmap.ToUpdateAllowlistServiceInput(sellerID, input))
+	if err != nil {
+		return allowlistErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.AllowlistOutput{Allowlist: hmap.ToAllowlistDTO(list)})
+}
+
+func (r *allowlistRoutes) createGroup(c echo.Context) error {
+	var input hd.CreateGroupInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	group, err := r.allowlistService.CreateGroup(c.Request().Context(), sellerID, input.Name, input.Members)
+	if err != nil {
+		return allowlistErr(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, hd.GroupOutput{Group: hmap.ToBidderGroupDTO(group)})
+}
+
+func (r *allowlistRoutes) listGroups(c echo.Context) error {
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	groups, err := r.allowlistService.ListGroups(c.Request().Context(), sellerID)
+	if err != nil {
+		return allowlistErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.ListGroupsOutput{Groups: hmap.ToBidderGroupDTOs(groups)})
+}
+
+func (r *allowlistRoutes) deleteGroup(c echo.Context) error {
+	var input hd.DeleteGroupInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.allowlistService.DeleteGroup(c.Request().Context(), sellerID, input.GroupID); err != nil {
+		return allowlistErr(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *allowlistRoutes) updateGroupMembers(c echo.Context) error {
+	var input hd.UpdateGroupMembersInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	group, err := r.allowlistService.UpdateGroupMembers(c.Request().Context(), sd.UpdateGroupMembersInput{
+		SellerID: sellerID,
+		GroupID:  input.GroupID,
+		Add:      input.Add,
+		Remove:   input.Remove,
+	})
+	if err != nil {
+		return allowlistErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.GroupOutput{Group: hmap.ToBidderGroupDTO(group)})
+}
@@ -0,0 +1,123 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type apiKeyRoutes struct {
+	apiKeyService service.APIKeys
+}
+
+func newAPIKeyRoutes(g *echo.Group, kServ service.APIKeys) {
+	r := &apiKeyRoutes{apiKeyService: kServ}
+
+	g.POST("", r.create)
+	g.GET("", r.list)
+	g.POST("/rotate", r.rotate)
+	g.DELETE("", r.revoke)
+}
+
+func (r *apiKeyRoutes) create(c echo.Context) error {
+	var input hd.CreateAPIKeyInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	k, plain, err := r.apiKeyService.CreateAPIKey(c.Request().Context(), sd.CreateAPIKeyInput{
+		OwnerID: ownerID,
+		Name:    input.Name,
+		Scopes:  input.Scopes,
+	})
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	out := hmap.ToAPIKeyDTO(k)
+	out.Key = plain
+	return c.JSON(http.StatusCreated, hd.APIKeyOutput{APIKey: out})
+}
+
+func (r *apiKeyRoutes) list(c echo.Context) error {
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	keys, err := r.apiKeyService.ListAPIKeys(c.Request().Context(), ownerID)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListAPIKeysOutput{APIKeys: hmap.ToAPIKeyDTOs(keys)})
+}
+
+func (r *apiKeyRoutes) rotate(c echo.Context) error {
+	var input hd.APIKeyIDInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	k, plain, err := r.apiKeyService.RotateAPIKey(c.Request().Context(), ownerID, input.KeyID)
+	if err != nil {
+		return apiKeyErr(c, err)
+	}
+
+	out := hmap.ToAPIKeyDTO(k)
+	out.Key = plain
+	return c.JSON(http.StatusOK, hd.APIKeyOutput{APIKey: out})
+}
+
+func (r *apiKeyRoutes) revoke(c echo.Context) error {
+	var input hd.APIKeyIDInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.apiKeyService.RevokeAPIKey(c.Request().Context(), ownerID, input.KeyID); err != nil {
+		return apiKeyErr(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func apiKeyErr(c echo.Context, err error) error {
+	if errors.Is(err, se.ErrNotFoundAPIKey) {
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
@@ -8,9 +8,13 @@ import (
 	hd "auction-platform/internal/controller/http/v1/dto"
 	he "auction-platform/internal/controller/http/v1/errors"
 	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
 	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
 	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
 	se "auction-platform/internal/service/errors"
+	"auction-platform/pkg/money"
 
 	"github.com/labstack/echo/v4"
 )
@@ -19,29 +23,37 @@ type auctionRoutes struct {
 	auctionService service.Auctions
 }
 
-func newAuctionRoutes(g *echo.Group, aServ service.Auctions) {
+func newAuctionRoutes(g *echo.Group, aServ service.Auctions, authMW, idemMW echo.MiddlewareFunc) {
 	r := &auctionRoutes{auctionService: aServ}
 
-	g.POST("/create", r.create)
-	g.GET("/get", r.get)
-	g.GET("/list", r.list)
+	g.POST("/create", r.create, authMW, mw.RequireRoles(e.RoleSeller, e.RoleAdmin), mw.RequireScopes(e.ScopeAuctionsWrite), idemMW)
+	g.GET("/get", r.get, mw.RequireScopes(e.ScopeAuctionsRead))
+	g.GET("/list", r.list, mw.RequireScopes(e.ScopeAuctionsRead))
+	g.GET("/mine", r.listMine, authMW, mw.RequireScopes(e.ScopeAuctionsRead))
+	g.DELETE("/delete", r.delete, authMW, mw.RequireRoles(e.RoleSeller, e.RoleAdmin), mw.RequireScopes(e.ScopeAuctionsWrite))
 }
 
 func (r *auctionRoutes) create(c echo.Context) error {
 	var input hd.CreateAuctionInput
 	if err := c.Bind(&input); err != nil {
-		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+		return ut.NewBindErrJSON(c, err)
 	}
 	if err := c.Validate(input); err != nil {
 		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
 	}
 
-	auction, err := r.auctionService.CreateAuction(c.Request().Context(), hmap.ToCreateAuctionServiceInput(input))
+	sellerID, err := ut.ResolveSubject(c, input.SellerID)
 	if err != nil {
-		if errors.Is(err, se.ErrAuctionAlreadyExists) {
-			return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAlreadyExists, err.Error())
-		}
-		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+		return err
+	}
+	input.SellerID = sellerID
+
+	serviceIn := hmap.ToCreateAuctionServiceInput(input)
+	serviceIn.ClientIP, serviceIn.DeviceID = ut.ClientDevice(c)
+
+	auction, err := r.auctionService.CreateAuction(c.Request().Context(), serviceIn)
+	if err != nil {
+		return createAuctionErr(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, hd.CreateAuctionOutput{
@@ -49,6 +61,37 @@ func (r *auctionRoutes) create(c echo.Context) error {
 	})
 }
 
+// createAuctionErr maps the errors of checking and creating an auction.
+func createAuctionErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrAuctionAlreadyExists):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAlreadyExists, err.Error())
+	case errors.Is(err, se.ErrUserBanned):
+		return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeUserBanned, err.Error())
+	case errors.Is(err, se.ErrInvalidAmount):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidAmount, err.Error())
+	case errors.Is(err, se.ErrUnsupportedCurrency):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeUnsupportedCurrency, err.Error())
+	case errors.Is(err, se.ErrNoFXRate):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeFXRateUnavailable, err.Error())
+	case errors.Is(err, se.ErrNoBidIncrement), errors.Is(err, se.ErrInvalidIncrements):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidIncrements, err.Error())
+	case errors.Is(err, se.ErrInvalidQuantity):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidQuantity, err.Error())
+	case errors.Is(err, se.ErrInvalidPricing):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidPricing, err.Error())
+	case errors.Is(err, se.ErrInvalidAuctionType):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidAuctionType, err.Error())
+	case errors.Is(err, se.ErrInvalidVisibility):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidVisibility, err.Error())
+	case errors.Is(err, se.ErrInvalidEligibility):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidEligibility, err.Error())
+	case errors.Is(err, se.ErrInvalidRelistPolicy):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidRelistPolicy, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
 func (r *auctionRoutes) get(c echo.Context) error {
 	var input hd.GetAuctionInput
 	if err := c.Bind(&input); err != nil {
@@ -58,10 +101,22 @@ func (r *auctionRoutes) get(c echo.Context) error {
 		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
 	}
 
-	auction, err := r.auctionService.GetAuction(c.Request().Context(), input.AuctionID)
+	var viewerID string
+	if id, ok := mw.GetIdentity(c); ok {
+		viewerID = id.Subject
+	}
+
+	auction, err := r.auctionService.GetAuction(c.Request().Context(), sd.GetAuctionInput{
+		AuctionID: input.AuctionID,
+		ViewerID:  viewerID,
+		Currency:  ut.ParseCurrency(input.Currency),
+	})
 	if err != nil {
-		if errors.Is(err, se.ErrNotFoundAuction) {
+		switch {
+		case errors.Is(err, se.ErrNotFoundAuction):
 			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, he.ErrNotFound.Error())
+		case errors.Is(err, se.ErrUnsupportedCurrency):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeUnsupportedCurrency, err.Error())
 		}
 		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
 	}
@@ -84,8 +139,34 @@ func (r *auctionRoutes) list(c echo.Context) error {
 		input.PageSize = 20
 	}
 
-	auctions, total, err := r.auctionService.ListActive(c.Request().Context(), input.Page, input.PageSize)
+	in := sd.ListAuctionsInput{
+		Page:     input.Page,
+		PageSize: input.PageSize,
+		Currency: ut.ParseCurrency(input.Currency),
+	}
+	priceCurrency := in.Currency
+	if priceCurrency == "" {
+		priceCurrency = money.DefaultCurrency
+	}
+	var err error
+	if in.MinPrice, err = ut.ParseOptionalMoney(input.MinPrice, priceCurrency); err == nil {
+		in.MaxPrice, err = ut.ParseOptionalMoney(input.MaxPrice, priceCurrency)
+	}
 	if err != nil {
+		if errors.Is(err, money.ErrUnsupportedCurrency) {
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeUnsupportedCurrency, se.ErrUnsupportedCurrency.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidAmount, he.ErrInvalidAmount.Error())
+	}
+
+	auctions, total, err := r.auctionService.ListActive(c.Request().Context(), in)
+	if err != nil {
+		switch {
+		case errors.Is(err, se.ErrUnsupportedCurrency):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeUnsupportedCurrency, err.Error())
+		case errors.Is(err, se.ErrNoFXRate):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeFXRateUnavailable, err.Error())
+		}
 		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
 	}
 
@@ -101,3 +182,64 @@ func (r *auctionRoutes) list(c echo.Context) error {
 		TotalPages: int(math.Ceil(float64(total) / float64(input.PageSize))),
 	})
 }
+
+func (r *auctionRoutes) listMine(c echo.Context) error {
+	var input hd.ListSellerAuctionsInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	auctions, total, err := r.auctionService.ListBySeller(c.Request().Context(), sellerID, input.Page, input.PageSize)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListSellerAuctionsOutput{
+		Auctions:   hmap.ToSellerAuctionDTOs(auctions),
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(input.PageSize))),
+	})
+}
+
+func (r *auctionRoutes) delete(c echo.Context) error {
+	var input hd.DeleteAuctionInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.auctionService.DeleteAuction(c.Request().Context(), sellerID, input.AuctionID); err != nil {
+		switch {
+		case errors.Is(err, se.ErrNotFoundAuction):
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, he.ErrNotFound.Error())
+		case errors.Is(err, se.ErrAuctionHasActivity):
+			return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionHasActivity, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
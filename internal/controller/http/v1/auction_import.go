@@ -0,0 +1,100 @@
+package httpapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const maxImportBytes = 5 << 20
+
+type importRoutes struct {
+	importService service.Imports
+}
+
+func newImportRoutes(g *echo.Group, iServ service.Imports, authMW, idemMW echo.MiddlewareFunc) {
+	r := &importRoutes{importService: iServ}
+
+	g.POST("/import", r.importAuctions, authMW, mw.RequireRoles(e.RoleSeller, e.RoleAdmin), mw.RequireScopes(e.ScopeAuctionsWrite), idemMW)
+}
+
+// importAuctions reads CSV or JSONL from the request body, picked by the
+// format parameter or else the Content-Type, and reports on every row.
+func (r *importRoutes) importAuctions(c echo.Context) error {
+	var input hd.ImportAuctionsInput
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	if input.Format == "" {
+		input.Format = ut.ImportFormatJSONL
+		if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), "text/csv") {
+			input.Format = ut.ImportFormatCSV
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxImportBytes+1))
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if len(body) > maxImportBytes {
+		return ut.NewErrReasonJSON(c, http.StatusRequestEntityTooLarge, he.ErrCodeImportTooLarge, he.ErrImportTooLarge.Error())
+	}
+
+	parsed, err := ut.ParseImport(input.Format, body)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	rows := make([]sd.ImportRow, 0, len(parsed))
+	for _, p := range parsed {
+		row := sd.ImportRow{Line: p.Line, Error: p.Err}
+		if row.Error == "" {
+			if err := c.Validate(p.Input); err != nil {
+				row.Error = err.Error()
+			} else if p.Input.SellerID != "" && p.Input.SellerID != sellerID {
+				row.Error = he.ErrIdentityMismatch.Error()
+			}
+		}
+		row.Auction = hmap.ToCreateAuctionServiceInput(p.Input)
+		rows = append(rows, row)
+	}
+
+	serviceIn := sd.ImportAuctionsInput{SellerID: sellerID, Rows: rows, DryRun: input.DryRun}
+	serviceIn.ClientIP, serviceIn.DeviceID = ut.ClientDevice(c)
+
+	report, err := r.importService.ImportAuctions(c.Request().Context(), serviceIn)
+	if err != nil {
+		switch {
+		case errors.Is(err, se.ErrEmptyImport):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+		case errors.Is(err, se.ErrTooManyImportRows):
+			return ut.NewErrReasonJSON(c, http.StatusRequestEntityTooLarge, he.ErrCodeImportTooLarge, err.Error())
+		case errors.Is(err, se.ErrUserBanned):
+			return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeUserBanned, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hmap.ToImportReportOutput(report))
+}
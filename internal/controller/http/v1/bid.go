@@ -2,12 +2,15 @@ package httpapi
 
 import (
 	"errors"
+	"math"
 	"net/http"
 
 	hd "auction-platform/internal/controller/http/v1/dto"
 	he "auction-platform/internal/controller/http/v1/errors"
 	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
 	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
 	"auction-platform/internal/service"
 	se "auction-platform/internal/service/errors"
 
@@ -18,23 +21,33 @@ type bidRoutes struct {
 	bidService service.Bids
 }
 
-func newBidRoutes(g *echo.Group, bServ service.Bids) {
+func newBidRoutes(g *echo.Group, bServ service.Bids, authMW, idemMW echo.MiddlewareFunc) {
 	r := &bidRoutes{bidService: bServ}
 
-	g.POST("/place", r.placeBid)
-	g.GET("/list", r.listByAuction)
+	g.POST("/place", r.placeBid, authMW, mw.RequireRoles(e.RoleBuyer, e.RoleAdmin), mw.RequireScopes(e.ScopeBidsWrite), idemMW)
+	g.GET("/list", r.listByAuction, mw.RequireScopes(e.ScopeBidsRead))
+	g.GET("/mine", r.listMine, authMW, mw.RequireScopes(e.ScopeBidsRead))
 }
 
 func (r *bidRoutes) placeBid(c echo.Context) error {
 	var input hd.PlaceBidInput
 	if err := c.Bind(&input); err != nil {
-		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+		return ut.NewBindErrJSON(c, err)
 	}
 	if err := c.Validate(input); err != nil {
 		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
 	}
 
-	bid, err := r.bidService.PlaceBid(c.Request().Context(), hmap.ToPlaceBidServiceInput(input))
+	bidderID, err := ut.ResolveSubject(c, input.BidderID)
+	if err != nil {
+		return err
+	}
+	input.BidderID = bidderID
+
+	serviceIn := hmap.ToPlaceBidServiceInput(input)
+	serviceIn.ClientIP, serviceIn.DeviceID = ut.ClientDevice(c)
+
+	bid, err := r.bidService.PlaceBid(c.Request().Context(), serviceIn)
 	if err != nil {
 		switch {
 		case errors.Is(err, se.ErrNotFoundAuction):
@@ -43,6 +56,14 @@ func (r *bidRoutes) placeBid(c echo.Context) error {
 			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeBidTooLow, err.Error())
 		case errors.Is(err, se.ErrAuctionNotActive):
 			return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionEnded, err.Error())
+		case errors.Is(err, se.ErrUserBanned):
+			return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeUserBanned, err.Error())
+		case errors.Is(err, se.ErrBidAlreadyExists):
+			return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAlreadyExists, err.Error())
+		case errors.Is(err, se.ErrInvalidAmount):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidAmount, err.Error())
+		case errors.Is(err, se.ErrInvalidQuantity):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidQuantity, err.Error())
 		default:
 			return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
 		}
@@ -62,8 +83,16 @@ func (r *bidRoutes) listByAuction(c echo.Context) error {
 		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
 	}
 
-	bids, err := r.bidService.GetBidsByAuction(c.Request().Context(), input.AuctionID, input.Limit)
+	var viewerID string
+	if id, ok := mw.GetIdentity(c); ok {
+		viewerID = id.Subject
+	}
+
+	bids, err := r.bidService.GetBidsByAuction(c.Request().Context(), input.AuctionID, viewerID, input.Limit)
 	if err != nil {
+		if errors.Is(err, se.ErrNotFoundAuction) {
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, he.ErrNotFound.Error())
+		}
 		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
 	}
 
@@ -71,3 +100,37 @@ func (r *bidRoutes) listByAuction(c echo.Context) error {
 		Bids: hmap.ToBidDTOs(bids),
 	})
 }
+
+func (r *bidRoutes) listMine(c echo.Context) error {
+	var input hd.ListBidderAuctionsInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	bidderID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	auctions, total, err := r.bidService.ListByBidder(c.Request().Context(), bidderID, input.Page, input.PageSize)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListBidderAuctionsOutput{
+		Auctions:   hmap.ToBidderAuctionDTOs(auctions),
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(input.PageSize))),
+	})
+}
@@ -0,0 +1,99 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type blocklistRoutes struct {
+	blocklistService service.Blocklists
+}
+
+func newBlocklistRoutes(seller *echo.Group, bServ service.Blocklists) {
+	r := &blocklistRoutes{blocklistService: bServ}
+
+	seller.POST("/blocked", r.block)
+	seller.GET("/blocked", r.list)
+	seller.DELETE("/blocked", r.unblock)
+}
+
+func blocklistErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundBlockedBidder):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrCannotBlockSelf):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
+func (r *blocklistRoutes) block(c echo.Context) error {
+	var input hd.BlockBidderInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	blocked, err := r.blocklistService.BlockBidder(c.Request().Context(), sd.BlockBidderInput{
+		SellerID: sellerID,
+		BidderID: input.BidderID,
+		Reason:   input.Reason,
+	})
+	if err != nil {
+		return blocklistErr(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, hd.BlockedBidderOutput{Blocked: hmap.ToBlockedBidderDTO(blocked)})
+}
+
+func (r *blocklistRoutes) list(c echo.Context) error {
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	blocked, err := r.blocklistService.ListBlocked(c.Request().Context(), sellerID)
+	if err != nil {
+		return blocklistErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.ListBlockedBiddersOutput{Blocked: hmap.ToBlockedBidderDTOs(blocked)})
+}
+
+func (r *blocklistRoutes) unblock(c echo.Context) error {
+	var input hd.UnblockBidderInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.blocklistService.UnblockBidder(c.Request().Context(), sellerID, input.BidderID); err != nil {
+		return blocklistErr(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,53 @@
+package httpdto
+
+import "time"
+
+type AdminAuctionInput struct {
+	AuctionID string `json:"auction_id" validate:"required,max=100"`
+}
+
+type AdminRejectBidInput struct {
+	BidID  string `json:"bid_id" validate:"required,max=100"`
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// BanUserInput.ExpiresAt is omitted for a permanent ban.
+type BanUserInput struct {
+	UserID    string     `json:"user_id" validate:"required,max=100"`
+	Reason    string     `json:"reason" validate:"max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type UnbanUserInput struct {
+	UserID string `query:"user_id" validate:"required,max=100"`
+}
+
+type BanDTO struct {
+	UserID    string     `json:"user_id"`
+	Reason    string     `json:"reason"`
+	BannedBy  string     `json:"banned_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type BanUserOutput struct {
+	Ban BanDTO `json:"ban"`
+}
+
+type ListBansOutput struct {
+	Bans []BanDTO `json:"bans"`
+}
+
+type CircuitBreakerStateDTO struct {
+	Name                 string `json:"name"`
+	State                string `json:"state"`
+	Requests             uint32 `json:"requests"`
+	TotalSuccesses       uint32 `json:"total_successes"`
+	TotalFailures        uint32 `json:"total_failures"`
+	ConsecutiveSuccesses uint32 `json:"consecutive_successes"`
+	ConsecutiveFailures  uint32 `json:"consecutive_failures"`
+}
+
+type CircuitBreakerStatesOutput struct {
+	Breakers []CircuitBreakerStateDTO `json:"breakers"`
+}
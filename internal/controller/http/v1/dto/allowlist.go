@@ -0,0 +1,64 @@
+package httpdto
+
+import "time"
+
+type SetVisibilityInput struct {
+	AuctionID  string `json:"auction_id" validate:"required,max=100"`
+	Visibility string `json:"visibility" validate:"required,oneof=PUBLIC UNLISTED PRIVATE"`
+}
+
+type SetVisibilityOutput struct {
+	Auction AuctionDTO `json:"auction"`
+}
+
+type GetAllowlistInput struct {
+	AuctionID string `query:"auction_id" validate:"required,max=100"`
+}
+
+type UpdateAllowlistInput struct {
+	AuctionID     string   `json:"auction_id" validate:"required,max=100"`
+	AddBidders    []string `json:"add_bidders" validate:"omitempty,max=500,dive,required,max=100"`
+	RemoveBidders []string `json:"remove_bidders" validate:"omitempty,max=500,dive,required,max=100"`
+	AddGroups     []int64  `json:"add_groups" validate:"omitempty,max=50,dive,gt=0"`
+	RemoveGroups  []int64  `json:"remove_groups" validate:"omitempty,max=50,dive,gt=0"`
+}
+
+type AllowlistDTO struct {
+	AuctionID string           `json:"auction_id"`
+	BidderIDs []string         `json:"bidder_ids"`
+	Groups    []BidderGroupDTO `json:"groups"`
+}
+
+type AllowlistOutput struct {
+	Allowlist AllowlistDTO `json:"allowlist"`
+}
+
+type CreateGroupInput struct {
+	Name    string   `json:"name" validate:"required,max=100"`
+	Members []string `json:"members" validate:"omitempty,max=500,dive,required,max=100"`
+}
+
+type BidderGroupDTO struct {
+	GroupID   int64     `json:"group_id"`
+	Name      string    `json:"name"`
+	Members   []string  `json:"members"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GroupOutput struct {
+	Group BidderGroupDTO `json:"group"`
+}
+
+type ListGroupsOutput struct {
+	Groups []BidderGroupDTO `json:"groups"`
+}
+
+type DeleteGroupInput struct {
+	GroupID int64 `query:"group_id" validate:"required,gt=0"`
+}
+
+type UpdateGroupMembersInput struct {
+	GroupID int64    `json:"group_id" validate:"required,gt=0"`
+	Add     []string `json:"add" validate:"omitempty,max=500,dive,required,max=100"`
+	Remove  []string `json:"remove" validate:"omitempty,max=500,dive,required,max=100"`
+}
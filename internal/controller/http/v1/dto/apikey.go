@@ -0,0 +1,39 @@
+package httpdto
+
+import "time"
+
+type CreateAPIKeyInput struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=auctions:read auctions:write bids:read bids:write"`
+}
+
+type APIKeyIDInput struct {
+	KeyID int64 `json:"key_id" query:"key_id" validate:"required,min=1"`
+}
+
+type SetAPIKeyQuotaInput struct {
+	KeyID int64   `json:"key_id" validate:"required,min=1"`
+	RPS   float64 `json:"rps" validate:"required,gt=0,max=1000"`
+	Burst int     `json:"burst" validate:"required,min=1,max=10000"`
+}
+
+type APIKeyDTO struct {
+	KeyID          int64      `json:"key_id"`
+	Name           string     `json:"name"`
+	Prefix         string     `json:"prefix"`
+	Key            string     `json:"key,omitempty"`
+	Scopes         []string   `json:"scopes"`
+	RateLimitRPS   float64    `json:"rate_limit_rps"`
+	RateLimitBurst int        `json:"rate_limit_burst"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RotatedAt      *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+type APIKeyOutput struct {
+	APIKey APIKeyDTO `json:"api_key"`
+}
+
+type ListAPIKeysOutput struct {
+	APIKeys []APIKeyDTO `json:"api_keys"`
+}
@@ -1,29 +1,92 @@
 package httpdto
 
-import "time"
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
 
 type CreateAuctionInput struct {
-	AuctionID   string  `json:"auction_id" validate:"required,max=100"`
-	Title       string  `json:"title" validate:"required,max=200"`
-	Description string  `json:"description" validate:"max=2000"`
-	SellerID    string  `json:"seller_id" validate:"required,max=100"`
-	StartPrice  float64 `json:"start_price" validate:"required,gt=0"`
-	MinStep     float64 `json:"min_step" validate:"required,gt=0"`
-	DurationMin int     `json:"duration_min" validate:"required,min=1,max=10080"`
+	AuctionID string `json:"auction_id" validate:"required,max=100"`
+	SellerID  string `json:"seller_id" validate:"omitempty,max=100"`
+	AuctionSettingsDTO
+}
+
+// AuctionSettingsDTO is everything of a new auction but its ID and seller,
+// as saved in templates.
+type AuctionSettingsDTO struct {
+	Title        string             `json:"title" validate:"required,max=200"`
+	Description  string             `json:"description" validate:"max=2000"`
+	Category     string             `json:"category" validate:"omitempty,max=50"`
+	StartPrice   money.Money        `json:"start_price" validate:"required,gt=0"`
+	MinStep      money.Money        `json:"min_step" validate:"omitempty,gt=0"`
+	Increments   []IncrementTierDTO `json:"increments" validate:"omitempty,max=20,dive"`
+	Currency     string             `json:"currency" validate:"omitempty,len=3"`
+	Quantity     int                `json:"quantity" validate:"omitempty,min=1,max=10000"`
+	Pricing      string             `json:"pricing" validate:"omitempty,oneof=PAY_AS_BID UNIFORM"`
+	Type         string             `json:"type" validate:"omitempty,oneof=FORWARD REVERSE"`
+	Visibility   string             `json:"visibility" validate:"omitempty,oneof=PUBLIC UNLISTED PRIVATE"`
+	Eligibility  *EligibilityDTO    `json:"eligibility"`
+	Invited      []string           `json:"invited_bidders" validate:"omitempty,max=500,dive,required,max=100"`
+	DurationMin  int                `json:"duration_min" validate:"required,min=1,max=10080"`
+	ReservePrice money.Money        `json:"reserve_price" validate:"gte=0"`
+	Relist       *RelistPolicyDTO   `json:"relist"`
+}
+
+type RelistPolicyDTO struct {
+	MaxRelists  int `json:"max_relists" validate:"min=0,max=52"`
+	PriceCutPct int `json:"price_cut_pct" validate:"min=0,max=90"`
 }
 
 type AuctionDTO struct {
-	AuctionID   string     `json:"auction_id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	SellerID    string     `json:"seller_id"`
-	StartPrice  float64    `json:"start_price"`
-	CurrentBid  float64    `json:"current_bid"`
-	MinStep     float64    `json:"min_step"`
-	Status      string     `json:"status"`
-	WinnerID    string     `json:"winner_id,omitempty"`
-	EndsAt      *time.Time `json:"ends_at"`
-	CreatedAt   *time.Time `json:"created_at"`
+	AuctionID    string              `json:"auction_id"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	Category     string              `json:"category,omitempty"`
+	SellerID     string              `json:"seller_id"`
+	StartPrice   money.Money         `json:"start_price"`
+	CurrentBid   money.Money         `json:"current_bid"`
+	MinStep      money.Money         `json:"min_step"`
+	NextMinBid   *money.Money        `json:"next_min_bid,omitempty"`
+	NextMaxBid   *money.Money        `json:"next_max_bid,omitempty"`
+	Increments   []IncrementTierDTO  `json:"increments,omitempty"`
+	Currency     string              `json:"currency"`
+	Quantity     int                 `json:"quantity"`
+	Pricing      string              `json:"pricing"`
+	Type         string              `json:"type"`
+	Visibility   string              `json:"visibility"`
+	Eligibility  *EligibilityDTO     `json:"eligibility,omitempty"`
+	Status       string              `json:"status"`
+	WinnerID     string              `json:"winner_id,omitempty"`
+	EndsAt       *time.Time          `json:"ends_at"`
+	CreatedAt    *time.Time          `json:"created_at"`
+	Converted    *ConvertedPricesDTO `json:"converted,omitempty"`
+	SellerRating *RatingSummaryDTO   `json:"seller_rating,omitempty"`
+	Answers      []QuestionDTO       `json:"answers,omitempty"`
+	Media        []MediaDTO          `json:"media,omitempty"`
+	RelistedFrom string              `json:"relisted_from,omitempty"`
+}
+
+type EligibilityDTO struct {
+	RequireRegistration bool        `json:"require_registration"`
+	Deposit             money.Money `json:"deposit" validate:"gte=0"`
+	MinAccountAgeDays   int         `json:"min_account_age_days" validate:"min=0,max=3650"`
+	MaxWinningAuctions  int         `json:"max_winning_auctions" validate:"min=0,max=1000"`
+}
+
+type IncrementTierDTO struct {
+	Below money.Money `json:"below"`
+	Step  money.Money `json:"step" validate:"gt=0"`
+}
+
+type ConvertedPricesDTO struct {
+	Currency   string       `json:"currency"`
+	Rate       float64      `json:"rate"`
+	StartPrice money.Money  `json:"start_price"`
+	CurrentBid money.Money  `json:"current_bid"`
+	MinStep    money.Money  `json:"min_step"`
+	NextMinBid *money.Money `json:"next_min_bid,omitempty"`
+	NextMaxBid *money.Money `json:"next_max_bid,omitempty"`
 }
 
 type CreateAuctionOutput struct {
@@ -32,6 +95,11 @@ type CreateAuctionOutput struct {
 
 type GetAuctionInput struct {
 	AuctionID string `query:"auction_id" validate:"required,max=100"`
+	Currency  string `query:"currency" validate:"omitempty,len=3"`
+}
+
+type DeleteAuctionInput struct {
+	AuctionID string `query:"auction_id" validate:"required,max=100"`
 }
 
 type GetAuctionOutput struct {
@@ -39,8 +107,11 @@ type GetAuctionOutput struct {
 }
 
 type ListAuctionsInput struct {
-	Page     int `query:"page"`
-	PageSize int `query:"page_size"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"page_size"`
+	Currency string `query:"currency"`
+	MinPrice string `query:"min_price"`
+	MaxPrice string `query:"max_price"`
 }
 
 type ListAuctionsOutput struct {
@@ -50,3 +121,23 @@ type ListAuctionsOutput struct {
 	PageSize   int          `json:"page_size"`
 	TotalPages int          `json:"total_pages"`
 }
+
+type ListSellerAuctionsInput struct {
+	Page     int `query:"page"`
+	PageSize int `query:"page_size"`
+}
+
+type SellerAuctionDTO struct {
+	AuctionDTO
+	BidsCount   int        `json:"bids_count"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	RelistCount int        `json:"relist_count,omitempty"`
+}
+
+type ListSellerAuctionsOutput struct {
+	Auctions   []SellerAuctionDTO `json:"auctions"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
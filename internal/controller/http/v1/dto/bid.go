@@ -1,21 +1,28 @@
 package httpdto
 
-import "time"
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
 
 type PlaceBidInput struct {
-	BidID     string  `json:"bid_id" validate:"required,max=100"`
-	AuctionID string  `json:"auction_id" validate:"required,max=100"`
-	BidderID  string  `json:"bidder_id" validate:"required,max=100"`
-	Amount    float64 `json:"amount" validate:"required,gt=0"`
+	BidID     string      `json:"bid_id" validate:"required,max=100"`
+	AuctionID string      `json:"auction_id" validate:"required,max=100"`
+	BidderID  string      `json:"bidder_id" validate:"omitempty,max=100"`
+	Amount    money.Money `json:"amount" validate:"required,gt=0"`
+	Quantity  int         `json:"quantity" validate:"omitempty,min=1,max=10000"`
 }
 
 type BidDTO struct {
-	BidID     string    `json:"bid_id"`
-	AuctionID string    `json:"auction_id"`
-	BidderID  string    `json:"bidder_id"`
-	Amount    float64   `json:"amount"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	BidID     string      `json:"bid_id"`
+	AuctionID string      `json:"auction_id"`
+	BidderID  string      `json:"bidder_id"`
+	Amount    money.Money `json:"amount"`
+	Currency  string      `json:"currency"`
+	Quantity  int         `json:"quantity"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
 }
 
 type PlaceBidOutput struct {
@@ -30,3 +37,30 @@ type GetBidsInput struct {
 type GetBidsOutput struct {
 	Bids []BidDTO `json:"bids"`
 }
+
+type ListBidderAuctionsInput struct {
+	Page     int `query:"page"`
+	PageSize int `query:"page_size"`
+}
+
+type BidderAuctionDTO struct {
+	AuctionID     string      `json:"auction_id"`
+	Title         string      `json:"title"`
+	AuctionStatus string      `json:"auction_status"`
+	CurrentBid    money.Money `json:"current_bid"`
+	MyHighestBid  money.Money `json:"my_highest_bid"`
+	Currency      string      `json:"currency"`
+	MyBidsCount   int         `json:"my_bids_count"`
+	IsWinning     bool        `json:"is_winning"`
+	Outcome       string      `json:"outcome"`
+	EndsAt        *time.Time  `json:"ends_at"`
+	FinishedAt    *time.Time  `json:"finished_at,omitempty"`
+}
+
+type ListBidderAuctionsOutput struct {
+	Auctions   []BidderAuctionDTO `json:"auctions"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
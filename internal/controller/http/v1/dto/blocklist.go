@@ -0,0 +1,26 @@
+package httpdto
+
+import "time"
+
+type BlockBidderInput struct {
+	BidderID string `json:"bidder_id" validate:"required,max=100"`
+	Reason   string `json:"reason" validate:"max=500"`
+}
+
+type UnblockBidderInput struct {
+	BidderID string `query:"bidder_id" validate:"required,max=100"`
+}
+
+type BlockedBidderDTO struct {
+	BidderID  string    `json:"bidder_id"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BlockedBidderOutput struct {
+	Blocked BlockedBidderDTO `json:"blocked"`
+}
+
+type ListBlockedBiddersOutput struct {
+	Blocked []BlockedBidderDTO `json:"blocked"`
+}
@@ -0,0 +1,43 @@
+package httpdto
+
+import "time"
+
+type ListFraudFlagsInput struct {
+	Status   string `query:"status" validate:"omitempty,oneof=OPEN CONFIRMED DISMISSED"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"page_size"`
+}
+
+type ReviewFraudFlagInput struct {
+	FlagID  int64  `json:"flag_id" validate:"required,min=1"`
+	Confirm bool   `json:"confirm"`
+	Note    string `json:"note" validate:"max=500"`
+}
+
+type FraudFlagDTO struct {
+	FlagID     int64      `json:"flag_id"`
+	BidID      string     `json:"bid_id"`
+	AuctionID  string     `json:"auction_id"`
+	BidderID   string     `json:"bidder_id"`
+	SellerID   string     `json:"seller_id"`
+	Score      int        `json:"score"`
+	Signals    []string   `json:"signals"`
+	Held       bool       `json:"held"`
+	Status     string     `json:"status"`
+	ReviewedBy string     `json:"reviewed_by,omitempty"`
+	Note       string     `json:"note,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+type FraudFlagOutput struct {
+	Flag FraudFlagDTO `json:"flag"`
+}
+
+type ListFraudFlagsOutput struct {
+	Flags      []FraudFlagDTO `json:"flags"`
+	Total      int64          `json:"total"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalPages int            `json:"total_pages"`
+}
@@ -0,0 +1,18 @@
+package httpdto
+
+import "time"
+
+type FXRateDTO struct {
+	Currency  string    `json:"currency"`
+	Rate      float64   `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListFXRatesOutput struct {
+	Base  string      `json:"base"`
+	Rates []FXRateDTO `json:"rates"`
+}
+
+type SetFXRatesInput struct {
+	Rates map[string]float64 `json:"rates" validate:"required,min=1"`
+}
@@ -0,0 +1,23 @@
+package httpdto
+
+type ImportAuctionsInput struct {
+	Format string `query:"format" validate:"omitempty,oneof=csv jsonl"`
+	DryRun bool   `query:"dry_run"`
+}
+
+type ImportRowDTO struct {
+	Line      int    `json:"line"`
+	AuctionID string `json:"auction_id,omitempty"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ImportReportOutput struct {
+	DryRun  bool           `json:"dry_run"`
+	Total   int            `json:"total"`
+	Valid   int            `json:"valid"`
+	Created int            `json:"created"`
+	Invalid int            `json:"invalid"`
+	Failed  int            `json:"failed"`
+	Rows    []ImportRowDTO `json:"rows"`
+}
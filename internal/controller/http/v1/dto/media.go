@@ -0,0 +1,36 @@
+package httpdto
+
+import "time"
+
+type UploadMediaInput struct {
+	AuctionID string `form:"auction_id" validate:"required,max=100"`
+}
+
+type DeleteMediaInput struct {
+	MediaID int64 `query:"media_id" validate:"required,gt=0"`
+}
+
+type ReorderMediaInput struct {
+	AuctionID string  `json:"auction_id" validate:"required,max=100"`
+	MediaIDs  []int64 `json:"media_ids" validate:"required,min=1,max=100,dive,gt=0"`
+}
+
+type MediaDTO struct {
+	MediaID      int64     `json:"media_id"`
+	Position     int       `json:"position"`
+	Kind         string    `json:"kind"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	FileName     string    `json:"file_name,omitempty"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type MediaOutput struct {
+	Media MediaDTO `json:"media"`
+}
+
+type ListMediaOutput struct {
+	Media []MediaDTO `json:"media"`
+}
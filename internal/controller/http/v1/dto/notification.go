@@ -0,0 +1,36 @@
+package httpdto
+
+import "time"
+
+type ListNotificationsInput struct {
+	UnreadOnly bool `query:"unread_only"`
+	Page       int  `query:"page"`
+	PageSize   int  `query:"page_size"`
+}
+
+type NotificationDTO struct {
+	NotificationID int64      `json:"notification_id"`
+	AuctionID      string     `json:"auction_id"`
+	Type           string     `json:"type"`
+	Message        string     `json:"message"`
+	IsRead         bool       `json:"is_read"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+}
+
+type ListNotificationsOutput struct {
+	Notifications []NotificationDTO `json:"notifications"`
+	Unread        int64             `json:"unread"`
+	Total         int64             `json:"total"`
+	Page          int               `json:"page"`
+	PageSize      int               `json:"page_size"`
+	TotalPages    int               `json:"total_pages"`
+}
+
+type MarkNotificationsReadInput struct {
+	NotificationIDs []int64 `json:"notification_ids"`
+}
+
+type MarkNotificationsReadOutput struct {
+	Updated int64 `json:"updated"`
+}
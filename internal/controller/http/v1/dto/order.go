@@ -0,0 +1,49 @@
+package httpdto
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+type ListOrdersInput struct {
+	Role     string `query:"role" validate:"omitempty,oneof=buyer seller"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"page_size"`
+}
+
+type OrderIDInput struct {
+	OrderID int64 `json:"order_id" query:"order_id" validate:"required,gt=0"`
+}
+
+type OrderDTO struct {
+	OrderID        int64       `json:"order_id"`
+	AuctionID      string      `json:"auction_id"`
+	BuyerID        string      `json:"buyer_id"`
+	SellerID       string      `json:"seller_id"`
+	Kind           string      `json:"kind"`
+	Status         string      `json:"status"`
+	Amount         money.Money `json:"amount"`
+	AmountPaid     money.Money `json:"amount_paid"`
+	AmountDue      money.Money `json:"amount_due"`
+	Quantity       int         `json:"quantity"`
+	Currency       string      `json:"currency"`
+	FXRate         float64     `json:"fx_rate"`
+	PaymentDueAt   *time.Time  `json:"payment_due_at,omitempty"`
+	OfferExpiresAt *time.Time  `json:"offer_expires_at,omitempty"`
+	PaidAt         *time.Time  `json:"paid_at,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+type OrderOutput struct {
+	Order OrderDTO `json:"order"`
+}
+
+type ListOrdersOutput struct {
+	Orders     []OrderDTO `json:"orders"`
+	Total      int64      `json:"total"`
+	Page       int        `json:"page"`
+	PageSize   int        `json:"page_size"`
+	TotalPages int        `json:"total_pages"`
+}
@@ -0,0 +1,37 @@
+package httpdto
+
+import "time"
+
+type AskQuestionInput struct {
+	AuctionID string `json:"auction_id" validate:"required,max=100"`
+	Question  string `json:"question" validate:"required,max=1000"`
+}
+
+type AnswerQuestionInput struct {
+	QuestionID int64  `json:"question_id" validate:"required,gt=0"`
+	Answer     string `json:"answer" validate:"required,max=2000"`
+	Public     *bool  `json:"public"`
+}
+
+type ListQuestionsInput struct {
+	AuctionID string `query:"auction_id" validate:"required,max=100"`
+}
+
+type QuestionDTO struct {
+	QuestionID int64      `json:"question_id"`
+	AuctionID  string     `json:"auction_id"`
+	AskerID    string     `json:"asker_id"`
+	Question   string     `json:"question"`
+	Answer     string     `json:"answer,omitempty"`
+	Public     bool       `json:"public"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+}
+
+type QuestionOutput struct {
+	Question QuestionDTO `json:"question"`
+}
+
+type ListQuestionsOutput struct {
+	Questions []QuestionDTO `json:"questions"`
+}
@@ -0,0 +1,45 @@
+package httpdto
+
+import "time"
+
+type RateInput struct {
+	AuctionID string `json:"auction_id" validate:"required,uuid"`
+	UserID    string `json:"user_id" validate:"omitempty,max=100"`
+	Score     int    `json:"score" validate:"required,min=1,max=5"`
+	Comment   string `json:"comment" validate:"max=1000"`
+}
+
+type GetProfileInput struct {
+	UserID string `query:"user_id" validate:"required,max=100"`
+}
+
+type RatingSummaryDTO struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+type RatingDTO struct {
+	AuctionID string    `json:"auction_id"`
+	RaterID   string    `json:"rater_id"`
+	RateeID   string    `json:"ratee_id"`
+	Role      string    `json:"role"`
+	Score     int       `json:"score"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RatingOutput struct {
+	Rating RatingDTO `json:"rating"`
+}
+
+type ReputationDTO struct {
+	Overall  RatingSummaryDTO `json:"overall"`
+	AsSeller RatingSummaryDTO `json:"as_seller"`
+	AsBuyer  RatingSummaryDTO `json:"as_buyer"`
+}
+
+type UserProfileOutput struct {
+	UserID     string        `json:"user_id"`
+	Reputation ReputationDTO `json:"reputation"`
+	Ratings    []RatingDTO   `json:"ratings"`
+}
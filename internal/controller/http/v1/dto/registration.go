@@ -0,0 +1,47 @@
+package httpdto
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+type RegisterInput struct {
+	AuctionID string `json:"auction_id" validate:"required,max=100"`
+}
+
+type GetRegistrationInput struct {
+	AuctionID string `query:"auction_id" validate:"required,max=100"`
+}
+
+type ListRegistrationsInput struct {
+	AuctionID string `query:"auction_id" validate:"required,max=100"`
+	Status    string `query:"status" validate:"omitempty,oneof=PENDING APPROVED DENIED"`
+}
+
+type ReviewRegistrationInput struct {
+	AuctionID string `json:"auction_id" validate:"required,max=100"`
+	BidderID  string `json:"bidder_id" validate:"required,max=100"`
+	Approve   bool   `json:"approve"`
+	Reason    string `json:"reason" validate:"max=500"`
+}
+
+type RegistrationDTO struct {
+	AuctionID  string      `json:"auction_id"`
+	BidderID   string      `json:"bidder_id"`
+	Status     string      `json:"status"`
+	Deposit    money.Money `json:"deposit"`
+	Currency   string      `json:"currency"`
+	ReviewedBy string      `json:"reviewed_by,omitempty"`
+	Reason     string      `json:"reason,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+type RegistrationOutput struct {
+	Registration RegistrationDTO `json:"registration"`
+}
+
+type ListRegistrationsOutput struct {
+	Registrations []RegistrationDTO `json:"registrations"`
+}
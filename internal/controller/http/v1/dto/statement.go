@@ -0,0 +1,37 @@
+package httpdto
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+type StatementPeriodInput struct {
+	From string `query:"from" validate:"required"`
+	To   string `query:"to" validate:"required"`
+}
+
+type StatementLineDTO struct {
+	OrderID   int64       `json:"order_id"`
+	AuctionID string      `json:"auction_id"`
+	BuyerID   string      `json:"buyer_id"`
+	Amount    money.Money `json:"amount"`
+	Currency  string      `json:"currency"`
+	FXRate    float64     `json:"fx_rate"`
+	Gross     money.Money `json:"gross"`
+	Fees      money.Money `json:"fees"`
+	Net       money.Money `json:"net"`
+	PaidAt    time.Time   `json:"paid_at"`
+}
+
+type SellerStatementOutput struct {
+	SellerID   string             `json:"seller_id"`
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Orders     int64              `json:"orders"`
+	GrossSales money.Money        `json:"gross_sales"`
+	Fees       money.Money        `json:"fees"`
+	NetPayout  money.Money        `json:"net_payout"`
+	Currency   string             `json:"currency"`
+	Lines      []StatementLineDTO `json:"lines"`
+}
@@ -0,0 +1,35 @@
+package httpdto
+
+import "time"
+
+type SaveTemplateInput struct {
+	Name     string             `json:"name" validate:"required,max=100"`
+	Settings AuctionSettingsDTO `json:"settings"`
+}
+
+type DeleteTemplateInput struct {
+	TemplateID int64 `query:"template_id" validate:"required,gt=0"`
+}
+
+// LaunchTemplateInput.DurationMin overrides the template's duration when set.
+type LaunchTemplateInput struct {
+	TemplateID  int64  `json:"template_id" validate:"required,gt=0"`
+	AuctionID   string `json:"auction_id" validate:"required,max=100"`
+	DurationMin int    `json:"duration_min" validate:"omitempty,min=1,max=10080"`
+}
+
+type TemplateDTO struct {
+	TemplateID int64              `json:"template_id"`
+	Name       string             `json:"name"`
+	Settings   AuctionSettingsDTO `json:"settings"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+type TemplateOutput struct {
+	Template TemplateDTO `json:"template"`
+}
+
+type ListTemplatesOutput struct {
+	Templates []TemplateDTO `json:"templates"`
+}
@@ -0,0 +1,51 @@
+package httpdto
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+type WalletAmountInput struct {
+	Amount money.Money `json:"amount" validate:"required,gt=0,max=1000000000"`
+}
+
+// DepositInput credits a payment that was received outside the platform.
+type DepositInput struct {
+	OwnerID string      `json:"owner_id" validate:"required,max=100"`
+	Amount  money.Money `json:"amount" validate:"required,gt=0,max=1000000000"`
+}
+
+type ListLedgerInput struct {
+	Page     int `query:"page"`
+	PageSize int `query:"page_size"`
+}
+
+type WalletDTO struct {
+	OwnerID   string      `json:"owner_id"`
+	Available money.Money `json:"available"`
+	Held      money.Money `json:"held"`
+	Currency  string      `json:"currency"`
+}
+
+type WalletOutput struct {
+	Wallet WalletDTO `json:"wallet"`
+}
+
+type LedgerEntryDTO struct {
+	EntryID     int64       `json:"entry_id"`
+	TransferID  int64       `json:"transfer_id"`
+	AccountType string      `json:"account_type"`
+	Amount      money.Money `json:"amount"`
+	Kind        string      `json:"kind"`
+	Reference   string      `json:"reference,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type ListLedgerOutput struct {
+	Entries    []LedgerEntryDTO `json:"entries"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int              `json:"total_pages"`
+}
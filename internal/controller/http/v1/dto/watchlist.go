@@ -0,0 +1,20 @@
+package httpdto
+
+import "time"
+
+type WatchInput struct {
+	AuctionID string `json:"auction_id" validate:"required,max=100"`
+}
+
+type UnwatchInput struct {
+	AuctionID string `query:"auction_id" validate:"required,max=100"`
+}
+
+type WatchlistItemDTO struct {
+	AuctionID string    `json:"auction_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetWatchlistOutput struct {
+	Items []WatchlistItemDTO `json:"items"`
+}
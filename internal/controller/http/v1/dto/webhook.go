@@ -0,0 +1,54 @@
+package httpdto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateWebhookInput struct {
+	URL        string   `json:"url" validate:"required,url,max=2000"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=bid.result auction.ended"`
+}
+
+type WebhookDTO struct {
+	WebhookID  int64     `json:"webhook_id"`
+	OwnerID    string    `json:"owner_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateWebhookOutput struct {
+	Webhook WebhookDTO `json:"webhook"`
+}
+
+type ListWebhooksOutput struct {
+	Webhooks []WebhookDTO `json:"webhooks"`
+}
+
+type DeleteWebhookInput struct {
+	WebhookID int64 `query:"webhook_id" validate:"required,gt=0"`
+}
+
+type ListWebhookDeliveriesInput struct {
+	WebhookID int64 `query:"webhook_id" validate:"required,gt=0"`
+	Limit     int   `query:"limit"`
+}
+
+type WebhookDeliveryDTO struct {
+	DeliveryID   int64           `json:"delivery_id"`
+	EventType    string          `json:"event_type"`
+	Payload      json.RawMessage `json:"payload"`
+	Status       string          `json:"status"`
+	Attempts     int             `json:"attempts"`
+	ResponseCode int             `json:"response_code"`
+	Error        string          `json:"error,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	DeliveredAt  *time.Time      `json:"delivered_at,omitempty"`
+}
+
+type ListWebhookDeliveriesOutput struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+}
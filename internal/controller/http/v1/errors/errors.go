@@ -12,6 +12,53 @@ const (
 	ErrCodeRateLimited    ErrorCode = "RATE_LIMITED"
 	ErrCodeAuctionEnded   ErrorCode = "AUCTION_ENDED"
 	ErrCodeBidTooLow      ErrorCode = "BID_TOO_LOW"
+	ErrCodeUnauthorized   ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden      ErrorCode = "FORBIDDEN"
+	ErrCodeUserBanned     ErrorCode = "USER_BANNED"
+
+	ErrCodeIdempotencyKeyReused  ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyInProgress ErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	ErrCodeInsufficientFunds     ErrorCode = "INSUFFICIENT_FUNDS"
+
+	ErrCodeOrderNotPayable ErrorCode = "ORDER_NOT_PAYABLE"
+	ErrCodeOfferNotOpen    ErrorCode = "OFFER_NOT_OPEN"
+	ErrCodeInvalidAmount   ErrorCode = "INVALID_AMOUNT"
+
+	ErrCodeUnsupportedCurrency ErrorCode = "UNSUPPORTED_CURRENCY"
+	ErrCodeFXRateUnavailable   ErrorCode = "FX_RATE_UNAVAILABLE"
+	ErrCodeInvalidIncrements   ErrorCode = "INVALID_INCREMENTS"
+
+	ErrCodeInvalidQuantity ErrorCode = "INVALID_QUANTITY"
+	ErrCodeInvalidPricing  ErrorCode = "INVALID_PRICING"
+
+	ErrCodeInvalidAuctionType ErrorCode = "INVALID_AUCTION_TYPE"
+
+	ErrCodeInvalidVisibility ErrorCode = "INVALID_VISIBILITY"
+
+	ErrCodeInvalidEligibility      ErrorCode = "INVALID_ELIGIBILITY"
+	ErrCodeRegistrationNotRequired ErrorCode = "REGISTRATION_NOT_REQUIRED"
+	ErrCodeRegistrationNotPending  ErrorCode = "REGISTRATION_NOT_PENDING"
+
+	ErrCodeFraudFlagNotOpen ErrorCode = "FRAUD_FLAG_NOT_OPEN"
+
+	ErrCodeBlockedBySeller ErrorCode = "BLOCKED_BY_SELLER"
+
+	ErrCodeAuctionNotSold ErrorCode = "AUCTION_NOT_SOLD"
+	ErrCodeNotTradeParty  ErrorCode = "NOT_TRADE_PARTY"
+	ErrCodeAlreadyRated   ErrorCode = "ALREADY_RATED"
+
+	ErrCodeQuestionAnswered ErrorCode = "QUESTION_ALREADY_ANSWERED"
+
+	ErrCodeMediaTooLarge        ErrorCode = "MEDIA_TOO_LARGE"
+	ErrCodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	ErrCodeTooManyMedia         ErrorCode = "TOO_MANY_MEDIA"
+	ErrCodeAuctionHasActivity   ErrorCode = "AUCTION_HAS_ACTIVITY"
+
+	ErrCodeImportTooLarge ErrorCode = "IMPORT_TOO_LARGE"
+
+	ErrCodeInvalidRelistPolicy ErrorCode = "INVALID_RELIST_POLICY"
+
+	ErrCodeInvalidWebhookURL ErrorCode = "INVALID_WEBHOOK_URL"
 )
 
 var (
@@ -19,4 +66,24 @@ var (
 	ErrNotFound       = errors.New("resource not found")
 	ErrAlreadyExists  = errors.New("resource already exists")
 	ErrInternalServer = errors.New("internal server error")
+
+	ErrMissingToken     = errors.New("missing bearer token")
+	ErrInvalidToken     = errors.New("invalid or expired token")
+	ErrIdentityMismatch = errors.New("request identity does not match the authenticated user")
+	ErrInsufficientRole = errors.New("insufficient role for this operation")
+	ErrInvalidAPIKey    = errors.New("invalid or revoked api key")
+	ErrMissingScope     = errors.New("api key lacks the scope required for this operation")
+	ErrAPIKeyNotAllowed = errors.New("api keys cannot be used for this operation")
+
+	ErrTooManyKeyLookups = errors.New("too many api key attempts from your IP")
+
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be at most 255 characters")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still being processed")
+
+	ErrInvalidPeriod = errors.New("from and to must be dates (YYYY-MM-DD) or RFC 3339 timestamps")
+	ErrInvalidAmount = errors.New("amount must be a decimal number with no more places than its currency allows")
+
+	ErrMissingFile    = errors.New("multipart form must include a file part")
+	ErrImportTooLarge = errors.New("import body exceeds 5 MiB")
 )
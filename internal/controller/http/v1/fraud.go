@@ -0,0 +1,97 @@
+package httpapi
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type fraudRoutes struct {
+	fraudService service.Fraud
+}
+
+func newFraudRoutes(admin *echo.Group, fServ service.Fraud) {
+	r := &fraudRoutes{fraudService: fServ}
+
+	admin.GET("/fraud/flags", r.listFlags)
+	admin.POST("/fraud/flags/review", r.reviewFlag)
+}
+
+func fraudErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundFraudFlag), errors.Is(err, se.ErrNotFoundBid), errors.Is(err, se.ErrNotFoundAuction):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrFraudFlagNotOpen):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeFraudFlagNotOpen, err.Error())
+	case errors.Is(err, se.ErrInvalidFraudStatus):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
+func (r *fraudRoutes) listFlags(c echo.Context) error {
+	var input hd.ListFraudFlagsInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	flags, total, err := r.fraudService.ListFlags(c.Request().Context(), e.FraudFlagStatus(input.Status), input.Page, input.PageSize)
+	if err != nil {
+		return fraudErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.ListFraudFlagsOutput{
+		Flags:      hmap.ToFraudFlagDTOs(flags),
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(input.PageSize))),
+	})
+}
+
+func (r *fraudRoutes) reviewFlag(c echo.Context) error {
+	var input hd.ReviewFraudFlagInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	reviewerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	flag, err := r.fraudService.ReviewFlag(c.Request().Context(), sd.ReviewFraudFlagInput{
+		FlagID:     input.FlagID,
+		ReviewerID: reviewerID,
+		Confirm:    input.Confirm,
+		Note:       input.Note,
+	})
+	if err != nil {
+		return fraudErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.FraudFlagOutput{Flag: hmap.ToFraudFlagDTO(flag)})
+}
@@ -0,0 +1,57 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type fxRoutes struct {
+	fxService service.FX
+}
+
+func newFXRoutes(public, admin *echo.Group, fxServ service.FX) {
+	r := &fxRoutes{fxService: fxServ}
+
+	public.GET("/rates", r.list)
+	admin.PUT("/fx/rates", r.set)
+}
+
+func (r *fxRoutes) list(c echo.Context) error {
+	rates, err := r.fxService.ListRates(c.Request().Context())
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+	return c.JSON(http.StatusOK, hmap.ToListFXRatesOutput(rates))
+}
+
+func (r *fxRoutes) set(c echo.Context) error {
+	var input hd.SetFXRatesInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	rates, err := r.fxService.SetRates(c.Request().Context(), hmap.ToFXRatesMap(input))
+	if err != nil {
+		switch {
+		case errors.Is(err, se.ErrUnsupportedCurrency):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeUnsupportedCurrency, err.Error())
+		case errors.Is(err, se.ErrInvalidFXRate):
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hmap.ToListFXRatesOutput(rates))
+}
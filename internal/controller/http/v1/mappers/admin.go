@@ -0,0 +1,41 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+)
+
+func ToBanDTO(b e.Ban) hd.BanDTO {
+	return hd.BanDTO{
+		UserID:    b.UserID,
+		Reason:    b.Reason,
+		BannedBy:  b.BannedBy,
+		CreatedAt: b.CreatedAt,
+		ExpiresAt: b.ExpiresAt,
+	}
+}
+
+func ToBanDTOs(bans []e.Ban) []hd.BanDTO {
+	dtos := make([]hd.BanDTO, 0, len(bans))
+	for _, b := range bans {
+		dtos = append(dtos, ToBanDTO(b))
+	}
+	return dtos
+}
+
+func ToCircuitBreakerStateDTOs(states []circuitbreaker.State) []hd.CircuitBreakerStateDTO {
+	dtos := make([]hd.CircuitBreakerStateDTO, 0, len(states))
+	for _, s := range states {
+		dtos = append(dtos, hd.CircuitBreakerStateDTO{
+			Name:                 s.Name,
+			State:                s.State,
+			Requests:             s.Requests,
+			TotalSuccesses:       s.TotalSuccesses,
+			TotalFailures:        s.TotalFailures,
+			ConsecutiveSuccesses: s.ConsecutiveSuccesses,
+			ConsecutiveFailures:  s.ConsecutiveFailures,
+		})
+	}
+	return dtos
+}
@@ -0,0 +1,51 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+	sd "auction-platform/internal/service/dto"
+)
+
+func ToUpdateAllowlistServiceInput(sellerID string, in hd.UpdateAllowlistInput) sd.UpdateAllowlistInput {
+	return sd.UpdateAllowlistInput{
+		SellerID:      sellerID,
+		AuctionID:     in.AuctionID,
+		AddBidders:    in.AddBidders,
+		RemoveBidders: in.RemoveBidders,
+		AddGroups:     in.AddGroups,
+		RemoveGroups:  in.RemoveGroups,
+	}
+}
+
+func ToAllowlistDTO(l e.Allowlist) hd.AllowlistDTO {
+	bidderIDs := l.BidderIDs
+	if bidderIDs == nil {
+		bidderIDs = []string{}
+	}
+	return hd.AllowlistDTO{
+		AuctionID: l.AuctionID,
+		BidderIDs: bidderIDs,
+		Groups:    ToBidderGroupDTOs(l.Groups),
+	}
+}
+
+func ToBidderGroupDTO(g e.BidderGroup) hd.BidderGroupDTO {
+	members := g.Members
+	if members == nil {
+		members = []string{}
+	}
+	return hd.BidderGroupDTO{
+		GroupID:   g.GroupID,
+		Name:      g.Name,
+		Members:   members,
+		CreatedAt: g.CreatedAt,
+	}
+}
+
+func ToBidderGroupDTOs(groups []e.BidderGroup) []hd.BidderGroupDTO {
+	dtos := make([]hd.BidderGroupDTO, 0, len(groups))
+	for _, g := range groups {
+		dtos = append(dtos, ToBidderGroupDTO(g))
+	}
+	return dtos
+}
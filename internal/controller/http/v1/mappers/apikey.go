@@ -0,0 +1,28 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToAPIKeyDTO(k e.APIKey) hd.APIKeyDTO {
+	return hd.APIKeyDTO{
+		KeyID:          k.KeyID,
+		Name:           k.Name,
+		Prefix:         k.Prefix,
+		Scopes:         k.Scopes,
+		RateLimitRPS:   k.RateLimitRPS,
+		RateLimitBurst: k.RateLimitBurst,
+		CreatedAt:      k.CreatedAt,
+		RotatedAt:      k.RotatedAt,
+		RevokedAt:      k.RevokedAt,
+	}
+}
+
+func ToAPIKeyDTOs(keys []e.APIKey) []hd.APIKeyDTO {
+	dtos := make([]hd.APIKeyDTO, 0, len(keys))
+	for _, k := range keys {
+		dtos = append(dtos, ToAPIKeyDTO(k))
+	}
+	return dtos
+}
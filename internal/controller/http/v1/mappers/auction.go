@@ -2,38 +2,153 @@ package httpmappers
 
 import (
 	hd "auction-platform/internal/controller/http/v1/dto"
+	ut "auction-platform/internal/controller/http/v1/utils"
 	e "auction-platform/internal/entity"
 	sd "auction-platform/internal/service/dto"
+	"auction-platform/pkg/money"
 )
 
 func ToCreateAuctionServiceInput(in hd.CreateAuctionInput) sd.CreateAuctionInput {
 	return sd.CreateAuctionInput{
-		AuctionID:   in.AuctionID,
-		Title:       in.Title,
-		Description: in.Description,
-		SellerID:    in.SellerID,
-		StartPrice:  in.StartPrice,
-		MinStep:     in.MinStep,
-		DurationMin: in.DurationMin,
+		AuctionID:    in.AuctionID,
+		Title:        in.Title,
+		Description:  in.Description,
+		Category:     in.Category,
+		SellerID:     in.SellerID,
+		StartPrice:   in.StartPrice,
+		MinStep:      in.MinStep,
+		Currency:     ut.ParseCurrency(in.Currency),
+		Increments:   toIncrementTable(in.Increments),
+		Quantity:     in.Quantity,
+		Pricing:      e.LotPricing(in.Pricing),
+		Type:         e.AuctionType(in.Type),
+		Visibility:   e.AuctionVisibility(in.Visibility),
+		Eligibility:  toEligibilityRules(in.Eligibility),
+		Invited:      in.Invited,
+		DurationMin:  in.DurationMin,
+		ReservePrice: in.ReservePrice,
+		Relist:       toRelistPolicy(in.Relist),
 	}
 }
 
 func ToAuctionDTO(a e.Auction) hd.AuctionDTO {
+	nextMin, nextMax := nextBidLimits(a.IsReverse(), a.NextBid())
 	return hd.AuctionDTO{
-		AuctionID:   a.AuctionID,
-		Title:       a.Title,
-		Description: a.Description,
-		SellerID:    a.SellerID,
-		StartPrice:  a.StartPrice,
-		CurrentBid:  a.CurrentBid,
-		MinStep:     a.MinStep,
-		Status:      string(a.Status),
-		WinnerID:    a.WinnerID,
-		EndsAt:      a.EndsAt,
-		CreatedAt:   a.CreatedAt,
+		AuctionID:    a.AuctionID,
+		Title:        a.Title,
+		Description:  a.Description,
+		Category:     a.Category,
+		SellerID:     a.SellerID,
+		StartPrice:   a.StartPrice,
+		CurrentBid:   a.CurrentBid,
+		MinStep:      a.MinStep,
+		NextMinBid:   nextMin,
+		NextMaxBid:   nextMax,
+		Increments:   toIncrementTierDTOs(a.Increments),
+		Currency:     string(a.Currency),
+		Quantity:     a.Quantity,
+		Pricing:      string(a.Pricing),
+		Type:         string(a.Type),
+		Visibility:   string(a.Visibility),
+		Eligibility:  toEligibilityDTO(a.Eligibility),
+		Status:       string(a.Status),
+		WinnerID:     a.WinnerID,
+		EndsAt:       a.EndsAt,
+		CreatedAt:    a.CreatedAt,
+		Converted:    toConvertedPricesDTO(a.Converted, a.IsReverse()),
+		SellerRating: toSellerRatingDTO(a.SellerRating),
+		Answers:      toAnswerDTOs(a.Answers),
+		Media:        ToMediaDTOs(a.Media),
+		RelistedFrom: a.RelistedFrom,
 	}
 }
 
+// nextBidLimits reports the next acceptable bid as a floor for forward
+// auctions and as a ceiling for reverse ones.
+func nextBidLimits(reverse bool, next money.Money) (minBid, maxBid *money.Money) {
+	if reverse {
+		return nil, &next
+	}
+	return &next, nil
+}
+
+func toConvertedPricesDTO(p *e.ConvertedPrices, reverse bool) *hd.ConvertedPricesDTO {
+	if p == nil {
+		return nil
+	}
+	nextMin, nextMax := nextBidLimits(reverse, p.NextBid)
+	return &hd.ConvertedPricesDTO{
+		Currency:   string(p.Currency),
+		Rate:       p.Rate,
+		StartPrice: p.StartPrice,
+		CurrentBid: p.CurrentBid,
+		MinStep:    p.MinStep,
+		NextMinBid: nextMin,
+		NextMaxBid: nextMax,
+	}
+}
+
+func toEligibilityRules(in *hd.EligibilityDTO) e.EligibilityRules {
+	if in == nil {
+		return e.EligibilityRules{}
+	}
+	return e.EligibilityRules{
+		RequireRegistration: in.RequireRegistration,
+		Deposit:             in.Deposit,
+		MinAccountAgeDays:   in.MinAccountAgeDays,
+		MaxWinningAuctions:  in.MaxWinningAuctions,
+	}
+}
+
+// toEligibilityDTO leaves out the rules of auctions anyone may bid on.
+func toEligibilityDTO(r e.EligibilityRules) *hd.EligibilityDTO {
+	if !r.RequireRegistration && r.Deposit.IsZero() && r.MinAccountAgeDays == 0 && r.MaxWinningAuctions == 0 {
+		return nil
+	}
+	return &hd.EligibilityDTO{
+		RequireRegistration: r.RequireRegistration,
+		Deposit:             r.Deposit,
+		MinAccountAgeDays:   r.MinAccountAgeDays,
+		MaxWinningAuctions:  r.MaxWinningAuctions,
+	}
+}
+
+func toRelistPolicy(in *hd.RelistPolicyDTO) e.RelistPolicy {
+	if in == nil {
+		return e.RelistPolicy{}
+	}
+	return e.RelistPolicy{MaxRelists: in.MaxRelists, PriceCutPct: in.PriceCutPct}
+}
+
+func toRelistPolicyDTO(p e.RelistPolicy) *hd.RelistPolicyDTO {
+	if p == (e.RelistPolicy{}) {
+		return nil
+	}
+	return &hd.RelistPolicyDTO{MaxRelists: p.MaxRelists, PriceCutPct: p.PriceCutPct}
+}
+
+func toIncrementTable(in []hd.IncrementTierDTO) e.IncrementTable {
+	if len(in) == 0 {
+		return nil
+	}
+	table := make(e.IncrementTable, 0, len(in))
+	for _, t := range in {
+		table = append(table, e.IncrementTier{Below: t.Below, Step: t.Step})
+	}
+	return table
+}
+
+func toIncrementTierDTOs(table e.IncrementTable) []hd.IncrementTierDTO {
+	if len(table) == 0 {
+		return nil
+	}
+	dtos := make([]hd.IncrementTierDTO, 0, len(table))
+	for _, t := range table {
+		dtos = append(dtos, hd.IncrementTierDTO{Below: t.Below, Step: t.Step})
+	}
+	return dtos
+}
+
 func ToAuctionDTOs(auctions []e.Auction) []hd.AuctionDTO {
 	dtos := make([]hd.AuctionDTO, 0, len(auctions))
 	for _, a := range auctions {
@@ -41,3 +156,16 @@ func ToAuctionDTOs(auctions []e.Auction) []hd.AuctionDTO {
 	}
 	return dtos
 }
+
+func ToSellerAuctionDTOs(auctions []e.SellerAuction) []hd.SellerAuctionDTO {
+	dtos := make([]hd.SellerAuctionDTO, 0, len(auctions))
+	for _, a := range auctions {
+		dtos = append(dtos, hd.SellerAuctionDTO{
+			AuctionDTO:  ToAuctionDTO(a.Auction),
+			BidsCount:   a.BidsCount,
+			FinishedAt:  a.FinishedAt,
+			RelistCount: a.RelistCount,
+		})
+	}
+	return dtos
+}
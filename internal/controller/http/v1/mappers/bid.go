@@ -12,6 +12,7 @@ func ToPlaceBidServiceInput(in hd.PlaceBidInput) sd.PlaceBidInput {
 		AuctionID: in.AuctionID,
 		BidderID:  in.BidderID,
 		Amount:    in.Amount,
+		Quantity:  in.Quantity,
 	}
 }
 
@@ -21,6 +22,8 @@ func ToBidDTO(b e.Bid) hd.BidDTO {
 		AuctionID: b.AuctionID,
 		BidderID:  b.BidderID,
 		Amount:    b.Amount,
+		Currency:  string(b.Amount.Currency),
+		Quantity:  b.Quantity,
 		Status:    string(b.Status),
 		CreatedAt: b.CreatedAt,
 	}
@@ -33,3 +36,23 @@ func ToBidDTOs(bids []e.Bid) []hd.BidDTO {
 	}
 	return dtos
 }
+
+func ToBidderAuctionDTOs(auctions []e.BidderAuction) []hd.BidderAuctionDTO {
+	dtos := make([]hd.BidderAuctionDTO, 0, len(auctions))
+	for _, a := range auctions {
+		dtos = append(dtos, hd.BidderAuctionDTO{
+			AuctionID:     a.AuctionID,
+			Title:         a.Title,
+			AuctionStatus: string(a.AuctionStatus),
+			CurrentBid:    a.CurrentBid,
+			MyHighestBid:  a.MaxBid,
+			Currency:      string(a.CurrentBid.Currency),
+			MyBidsCount:   a.BidsCount,
+			IsWinning:     a.IsLeading,
+			Outcome:       string(a.Outcome),
+			EndsAt:        a.EndsAt,
+			FinishedAt:    a.FinishedAt,
+		})
+	}
+	return dtos
+}
@@ -0,0 +1,22 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToBlockedBidderDTO(b e.BlockedBidder) hd.BlockedBidderDTO {
+	return hd.BlockedBidderDTO{
+		BidderID:  b.BidderID,
+		Reason:    b.Reason,
+		CreatedAt: b.CreatedAt,
+	}
+}
+
+func ToBlockedBidderDTOs(blocked []e.BlockedBidder) []hd.BlockedBidderDTO {
+	dtos := make([]hd.BlockedBidderDTO, 0, len(blocked))
+	for _, b := range blocked {
+		dtos = append(dtos, ToBlockedBidderDTO(b))
+	}
+	return dtos
+}
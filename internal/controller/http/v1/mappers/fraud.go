@@ -0,0 +1,36 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToFraudFlagDTO(f e.FraudFlag) hd.FraudFlagDTO {
+	signals := make([]string, 0, len(f.Signals))
+	for _, s := range f.Signals {
+		signals = append(signals, string(s))
+	}
+	return hd.FraudFlagDTO{
+		FlagID:     f.FlagID,
+		BidID:      f.BidID,
+		AuctionID:  f.AuctionID,
+		BidderID:   f.BidderID,
+		SellerID:   f.SellerID,
+		Score:      f.Score,
+		Signals:    signals,
+		Held:       f.Held,
+		Status:     string(f.Status),
+		ReviewedBy: f.ReviewedBy,
+		Note:       f.Note,
+		CreatedAt:  f.CreatedAt,
+		ReviewedAt: f.ReviewedAt,
+	}
+}
+
+func ToFraudFlagDTOs(flags []e.FraudFlag) []hd.FraudFlagDTO {
+	dtos := make([]hd.FraudFlagDTO, 0, len(flags))
+	for _, f := range flags {
+		dtos = append(dtos, ToFraudFlagDTO(f))
+	}
+	return dtos
+}
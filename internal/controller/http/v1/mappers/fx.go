@@ -0,0 +1,31 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/pkg/money"
+)
+
+func ToListFXRatesOutput(rates []e.FXRate) hd.ListFXRatesOutput {
+	dtos := make([]hd.FXRateDTO, 0, len(rates))
+	for _, r := range rates {
+		dtos = append(dtos, hd.FXRateDTO{
+			Currency:  string(r.Currency),
+			Rate:      r.Rate,
+			UpdatedAt: r.UpdatedAt,
+		})
+	}
+	return hd.ListFXRatesOutput{
+		Base:  string(money.DefaultCurrency),
+		Rates: dtos,
+	}
+}
+
+func ToFXRatesMap(in hd.SetFXRatesInput) map[money.Currency]float64 {
+	rates := make(map[money.Currency]float64, len(in.Rates))
+	for c, r := range in.Rates {
+		rates[ut.ParseCurrency(c)] = r
+	}
+	return rates
+}
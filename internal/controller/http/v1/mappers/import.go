@@ -0,0 +1,27 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToImportReportOutput(r e.ImportReport) hd.ImportReportOutput {
+	rows := make([]hd.ImportRowDTO, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		rows = append(rows, hd.ImportRowDTO{
+			Line:      row.Line,
+			AuctionID: row.AuctionID,
+			Status:    string(row.Status),
+			Error:     row.Error,
+		})
+	}
+	return hd.ImportReportOutput{
+		DryRun:  r.DryRun,
+		Total:   len(r.Rows),
+		Valid:   r.Count(e.ImportRowValid),
+		Created: r.Count(e.ImportRowCreated),
+		Invalid: r.Count(e.ImportRowInvalid),
+		Failed:  r.Count(e.ImportRowFailed),
+		Rows:    rows,
+	}
+}
@@ -0,0 +1,31 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToMediaDTO(m e.Media) hd.MediaDTO {
+	return hd.MediaDTO{
+		MediaID:      m.MediaID,
+		Position:     m.Position,
+		Kind:         string(m.Kind),
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		FileName:     m.FileName,
+		URL:          m.URL,
+		ThumbnailURL: m.ThumbnailURL,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func ToMediaDTOs(media []e.Media) []hd.MediaDTO {
+	if len(media) == 0 {
+		return nil
+	}
+	dtos := make([]hd.MediaDTO, 0, len(media))
+	for _, m := range media {
+		dtos = append(dtos, ToMediaDTO(m))
+	}
+	return dtos
+}
@@ -0,0 +1,26 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToNotificationDTO(n e.Notification) hd.NotificationDTO {
+	return hd.NotificationDTO{
+		NotificationID: n.NotificationID,
+		AuctionID:      n.AuctionID,
+		Type:           string(n.Type),
+		Message:        n.Message,
+		IsRead:         n.IsRead,
+		CreatedAt:      n.CreatedAt,
+		ReadAt:         n.ReadAt,
+	}
+}
+
+func ToNotificationDTOs(notifications []e.Notification) []hd.NotificationDTO {
+	dtos := make([]hd.NotificationDTO, 0, len(notifications))
+	for _, n := range notifications {
+		dtos = append(dtos, ToNotificationDTO(n))
+	}
+	return dtos
+}
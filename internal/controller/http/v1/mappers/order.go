@@ -0,0 +1,36 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToOrderDTO(o e.Order) hd.OrderDTO {
+	return hd.OrderDTO{
+		OrderID:        o.OrderID,
+		AuctionID:      o.AuctionID,
+		BuyerID:        o.BuyerID,
+		SellerID:       o.SellerID,
+		Kind:           string(o.Kind),
+		Status:         string(o.Status),
+		Amount:         o.Amount,
+		AmountPaid:     o.AmountPaid,
+		AmountDue:      o.AmountDue(),
+		Quantity:       o.Quantity,
+		Currency:       string(o.Amount.Currency),
+		FXRate:         o.FXRate,
+		PaymentDueAt:   o.PaymentDueAt,
+		OfferExpiresAt: o.OfferExpiresAt,
+		PaidAt:         o.PaidAt,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+}
+
+func ToOrderDTOs(orders []e.Order) []hd.OrderDTO {
+	dtos := make([]hd.OrderDTO, 0, len(orders))
+	for _, o := range orders {
+		dtos = append(dtos, ToOrderDTO(o))
+	}
+	return dtos
+}
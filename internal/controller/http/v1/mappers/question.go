@@ -0,0 +1,34 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToQuestionDTO(q e.Question) hd.QuestionDTO {
+	return hd.QuestionDTO{
+		QuestionID: q.QuestionID,
+		AuctionID:  q.AuctionID,
+		AskerID:    q.AskerID,
+		Question:   q.Question,
+		Answer:     q.Answer,
+		Public:     q.Public,
+		CreatedAt:  q.CreatedAt,
+		AnsweredAt: q.AnsweredAt,
+	}
+}
+
+func toAnswerDTOs(answers []e.Question) []hd.QuestionDTO {
+	if len(answers) == 0 {
+		return nil
+	}
+	return ToQuestionDTOs(answers)
+}
+
+func ToQuestionDTOs(questions []e.Question) []hd.QuestionDTO {
+	dtos := make([]hd.QuestionDTO, 0, len(questions))
+	for _, q := range questions {
+		dtos = append(dtos, ToQuestionDTO(q))
+	}
+	return dtos
+}
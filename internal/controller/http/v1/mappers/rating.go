@@ -0,0 +1,51 @@
+package httpmappers
+
+import (
+	"math"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToRatingSummaryDTO(s e.RatingSummary) hd.RatingSummaryDTO {
+	return hd.RatingSummaryDTO{
+		Average: math.Round(s.Average*100) / 100,
+		Count:   s.Count,
+	}
+}
+
+func toSellerRatingDTO(s *e.RatingSummary) *hd.RatingSummaryDTO {
+	if s == nil {
+		return nil
+	}
+	dto := ToRatingSummaryDTO(*s)
+	return &dto
+}
+
+func ToRatingDTO(r e.Rating) hd.RatingDTO {
+	return hd.RatingDTO{
+		AuctionID: r.AuctionID,
+		RaterID:   r.RaterID,
+		RateeID:   r.RateeID,
+		Role:      string(r.Role),
+		Score:     r.Score,
+		Comment:   r.Comment,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func ToUserProfileOutput(p e.UserProfile) hd.UserProfileOutput {
+	ratings := make([]hd.RatingDTO, 0, len(p.Ratings))
+	for _, r := range p.Ratings {
+		ratings = append(ratings, ToRatingDTO(r))
+	}
+	return hd.UserProfileOutput{
+		UserID: p.UserID,
+		Reputation: hd.ReputationDTO{
+			Overall:  ToRatingSummaryDTO(p.Reputation.Overall()),
+			AsSeller: ToRatingSummaryDTO(p.Reputation.AsSeller),
+			AsBuyer:  ToRatingSummaryDTO(p.Reputation.AsBuyer),
+		},
+		Ratings: ratings,
+	}
+}
@@ -0,0 +1,28 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToRegistrationDTO(r e.Registration) hd.RegistrationDTO {
+	return hd.RegistrationDTO{
+		AuctionID:  r.AuctionID,
+		BidderID:   r.BidderID,
+		Status:     string(r.Status),
+		Deposit:    r.Deposit,
+		Currency:   string(r.Deposit.Currency),
+		ReviewedBy: r.ReviewedBy,
+		Reason:     r.Reason,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+func ToRegistrationDTOs(regs []e.Registration) []hd.RegistrationDTO {
+	dtos := make([]hd.RegistrationDTO, 0, len(regs))
+	for _, r := range regs {
+		dtos = append(dtos, ToRegistrationDTO(r))
+	}
+	return dtos
+}
@@ -0,0 +1,53 @@
+package httpmappers
+
+import (
+	"strconv"
+	"time"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+var StatementCSVHeader = []string{"seller_id", "from", "to", "orders", "gross_sales", "fees", "net_payout", "currency"}
+
+func ToSellerStatementOutput(st e.SellerStatement) hd.SellerStatementOutput {
+	lines := make([]hd.StatementLineDTO, 0, len(st.Lines))
+	for _, l := range st.Lines {
+		lines = append(lines, hd.StatementLineDTO{
+			OrderID:   l.OrderID,
+			AuctionID: l.AuctionID,
+			BuyerID:   l.BuyerID,
+			Amount:    l.Amount,
+			Currency:  string(l.Amount.Currency),
+			FXRate:    l.FXRate,
+			Gross:     l.Gross,
+			Fees:      l.Fees,
+			Net:       l.Net(),
+			PaidAt:    l.PaidAt,
+		})
+	}
+	return hd.SellerStatementOutput{
+		SellerID:   st.SellerID,
+		From:       st.From,
+		To:         st.To,
+		Orders:     st.Orders,
+		GrossSales: st.GrossSales,
+		Fees:       st.Fees,
+		NetPayout:  st.NetPayout,
+		Currency:   string(st.GrossSales.Currency),
+		Lines:      lines,
+	}
+}
+
+func ToStatementCSVRecord(st e.SellerStatement) []string {
+	return []string{
+		st.SellerID,
+		st.From.Format(time.RFC3339),
+		st.To.Format(time.RFC3339),
+		strconv.FormatInt(st.Orders, 10),
+		st.GrossSales.String(),
+		st.Fees.String(),
+		st.NetPayout.String(),
+		string(st.GrossSales.Currency),
+	}
+}
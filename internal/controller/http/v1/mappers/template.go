@@ -0,0 +1,51 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+	sd "auction-platform/internal/service/dto"
+)
+
+func ToSaveTemplateServiceInput(in hd.SaveTemplateInput, sellerID string) sd.SaveTemplateInput {
+	return sd.SaveTemplateInput{
+		SellerID: sellerID,
+		Name:     in.Name,
+		Auction:  ToCreateAuctionServiceInput(hd.CreateAuctionInput{AuctionSettingsDTO: in.Settings}),
+	}
+}
+
+func ToTemplateDTO(t e.AuctionTemplate) hd.TemplateDTO {
+	s := t.Settings
+	return hd.TemplateDTO{
+		TemplateID: t.TemplateID,
+		Name:       t.Name,
+		Settings: hd.AuctionSettingsDTO{
+			Title:        s.Title,
+			Description:  s.Description,
+			Category:     s.Category,
+			StartPrice:   s.StartPrice,
+			MinStep:      s.MinStep,
+			Increments:   toIncrementTierDTOs(s.Increments),
+			Currency:     string(s.Currency),
+			Quantity:     s.Quantity,
+			Pricing:      string(s.Pricing),
+			Type:         string(s.Type),
+			Visibility:   string(s.Visibility),
+			Eligibility:  toEligibilityDTO(s.Eligibility),
+			Invited:      s.Invited,
+			DurationMin:  s.DurationMin,
+			ReservePrice: s.ReservePrice,
+			Relist:       toRelistPolicyDTO(s.Relist),
+		},
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func ToTemplateDTOs(templates []e.AuctionTemplate) []hd.TemplateDTO {
+	dtos := make([]hd.TemplateDTO, 0, len(templates))
+	for _, t := range templates {
+		dtos = append(dtos, ToTemplateDTO(t))
+	}
+	return dtos
+}
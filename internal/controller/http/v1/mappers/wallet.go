@@ -0,0 +1,31 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToWalletDTO(w e.Wallet) hd.WalletDTO {
+	return hd.WalletDTO{
+		OwnerID:   w.OwnerID,
+		Available: w.Available,
+		Held:      w.Held,
+		Currency:  string(w.Available.Currency),
+	}
+}
+
+func ToLedgerEntryDTOs(entries []e.LedgerEntry) []hd.LedgerEntryDTO {
+	dtos := make([]hd.LedgerEntryDTO, 0, len(entries))
+	for _, le := range entries {
+		dtos = append(dtos, hd.LedgerEntryDTO{
+			EntryID:     le.EntryID,
+			TransferID:  le.TransferID,
+			AccountType: string(le.AccountType),
+			Amount:      le.Amount,
+			Kind:        string(le.Kind),
+			Reference:   le.Reference,
+			CreatedAt:   le.CreatedAt,
+		})
+	}
+	return dtos
+}
@@ -0,0 +1,17 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+)
+
+func ToWatchlistItemDTOs(items []e.WatchlistItem) []hd.WatchlistItemDTO {
+	dtos := make([]hd.WatchlistItemDTO, 0, len(items))
+	for _, w := range items {
+		dtos = append(dtos, hd.WatchlistItemDTO{
+			AuctionID: w.AuctionID,
+			CreatedAt: w.CreatedAt,
+		})
+	}
+	return dtos
+}
@@ -0,0 +1,52 @@
+package httpmappers
+
+import (
+	hd "auction-platform/internal/controller/http/v1/dto"
+	e "auction-platform/internal/entity"
+	sd "auction-platform/internal/service/dto"
+)
+
+func ToCreateWebhookServiceInput(in hd.CreateWebhookInput, ownerID string) sd.CreateWebhookInput {
+	return sd.CreateWebhookInput{
+		OwnerID:    ownerID,
+		URL:        in.URL,
+		EventTypes: in.EventTypes,
+	}
+}
+
+func ToWebhookDTO(w e.Webhook) hd.WebhookDTO {
+	return hd.WebhookDTO{
+		WebhookID:  w.WebhookID,
+		OwnerID:    w.OwnerID,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		IsActive:   w.IsActive,
+		CreatedAt:  w.CreatedAt,
+	}
+}
+
+func ToWebhookDTOs(webhooks []e.Webhook) []hd.WebhookDTO {
+	dtos := make([]hd.WebhookDTO, 0, len(webhooks))
+	for _, w := range webhooks {
+		dtos = append(dtos, ToWebhookDTO(w))
+	}
+	return dtos
+}
+
+func ToWebhookDeliveryDTOs(deliveries []e.WebhookDelivery) []hd.WebhookDeliveryDTO {
+	dtos := make([]hd.WebhookDeliveryDTO, 0, len(deliveries))
+	for _, d := range deliveries {
+		dtos = append(dtos, hd.WebhookDeliveryDTO{
+			DeliveryID:   d.DeliveryID,
+			EventType:    string(d.EventType),
+			Payload:      d.Payload,
+			Status:       string(d.Status),
+			Attempts:     d.Attempts,
+			ResponseCode: d.ResponseCode,
+			Error:        d.Error,
+			CreatedAt:    d.CreatedAt,
+			DeliveredAt:  d.DeliveredAt,
+		})
+	}
+	return dtos
+}
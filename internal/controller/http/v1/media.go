@@ -0,0 +1,142 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const maxMediaFileNameLen = 255
+
+type mediaRoutes struct {
+	mediaService service.Media
+}
+
+func newMediaRoutes(g *echo.Group, mServ service.Media, authMW echo.MiddlewareFunc) {
+	r := &mediaRoutes{mediaService: mServ}
+
+	g.Use(authMW, mw.RequireRoles(e.RoleSeller, e.RoleAdmin), mw.RequireScopes(e.ScopeAuctionsWrite))
+	g.POST("", r.upload)
+	g.DELETE("", r.delete)
+	g.POST("/order", r.reorder)
+}
+
+func mediaErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundAuction), errors.Is(err, se.ErrNotFoundMedia):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrMediaTooLarge):
+		return ut.NewErrReasonJSON(c, http.StatusRequestEntityTooLarge, he.ErrCodeMediaTooLarge, err.Error())
+	case errors.Is(err, se.ErrUnsupportedMediaType):
+		return ut.NewErrReasonJSON(c, http.StatusUnsupportedMediaType, he.ErrCodeUnsupportedMediaType, err.Error())
+	case errors.Is(err, se.ErrEmptyMedia), errors.Is(err, se.ErrInvalidMedia), errors.Is(err, se.ErrInvalidMediaOrder):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	case errors.Is(err, se.ErrTooManyMedia):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeTooManyMedia, err.Error())
+	case errors.Is(err, se.ErrAuctionNotActive):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionEnded, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
+// upload takes a multipart form with the auction_id field and the file part.
+func (r *mediaRoutes) upload(c echo.Context) error {
+	var input hd.UploadMediaInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrMissingFile.Error())
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrMissingFile.Error())
+	}
+	defer file.Close()
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(fh.Filename)
+	if len(name) > maxMediaFileNameLen {
+		name = name[:maxMediaFileNameLen]
+	}
+
+	media, err := r.mediaService.Upload(c.Request().Context(), sd.UploadMediaInput{
+		AuctionID: input.AuctionID,
+		SellerID:  sellerID,
+		FileName:  name,
+		File:      file,
+	})
+	if err != nil {
+		return mediaErr(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, hd.MediaOutput{Media: hmap.ToMediaDTO(media)})
+}
+
+func (r *mediaRoutes) delete(c echo.Context) error {
+	var input hd.DeleteMediaInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.mediaService.DeleteMedia(c.Request().Context(), sellerID, input.MediaID); err != nil {
+		return mediaErr(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *mediaRoutes) reorder(c echo.Context) error {
+	var input hd.ReorderMediaInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	media, err := r.mediaService.ReorderMedia(c.Request().Context(), sd.ReorderMediaInput{
+		AuctionID: input.AuctionID,
+		SellerID:  sellerID,
+		MediaIDs:  input.MediaIDs,
+	})
+	if err != nil {
+		return mediaErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.ListMediaOutput{Media: hmap.ToMediaDTOs(media)})
+}
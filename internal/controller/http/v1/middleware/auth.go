@@ -0,0 +1,298 @@
+package mw
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/auth"
+	"auction-platform/internal/infrastruct/ratelimit"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	identityKey  = "identity"
+	headerAPIKey = "X-API-Key"
+
+	maxCachedKeys = 10000
+)
+
+// Tokens issued before roles were introduced carry no roles claim; they keep
+// the buyer and seller rights every user had back then.
+var defaultRoles = []e.Role{e.RoleBuyer, e.RoleSeller}
+
+type Identity struct {
+	Subject string
+	Roles   []e.Role
+	APIKey  *e.APIKey
+}
+
+func (id Identity) HasRole(role e.Role) bool {
+	for _, r := range id.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the caller may use the given scope. Scopes only
+// narrow API keys; user tokens are governed by roles alone.
+func (id Identity) HasScope(scope e.APIKeyScope) bool {
+	if id.APIKey == nil {
+		return true
+	}
+	return id.APIKey.HasScope(scope)
+}
+
+func GetIdentity(c echo.Context) (Identity, bool) {
+	id, ok := c.Get(identityKey).(Identity)
+	return id, ok
+}
+
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, plain string) (e.APIKey, error)
+}
+
+type cachedKey struct {
+	key     *e.APIKey // nil when the key was rejected
+	expires time.Time
+}
+
+// Authenticator verifies API keys against the database before the rate
+// limiter has seen the request, so lookups that miss the short-lived key
+// cache are throttled per IP on their own.
+type Authenticator struct {
+	jwt         *auth.JWTValidator
+	keys        APIKeyVerifier
+	lookups     ratelimit.Limiter
+	lookupRPS   float64
+	lookupBurst int
+	cacheTTL    time.Duration
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cachedKey
+}
+
+func NewAuthenticator(
+	jwt *auth.JWTValidator,
+	keys APIKeyVerifier,
+	lookups ratelimit.Limiter,
+	lookupRPS float64,
+	lookupBurst int,
+	cacheTTL time.Duration,
+) *Authenticator {
+	return &Authenticator{
+		jwt:         jwt,
+		keys:        keys,
+		lookups:     lookups,
+		lookupRPS:   lookupRPS,
+		lookupBurst: lookupBurst,
+		cacheTTL:    cacheTTL,
+		cache:       make(map[[sha256.Size]byte]cachedKey),
+	}
+}
+
+// Identify resolves the caller from an API key or bearer token when one is
+// present and leaves anonymous requests untouched, so the rate limiter can
+// tell clients apart before routing. Middleware enforces authentication.
+func (a *Authenticator) Identify() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if id, err := a.authenticate(c); err == nil {
+				c.Set(identityKey, id)
+			}
+			return next(c)
+		}
+	}
+}
+
+func (a *Authenticator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := GetIdentity(c); ok {
+				return next(c)
+			}
+
+			id, err := a.authenticate(c)
+			if errors.Is(err, he.ErrTooManyKeyLookups) {
+				log.Warnf("API key lookups throttled: ip=%s path=%s", c.RealIP(), c.Path())
+				return c.JSON(http.StatusTooManyRequests, hd.ErrorOutput{
+					Error: hd.APIError{Code: he.ErrCodeRateLimited, Message: err.Error()},
+				})
+			}
+			if err != nil {
+				log.Debugf("Rejected credentials: ip=%s path=%s: %v", c.RealIP(), c.Path(), err)
+				return c.JSON(http.StatusUnauthorized, hd.ErrorOutput{
+					Error: hd.APIError{Code: he.ErrCodeUnauthorized, Message: err.Error()},
+				})
+			}
+
+			c.Set(identityKey, id)
+			return next(c)
+		}
+	}
+}
+
+func (a *Authenticator) authenticate(c echo.Context) (Identity, error) {
+	if key := c.Request().Header.Get(headerAPIKey); key != "" {
+		k, err := a.verifyAPIKey(c, key)
+		if err != nil {
+			return Identity{}, err
+		}
+		return Identity{Subject: k.OwnerID, Roles: defaultRoles, APIKey: k}, nil
+	}
+
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || token == "" {
+		return Identity{}, he.ErrMissingToken
+	}
+
+	claims, err := a.jwt.Parse(token)
+	if err != nil {
+		log.Debugf("Rejected token: ip=%s: %v", c.RealIP(), err)
+		return Identity{}, he.ErrInvalidToken
+	}
+
+	roles := defaultRoles
+	if len(claims.Roles) > 0 {
+		roles = make([]e.Role, 0, len(claims.Roles))
+		for _, r := range claims.Roles {
+			roles = append(roles, e.Role(r))
+		}
+	}
+
+	return Identity{Subject: claims.Subject, Roles: roles}, nil
+}
+
+// verifyAPIKey answers from the cache when it can. Misses cost a database
+// lookup, so they are limited per IP before reaching the verifier; a revoked
+// key may therefore keep working for up to cacheTTL.
+func (a *Authenticator) verifyAPIKey(c echo.Context, plain string) (*e.APIKey, error) {
+	ctx := c.Request().Context()
+	sum := sha256.Sum256([]byte(plain))
+
+	if k, ok := a.cached(sum); ok {
+		if k == nil {
+			return nil, he.ErrInvalidAPIKey
+		}
+		return k, nil
+	}
+
+	res, err := a.lookups.Allow(ctx, "apikey_lookup:"+c.RealIP(), a.lookupRPS, a.lookupBurst)
+	if err == nil && !res.Allowed {
+		return nil, he.ErrTooManyKeyLookups
+	}
+
+	k, err := a.keys.VerifyAPIKey(ctx, plain)
+	switch {
+	case errors.Is(err, se.ErrInvalidAPIKey):
+		a.store(sum, nil)
+		log.Debugf("Rejected api key: ip=%s: %v", c.RealIP(), err)
+		return nil, he.ErrInvalidAPIKey
+	case err != nil:
+		log.Debugf("Cannot verify api key: ip=%s: %v", c.RealIP(), err)
+		return nil, he.ErrInvalidAPIKey
+	}
+
+	a.store(sum, &k)
+	return &k, nil
+}
+
+func (a *Authenticator) cached(sum [sha256.Size]byte) (*e.APIKey, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, ok := a.cache[sum]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.key, true
+}
+
+func (a *Authenticator) store(sum [sha256.Size]byte, k *e.APIKey) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if len(a.cache) >= maxCachedKeys {
+		for s, entry := range a.cache {
+			if now.After(entry.expires) {
+				delete(a.cache, s)
+			}
+		}
+		if len(a.cache) >= maxCachedKeys {
+			clear(a.cache)
+		}
+	}
+	a.cache[sum] = cachedKey{key: k, expires: now.Add(a.cacheTTL)}
+}
+
+func RequireRoles(roles ...e.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id, ok := GetIdentity(c)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, hd.ErrorOutput{
+					Error: hd.APIError{Code: he.ErrCodeUnauthorized, Message: he.ErrMissingToken.Error()},
+				})
+			}
+
+			for _, role := range roles {
+				if id.HasRole(role) {
+					return next(c)
+				}
+			}
+
+			log.Warnf("Access denied: user=%s path=%s", id.Subject, c.Path())
+			return c.JSON(http.StatusForbidden, hd.ErrorOutput{
+				Error: hd.APIError{Code: he.ErrCodeForbidden, Message: he.ErrInsufficientRole.Error()},
+			})
+		}
+	}
+}
+
+func RequireScopes(scopes ...e.APIKeyScope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id, ok := GetIdentity(c)
+			if !ok {
+				return next(c)
+			}
+
+			for _, scope := range scopes {
+				if !id.HasScope(scope) {
+					log.Warnf("Scope denied: key=%d scope=%s path=%s", id.APIKey.KeyID, scope, c.Path())
+					return c.JSON(http.StatusForbidden, hd.ErrorOutput{
+						Error: hd.APIError{Code: he.ErrCodeForbidden, Message: he.ErrMissingScope.Error()},
+					})
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+func RequireUserToken() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if id, ok := GetIdentity(c); ok && id.APIKey != nil {
+				return c.JSON(http.StatusForbidden, hd.ErrorOutput{
+					Error: hd.APIError{Code: he.ErrCodeForbidden, Message: he.ErrAPIKeyNotAllowed.Error()},
+				})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package mw
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	"auction-platform/internal/infrastruct/idempotency"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderIdempotencyKey   = "Idempotency-Key"
+	headerIdempotentReplay = "Idempotent-Replayed"
+	maxIdempotencyKeyLen   = 255
+)
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+type Idempotency struct {
+	store   *idempotency.Store
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+func NewIdempotency(store *idempotency.Store, ttl, lockTTL time.Duration) *Idempotency {
+	return &Idempotency{store: store, ttl: ttl, lockTTL: lockTTL}
+}
+
+// Middleware replays the stored response when a request is retried with the
+// same Idempotency-Key and body. Keys are scoped to the caller and route, so
+// it must run after authentication. Server errors are not stored, letting
+// the client retry them.
+func (i *Idempotency) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLen {
+				return c.JSON(http.StatusBadRequest, hd.ErrorOutput{
+					Error: hd.APIError{Code: he.ErrCodeInvalidParams, Message: he.ErrInvalidIdempotencyKey.Error()},
+				})
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, hd.ErrorOutput{
+					Error: hd.APIError{Code: he.ErrCodeInvalidParams, Message: he.ErrInvalidParams.Error()},
+				})
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			caller := c.RealIP()
+			if id, ok := GetIdentity(c); ok {
+				caller = id.Subject
+			}
+			storeKey := caller + ":" + c.Request().Method + ":" + c.Path() + ":" + key
+			fingerprint := requestFingerprint(c.Request().Method, c.Path(), body)
+
+			ctx := c.Request().Context()
+			rec, reserved, err := i.store.Reserve(ctx, storeKey, fingerprint, i.lockTTL)
+			switch {
+			case errors.Is(err, idempotency.ErrConflict):
+				return idempotencyInProgress(c)
+			case err != nil:
+				log.Warnf("Idempotency store unavailable, processing without key: %v", err)
+				return next(c)
+			case !reserved:
+				if rec.Fingerprint != fingerprint {
+					return c.JSON(http.StatusUnprocessableEntity, hd.ErrorOutput{
+						Error: hd.APIError{Code: he.ErrCodeIdempotencyKeyReused, Message: he.ErrIdempotencyKeyReused.Error()},
+					})
+				}
+				if !rec.Completed {
+					return idempotencyInProgress(c)
+				}
+				c.Response().Header().Set(headerIdempotentReplay, "true")
+				return c.Blob(rec.StatusCode, rec.ContentType, rec.Body)
+			}
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			handlerErr := next(c)
+			c.Response().Writer = recorder.ResponseWriter
+
+			status := c.Response().Status
+			if !c.Response().Committed || status >= http.StatusInternalServerError {
+				if err := i.store.Release(ctx, storeKey); err != nil {
+					log.Warnf("Failed to release idempotency key: %v", err)
+				}
+				return handlerErr
+			}
+
+			err = i.store.Complete(ctx, storeKey, idempotency.Record{
+				Fingerprint: fingerprint,
+				StatusCode:  status,
+				ContentType: c.Response().Header().Get(echo.HeaderContentType),
+				Body:        recorder.body.Bytes(),
+			}, i.ttl)
+			if err != nil {
+				log.Warnf("Failed to store idempotent response: %v", err)
+			}
+			return handlerErr
+		}
+	}
+}
+
+func idempotencyInProgress(c echo.Context) error {
+	c.Response().Header().Set("Retry-After", "1")
+	return c.JSON(http.StatusConflict, hd.ErrorOutput{
+		Error: hd.APIError{Code: he.ErrCodeIdempotencyInProgress, Message: he.ErrIdempotencyInProgress.Error()},
+	})
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -3,71 +3,202 @@ package mw
 import (
 	hd "auction-platform/internal/controller/http/v1/dto"
 	he "auction-platform/internal/controller/http/v1/errors"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/ratelimit"
 	"auction-platform/internal/metrics"
+	"context"
+	"errors"
+	"math"
 	"net/http"
-	"sync"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/time/rate"
+	"github.com/sony/gobreaker"
 )
 
-type RateLimiter struct {
-	global  *rate.Limiter
-	perIP   map[string]*rate.Limiter
-	mu      sync.RWMutex
-	rps     rate.Limit
+const (
+	IdentityIP     = "ip"
+	IdentityUser   = "user"
+	IdentityAPIKey = "api_key"
+
+	rateLimitBreaker = "redis_ratelimit"
+)
+
+// RateLimitPolicy limits requests matching Method and Path per identity.
+// Path is an echo route path; a trailing "*" matches by prefix and an empty
+// Method matches any method. Identity falls back from api_key to user to ip
+// when the caller does not carry the requested kind.
+type RateLimitPolicy struct {
+	Name     string
+	Method   string
+	Path     string
+	Identity string
+	RPS      float64
+	Burst    int
+}
+
+func (p RateLimitPolicy) matches(method, path string) bool {
+	if p.Method != "" && !strings.EqualFold(p.Method, method) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(p.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return p.Path == path
+}
+
+type bucket struct {
+	key     string
+	rps     float64
 	burst   int
-	metrics *metrics.Metrics
+	message string
 }
 
-func NewRateLimiter(rps float64, burst int, m *metrics.Metrics) *RateLimiter {
+type RateLimiter struct {
+	store    ratelimit.Limiter
+	fallback ratelimit.Limiter
+	breaker  *circuitbreaker.CircuitBreaker
+	global   RateLimitPolicy
+	perIP    RateLimitPolicy
+	policies []RateLimitPolicy
+	metrics  *metrics.Metrics
+}
+
+func NewRateLimiter(
+	store ratelimit.Limiter,
+	fallback ratelimit.Limiter,
+	breaker *circuitbreaker.CircuitBreaker,
+	rps float64,
+	burst int,
+	policies []RateLimitPolicy,
+	m *metrics.Metrics,
+) *RateLimiter {
+	valid := make([]RateLimitPolicy, 0, len(policies))
+	for _, p := range policies {
+		if p.RPS <= 0 || p.Burst < 1 {
+			log.Warnf("Ignoring rate limit policy %q: rps and burst must be positive", p.Name)
+			continue
+		}
+		valid = append(valid, p)
+	}
+
 	return &RateLimiter{
-		global:  rate.NewLimiter(rate.Limit(rps), burst),
-		perIP:   make(map[string]*rate.Limiter),
-		rps:     rate.Limit(rps / 10),
-		burst:   burst / 10,
-		metrics: m,
+		store:    store,
+		fallback: fallback,
+		breaker:  breaker,
+		global:   RateLimitPolicy{Name: "global", RPS: rps, Burst: burst},
+		perIP:    RateLimitPolicy{Name: "default", Identity: IdentityIP, RPS: rps / 10, Burst: burst / 10},
+		policies: valid,
+		metrics:  m,
+	}
+}
+
+func (rl *RateLimiter) allow(ctx context.Context, b bucket) ratelimit.Result {
+	result, err := rl.breaker.Execute(rateLimitBreaker, func() (any, error) {
+		return rl.store.Allow(ctx, b.key, b.rps, b.burst)
+	})
+	if err == nil {
+		return result.(ratelimit.Result)
+	}
+
+	if !errors.Is(err, gobreaker.ErrOpenState) && !errors.Is(err, gobreaker.ErrTooManyRequests) {
+		log.Warnf("Rate limit store failed, using local fallback: %v", err)
+	}
+	res, _ := rl.fallback.Allow(ctx, b.key, b.rps, b.burst)
+	return res
+}
+
+func (rl *RateLimiter) policyBucket(c echo.Context, p RateLimitPolicy) bucket {
+	id, authenticated := GetIdentity(c)
+
+	kind, value := IdentityIP, c.RealIP()
+	switch {
+	case p.Identity == IdentityAPIKey && authenticated && id.APIKey != nil:
+		kind, value = IdentityAPIKey, strconv.FormatInt(id.APIKey.KeyID, 10)
+	case (p.Identity == IdentityAPIKey || p.Identity == IdentityUser) && authenticated:
+		kind, value = IdentityUser, id.Subject
+	}
+
+	message := "too many requests"
+	if kind == IdentityIP {
+		message = "too many requests from your IP"
+	}
+
+	return bucket{
+		key:     "policy:" + p.Name + ":" + kind + ":" + value,
+		rps:     p.RPS,
+		burst:   p.Burst,
+		message: message,
 	}
 }
 
-func (rl *RateLimiter) getIPLimiter(ip string) *rate.Limiter {
-	rl.mu.RLock()
-	limiter, exists := rl.perIP[ip]
-	rl.mu.RUnlock()
-	if exists {
-		return limiter
+// buckets lists the limits a request must pass, most specific last. A matched
+// route policy applies on top of an API key's own quota; otherwise the key
+// quota replaces the default per-IP limit.
+func (rl *RateLimiter) buckets(c echo.Context) []bucket {
+	buckets := []bucket{{key: "global", rps: rl.global.RPS, burst: rl.global.Burst, message: "too many requests"}}
+
+	id, ok := GetIdentity(c)
+	if ok && id.APIKey != nil {
+		buckets = append(buckets, bucket{
+			key:     "apikey:" + strconv.FormatInt(id.APIKey.KeyID, 10),
+			rps:     id.APIKey.RateLimitRPS,
+			burst:   id.APIKey.RateLimitBurst,
+			message: "too many requests for this api key",
+		})
 	}
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-	if limiter, exists = rl.perIP[ip]; exists {
-		return limiter
+
+	path := c.Path()
+	if path == "" {
+		path = c.Request().URL.Path
+	}
+	for _, p := range rl.policies {
+		if p.matches(c.Request().Method, path) {
+			return append(buckets, rl.policyBucket(c, p))
+		}
+	}
+
+	if !ok || id.APIKey == nil {
+		buckets = append(buckets, rl.policyBucket(c, rl.perIP))
 	}
-	limiter = rate.NewLimiter(rl.rps, rl.burst)
-	rl.perIP[ip] = limiter
-	return limiter
+	return buckets
 }
 
 func (rl *RateLimiter) Middleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if !rl.global.Allow() {
-				rl.metrics.RateLimiterRejected.Inc()
-				log.Warnf("Global rate limit: ip=%s path=%s", c.RealIP(), c.Path())
-				return c.JSON(http.StatusTooManyRequests, hd.ErrorOutput{
-					Error: hd.APIError{Code: he.ErrCodeRateLimited, Message: "too many requests"},
-				})
-			}
+			ctx := c.Request().Context()
 
-			if !rl.getIPLimiter(c.RealIP()).Allow() {
-				rl.metrics.RateLimiterRejected.Inc()
-				log.Warnf("Per-IP rate limit: ip=%s path=%s", c.RealIP(), c.Path())
-				return c.JSON(http.StatusTooManyRequests, hd.ErrorOutput{
-					Error: hd.APIError{Code: he.ErrCodeRateLimited, Message: "too many requests from your IP"},
-				})
+			var last ratelimit.Result
+			for _, b := range rl.buckets(c) {
+				last = rl.allow(ctx, b)
+				if !last.Allowed {
+					rl.metrics.RateLimiterRejected.Inc()
+					log.Warnf("Rate limit: bucket=%s ip=%s path=%s", b.key, c.RealIP(), c.Path())
+					setRateLimitHeaders(c, last)
+					c.Response().Header().Set("Retry-After", strconv.Itoa(ceilSeconds(last.RetryAfter)))
+					return c.JSON(http.StatusTooManyRequests, hd.ErrorOutput{
+						Error: hd.APIError{Code: he.ErrCodeRateLimited, Message: b.message},
+					})
+				}
 			}
 
+			setRateLimitHeaders(c, last)
 			return next(c)
 		}
 	}
 }
+
+func setRateLimitHeaders(c echo.Context, res ratelimit.Result) {
+	h := c.Response().Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
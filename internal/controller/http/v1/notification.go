@@ -0,0 +1,88 @@
+package httpapi
+
+import (
+	"math"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+type notificationRoutes struct {
+	notificationService service.Notifications
+}
+
+func newNotificationRoutes(g *echo.Group, nServ service.Notifications) {
+	r := &notificationRoutes{notificationService: nServ}
+
+	g.GET("", r.list)
+	g.POST("/read", r.markRead)
+}
+
+func (r *notificationRoutes) list(c echo.Context) error {
+	var input hd.ListNotificationsInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	userID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	notifications, total, err := r.notificationService.ListNotifications(ctx, userID, input.UnreadOnly, input.Page, input.PageSize)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	unread, err := r.notificationService.CountUnread(ctx, userID)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListNotificationsOutput{
+		Notifications: hmap.ToNotificationDTOs(notifications),
+		Unread:        unread,
+		Total:         total,
+		Page:          input.Page,
+		PageSize:      input.PageSize,
+		TotalPages:    int(math.Ceil(float64(total) / float64(input.PageSize))),
+	})
+}
+
+func (r *notificationRoutes) markRead(c echo.Context) error {
+	var input hd.MarkNotificationsReadInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	userID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	updated, err := r.notificationService.MarkRead(c.Request().Context(), userID, input.NotificationIDs)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.MarkNotificationsReadOutput{Updated: updated})
+}
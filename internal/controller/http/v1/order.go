@@ -0,0 +1,142 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type orderRoutes struct {
+	orderService service.Orders
+}
+
+func newOrderRoutes(g *echo.Group, oServ service.Orders) {
+	r := &orderRoutes{orderService: oServ}
+
+	g.GET("", r.list)
+	g.GET("/get", r.get)
+	g.POST("/pay", r.pay)
+	g.POST("/offer/accept", r.acceptOffer)
+	g.POST("/offer/decline", r.declineOffer)
+}
+
+func (r *orderRoutes) list(c echo.Context) error {
+	var input hd.ListOrdersInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	userID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	orders, total, err := r.orderService.ListOrders(c.Request().Context(), sd.ListOrdersInput{
+		UserID:   userID,
+		AsSeller: input.Role == "seller",
+		Page:     input.Page,
+		PageSize: input.PageSize,
+	})
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListOrdersOutput{
+		Orders:     hmap.ToOrderDTOs(orders),
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(input.PageSize))),
+	})
+}
+
+func (r *orderRoutes) get(c echo.Context) error {
+	var input hd.OrderIDInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	userID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	order, err := r.orderService.GetOrder(c.Request().Context(), userID, input.OrderID)
+	if err != nil {
+		return orderError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.OrderOutput{Order: hmap.ToOrderDTO(order)})
+}
+
+func (r *orderRoutes) pay(c echo.Context) error {
+	return r.act(c, r.orderService.PayOrder)
+}
+
+func (r *orderRoutes) acceptOffer(c echo.Context) error {
+	return r.act(c, r.orderService.AcceptOffer)
+}
+
+func (r *orderRoutes) declineOffer(c echo.Context) error {
+	return r.act(c, r.orderService.DeclineOffer)
+}
+
+func (r *orderRoutes) act(c echo.Context, fn func(ctx context.Context, buyerID string, orderID int64) (e.Order, error)) error {
+	var input hd.OrderIDInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	buyerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	order, err := fn(c.Request().Context(), buyerID, input.OrderID)
+	if err != nil {
+		return orderError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.OrderOutput{Order: hmap.ToOrderDTO(order)})
+}
+
+func orderError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundOrder):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrOrderNotPayable):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeOrderNotPayable, err.Error())
+	case errors.Is(err, se.ErrOfferNotOpen):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeOfferNotOpen, err.Error())
+	case errors.Is(err, se.ErrInsufficientFunds):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeInsufficientFunds, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
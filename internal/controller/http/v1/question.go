@@ -0,0 +1,122 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type questionRoutes struct {
+	questionService service.Questions
+}
+
+func newQuestionRoutes(g *echo.Group, qServ service.Questions, authMW echo.MiddlewareFunc) {
+	r := &questionRoutes{questionService: qServ}
+
+	g.POST("", r.ask, authMW, mw.RequireUserToken())
+	g.POST("/answer", r.answer, authMW, mw.RequireUserToken(), mw.RequireRoles(e.RoleSeller, e.RoleAdmin))
+	g.GET("", r.list, mw.RequireScopes(e.ScopeAuctionsRead))
+}
+
+func questionErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundAuction), errors.Is(err, se.ErrNotFoundQuestion):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrAuctionNotActive):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionEnded, err.Error())
+	case errors.Is(err, se.ErrQuestionAlreadyAnswered):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeQuestionAnswered, err.Error())
+	case errors.Is(err, se.ErrUserBanned):
+		return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeUserBanned, err.Error())
+	case errors.Is(err, se.ErrSellerCannotAsk):
+		return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeForbidden, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
+func (r *questionRoutes) ask(c echo.Context) error {
+	var input hd.AskQuestionInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	askerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	question, err := r.questionService.Ask(c.Request().Context(), sd.AskQuestionInput{
+		AuctionID: input.AuctionID,
+		AskerID:   askerID,
+		Question:  input.Question,
+	})
+	if err != nil {
+		return questionErr(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, hd.QuestionOutput{Question: hmap.ToQuestionDTO(question)})
+}
+
+// answer publishes the answer unless public is explicitly false.
+func (r *questionRoutes) answer(c echo.Context) error {
+	var input hd.AnswerQuestionInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	question, err := r.questionService.Answer(c.Request().Context(), sd.AnswerQuestionInput{
+		QuestionID: input.QuestionID,
+		SellerID:   sellerID,
+		Answer:     input.Answer,
+		Public:     input.Public == nil || *input.Public,
+	})
+	if err != nil {
+		return questionErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.QuestionOutput{Question: hmap.ToQuestionDTO(question)})
+}
+
+func (r *questionRoutes) list(c echo.Context) error {
+	var input hd.ListQuestionsInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	var viewerID string
+	if id, ok := mw.GetIdentity(c); ok {
+		viewerID = id.Subject
+	}
+
+	questions, err := r.questionService.ListQuestions(c.Request().Context(), input.AuctionID, viewerID)
+	if err != nil {
+		return questionErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.ListQuestionsOutput{Questions: hmap.ToQuestionDTOs(questions)})
+}
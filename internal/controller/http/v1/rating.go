@@ -0,0 +1,88 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ratingRoutes struct {
+	ratingService service.Ratings
+}
+
+func newRatingRoutes(ratings, users *echo.Group, rServ service.Ratings) {
+	r := &ratingRoutes{ratingService: rServ}
+
+	ratings.POST("", r.rate)
+	users.GET("/profile", r.profile)
+}
+
+func ratingErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundAuction):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrInvalidRatingScore), errors.Is(err, se.ErrRateeRequired), errors.Is(err, se.ErrSelfRating):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	case errors.Is(err, se.ErrAuctionNotSold):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionNotSold, err.Error())
+	case errors.Is(err, se.ErrNotTradeParty):
+		return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeNotTradeParty, err.Error())
+	case errors.Is(err, se.ErrAlreadyRated):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAlreadyRated, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
+func (r *ratingRoutes) rate(c echo.Context) error {
+	var input hd.RateInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	raterID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	rating, err := r.ratingService.Rate(c.Request().Context(), sd.RateInput{
+		AuctionID: input.AuctionID,
+		RaterID:   raterID,
+		RateeID:   input.UserID,
+		Score:     input.Score,
+		Comment:   input.Comment,
+	})
+	if err != nil {
+		return ratingErr(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, hd.RatingOutput{Rating: hmap.ToRatingDTO(rating)})
+}
+
+func (r *ratingRoutes) profile(c echo.Context) error {
+	var input hd.GetProfileInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	profile, err := r.ratingService.GetProfile(c.Request().Context(), input.UserID)
+	if err != nil {
+		return ratingErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hmap.ToUserProfileOutput(profile))
+}
@@ -0,0 +1,159 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type registrationRoutes struct {
+	registrationService service.Registrations
+}
+
+func newRegistrationRoutes(bidder, seller *echo.Group, regServ service.Registrations) {
+	r := &registrationRoutes{registrationService: regServ}
+
+	bidder.POST("", r.register, mw.RequireRoles(e.RoleBuyer, e.RoleAdmin))
+	bidder.GET("", r.get)
+	seller.GET("/registrations", r.list)
+	seller.POST("/registrations/review", r.review)
+}
+
+func registrationErr(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, se.ErrNotFoundAuction), errors.Is(err, se.ErrNotFoundRegistration):
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	case errors.Is(err, se.ErrRegistrationAlreadyExists):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAlreadyExists, err.Error())
+	case errors.Is(err, se.ErrRegistrationNotRequired):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeRegistrationNotRequired, err.Error())
+	case errors.Is(err, se.ErrRegistrationNotPending):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeRegistrationNotPending, err.Error())
+	case errors.Is(err, se.ErrAuctionNotActive):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeAuctionEnded, err.Error())
+	case errors.Is(err, se.ErrUserBanned):
+		return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeUserBanned, err.Error())
+	case errors.Is(err, se.ErrBlockedBySeller):
+		return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeBlockedBySeller, err.Error())
+	case errors.Is(err, se.ErrSellerCannotBid), errors.Is(err, se.ErrNotInvited):
+		return ut.NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeForbidden, err.Error())
+	case errors.Is(err, se.ErrInsufficientFunds):
+		return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeInsufficientFunds, err.Error())
+	case errors.Is(err, se.ErrNoFXRate):
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeFXRateUnavailable, err.Error())
+	}
+	return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+}
+
+func (r *registrationRoutes) register(c echo.Context) error {
+	var input hd.RegisterInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	bidderID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	reg, err := r.registrationService.Register(c.Request().Context(), input.AuctionID, bidderID)
+	if err != nil {
+		return registrationErr(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, hd.RegistrationOutput{Registration: hmap.ToRegistrationDTO(reg)})
+}
+
+func (r *registrationRoutes) get(c echo.Context) error {
+	var input hd.GetRegistrationInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	bidderID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	reg, err := r.registrationService.GetRegistration(c.Request().Context(), input.AuctionID, bidderID)
+	if err != nil {
+		return registrationErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.RegistrationOutput{Registration: hmap.ToRegistrationDTO(reg)})
+}
+
+func (r *registrationRoutes) list(c echo.Context) error {
+	var input hd.ListRegistrationsInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	reviewerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+	id, _ := mw.GetIdentity(c)
+
+	regs, err := r.registrationService.ListRegistrations(c.Request().Context(), sd.ListRegistrationsInput{
+		ReviewerID: reviewerID,
+		IsAdmin:    id.HasRole(e.RoleAdmin),
+		AuctionID:  input.AuctionID,
+		Status:     e.RegistrationStatus(input.Status),
+	})
+	if err != nil {
+		return registrationErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.ListRegistrationsOutput{Registrations: hmap.ToRegistrationDTOs(regs)})
+}
+
+func (r *registrationRoutes) review(c echo.Context) error {
+	var input hd.ReviewRegistrationInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	reviewerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+	id, _ := mw.GetIdentity(c)
+
+	reg, err := r.registrationService.ReviewRegistration(c.Request().Context(), sd.ReviewRegistrationInput{
+		ReviewerID: reviewerID,
+		IsAdmin:    id.HasRole(e.RoleAdmin),
+		AuctionID:  input.AuctionID,
+		BidderID:   input.BidderID,
+		Approve:    input.Approve,
+		Reason:     input.Reason,
+	})
+	if err != nil {
+		return registrationErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.RegistrationOutput{Registration: hmap.ToRegistrationDTO(reg)})
+}
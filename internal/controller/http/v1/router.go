@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	mw "auction-platform/internal/controller/http/v1/middleware"
+	e "auction-platform/internal/entity"
 	"auction-platform/internal/metrics"
 	"auction-platform/internal/service"
 	"net/http"
@@ -19,14 +20,15 @@ func ConfigureRouter(
 	m *metrics.Metrics,
 	pool *pgxpool.Pool,
 	rdb *redis.Client,
-	rps float64,
-	burst int,
+	authenticator *mw.Authenticator,
+	rl *mw.RateLimiter,
+	idem *mw.Idempotency,
 ) {
 	handler.Use(middleware.Recover())
 	handler.Use(mw.LoggingMiddleware())
 	handler.Use(mw.MetricsMiddleware(m))
 
-	rl := mw.NewRateLimiter(rps, burst, m)
+	handler.Use(authenticator.Identify())
 	handler.Use(rl.Middleware())
 
 	handler.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
@@ -35,8 +37,30 @@ func ConfigureRouter(
 
 	api := handler.Group("/api/v1")
 	{
-		newAuctionRoutes(api.Group("/auction"), services.Auctions)
-		newBidRoutes(api.Group("/bid"), services.Bids)
+		authMW := authenticator.Middleware()
+		idemMW := idem.Middleware()
+		newAuctionRoutes(api.Group("/auction"), services.Auctions, authMW, idemMW)
+		newImportRoutes(api.Group("/auction"), services.Imports, authMW, idemMW)
+		newQuestionRoutes(api.Group("/auction/questions"), services.Questions, authMW)
+		newMediaRoutes(api.Group("/auction/media"), services.Media, authMW)
+		newTemplateRoutes(api.Group("/auction/templates", authMW, mw.RequireRoles(e.RoleSeller, e.RoleAdmin)), services.Templates, idemMW)
+		newBidRoutes(api.Group("/bid"), services.Bids, authMW, idemMW)
+		newWatchlistRoutes(api.Group("/watchlist", authMW), services.Watchlist)
+		newNotificationRoutes(api.Group("/notifications", authMW), services.Notifications)
+		newWebhookRoutes(api.Group("/webhooks", authMW), services.Webhooks)
+		newOrderRoutes(api.Group("/orders", authMW, mw.RequireUserToken()), services.Orders)
+		newAPIKeyRoutes(api.Group("/apikeys", authMW, mw.RequireUserToken()), services.APIKeys)
+		adminGroup := api.Group("/admin", authMW, mw.RequireUserToken(), mw.RequireRoles(e.RoleAdmin))
+		newAdminRoutes(adminGroup, services.Admin, services.Bids, services.APIKeys)
+		newFraudRoutes(adminGroup, services.Fraud)
+		newWalletRoutes(api.Group("/wallet", authMW, mw.RequireUserToken()), adminGroup, services.Wallets)
+		sellerGroup := api.Group("/seller", authMW, mw.RequireUserToken(), mw.RequireRoles(e.RoleSeller, e.RoleAdmin))
+		newStatementRoutes(sellerGroup, adminGroup, services.Statements)
+		newAllowlistRoutes(sellerGroup, services.Allowlists)
+		newBlocklistRoutes(sellerGroup, services.Blocklists)
+		newRegistrationRoutes(api.Group("/registrations", authMW, mw.RequireUserToken()), sellerGroup, services.Registrations)
+		newFXRoutes(api.Group("/fx"), adminGroup, services.FX)
+		newRatingRoutes(api.Group("/ratings", authMW, mw.RequireUserToken()), api.Group("/users"), services.Ratings)
 	}
 
 	handler.GET("/", func(c echo.Context) error {
@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type statementRoutes struct {
+	statementService service.Statements
+}
+
+func newStatementRoutes(seller, admin *echo.Group, stServ service.Statements) {
+	r := &statementRoutes{statementService: stServ}
+
+	seller.GET("/statement", r.sellerStatement)
+	admin.GET("/statements/export", r.exportStatements)
+}
+
+func (r *statementRoutes) sellerStatement(c echo.Context) error {
+	var input hd.StatementPeriodInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	from, to, err := ut.ParsePeriod(input.From, input.To)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidPeriod.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	st, err := r.statementService.SellerStatement(c.Request().Context(), sellerID, from, to)
+	if err != nil {
+		if errors.Is(err, se.ErrInvalidPeriod) {
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hmap.ToSellerStatementOutput(st))
+}
+
+func (r *statementRoutes) exportStatements(c echo.Context) error {
+	var input hd.StatementPeriodInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+	from, to, err := ut.ParsePeriod(input.From, input.To)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidPeriod.Error())
+	}
+
+	statements, err := r.statementService.SellerSummaries(c.Request().Context(), from, to)
+	if err != nil {
+		if errors.Is(err, se.ErrInvalidPeriod) {
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="statements_%s_%s.csv"`, from.Format("20060102"), to.Format("20060102")))
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	_ = w.Write(hmap.StatementCSVHeader)
+	for _, st := range statements {
+		_ = w.Write(hmap.ToStatementCSVRecord(st))
+	}
+	w.Flush()
+	return w.Error()
+}
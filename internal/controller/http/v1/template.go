@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type templateRoutes struct {
+	templateService service.Templates
+}
+
+func newTemplateRoutes(g *echo.Group, tServ service.Templates, idemMW echo.MiddlewareFunc) {
+	r := &templateRoutes{templateService: tServ}
+
+	g.POST("", r.save, mw.RequireScopes(e.ScopeAuctionsWrite))
+	g.GET("", r.list, mw.RequireScopes(e.ScopeAuctionsRead))
+	g.DELETE("", r.delete, mw.RequireScopes(e.ScopeAuctionsWrite))
+	g.POST("/launch", r.launch, mw.RequireScopes(e.ScopeAuctionsWrite), idemMW)
+}
+
+func templateErr(c echo.Context, err error) error {
+	if errors.Is(err, se.ErrNotFoundTemplate) {
+		return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+	}
+	return createAuctionErr(c, err)
+}
+
+// save stores the settings under the name, replacing the seller's template
+// of that name if there is one.
+func (r *templateRoutes) save(c echo.Context) error {
+	var input hd.SaveTemplateInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewBindErrJSON(c, err)
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	template, err := r.templateService.SaveTemplate(c.Request().Context(), hmap.ToSaveTemplateServiceInput(input, sellerID))
+	if err != nil {
+		return templateErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.TemplateOutput{Template: hmap.ToTemplateDTO(template)})
+}
+
+func (r *templateRoutes) list(c echo.Context) error {
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	templates, err := r.templateService.ListTemplates(c.Request().Context(), sellerID)
+	if err != nil {
+		return templateErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hd.ListTemplatesOutput{Templates: hmap.ToTemplateDTOs(templates)})
+}
+
+func (r *templateRoutes) delete(c echo.Context) error {
+	var input hd.DeleteTemplateInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.templateService.DeleteTemplate(c.Request().Context(), sellerID, input.TemplateID); err != nil {
+		return templateErr(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *templateRoutes) launch(c echo.Context) error {
+	var input hd.LaunchTemplateInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewBindErrJSON(c, err)
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	sellerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	serviceIn := sd.LaunchTemplateInput{
+		SellerID:    sellerID,
+		TemplateID:  input.TemplateID,
+		AuctionID:   input.AuctionID,
+		DurationMin: input.DurationMin,
+	}
+	serviceIn.ClientIP, serviceIn.DeviceID = ut.ClientDevice(c)
+
+	auction, err := r.templateService.LaunchTemplate(c.Request().Context(), serviceIn)
+	if err != nil {
+		return templateErr(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, hd.CreateAuctionOutput{Auction: hmap.ToAuctionDTO(auction)})
+}
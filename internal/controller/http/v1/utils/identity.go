@@ -0,0 +1,21 @@
+package httputils
+
+import (
+	"net/http"
+
+	he "auction-platform/internal/controller/http/v1/errors"
+	mw "auction-platform/internal/controller/http/v1/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func ResolveSubject(c echo.Context, claimed string) (string, error) {
+	id, ok := mw.GetIdentity(c)
+	if !ok {
+		return "", NewErrReasonJSON(c, http.StatusUnauthorized, he.ErrCodeUnauthorized, he.ErrMissingToken.Error())
+	}
+	if claimed != "" && claimed != id.Subject {
+		return "", NewErrReasonJSON(c, http.StatusForbidden, he.ErrCodeForbidden, he.ErrIdentityMismatch.Error())
+	}
+	return id.Subject, nil
+}
@@ -0,0 +1,154 @@
+package httputils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+)
+
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+)
+
+// ImportRow is one auction of a bulk import. Err is set when the row could
+// not be decoded; Line is the 1-based line it starts on.
+type ImportRow struct {
+	Line  int
+	Input hd.CreateAuctionInput
+	Err   string
+}
+
+// CSV columns are named after the JSON fields of CreateAuctionInput.
+// increments, eligibility and relist hold JSON, invited_bidders is ';'
+// separated.
+var (
+	csvIntColumns  = map[string]bool{"quantity": true, "duration_min": true}
+	csvJSONColumns = map[string]bool{"increments": true, "eligibility": true, "relist": true}
+	csvColumns     = map[string]bool{
+		"auction_id": true, "title": true, "description": true, "category": true, "seller_id": true,
+		"start_price": true, "min_step": true, "currency": true, "quantity": true, "pricing": true,
+		"type": true, "visibility": true, "duration_min": true, "increments": true, "eligibility": true,
+		"invited_bidders": true, "reserve_price": true, "relist": true,
+	}
+)
+
+// ParseImport splits an import body into rows. Only a malformed file as a
+// whole is an error; a bad row is returned with Err set.
+func ParseImport(format string, body []byte) ([]ImportRow, error) {
+	if format == ImportFormatCSV {
+		return parseCSVImport(body)
+	}
+	return parseJSONLImport(body)
+}
+
+func parseJSONLImport(body []byte) ([]ImportRow, error) {
+	sc := bufio.NewScanner(bytes.NewReader(body))
+	sc.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+
+	var rows []ImportRow
+	for line := 1; sc.Scan(); line++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		row := ImportRow{Line: line}
+		if err := json.Unmarshal(text, &row.Input); err != nil {
+			row.Err = "invalid JSON: " + err.Error()
+		}
+		rows = append(rows, row)
+	}
+	return rows, sc.Err()
+}
+
+func parseCSVImport(body []byte) ([]ImportRow, error) {
+	r := csv.NewReader(bytes.NewReader(body))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+	for i, col := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\uFEFF")))
+		if !csvColumns[header[i]] {
+			return nil, fmt.Errorf("csv header: unknown column %q", col)
+		}
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		line, _ := r.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, ImportRow{Line: parseErr.StartLine, Err: parseErr.Err.Error()})
+			continue
+		}
+
+		row := ImportRow{Line: line}
+		if err := decodeCSVRecord(header, record, &row.Input); err != nil {
+			row.Err = err.Error()
+		}
+		rows = append(rows, row)
+	}
+}
+
+func decodeCSVRecord(header, record []string, into *hd.CreateAuctionInput) error {
+	fields := make(map[string]any, len(header))
+	for i, col := range header {
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+		switch {
+		case csvIntColumns[col]:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%s must be an integer", col)
+			}
+			fields[col] = n
+		case csvJSONColumns[col]:
+			if !json.Valid([]byte(value)) {
+				return fmt.Errorf("%s must be JSON", col)
+			}
+			fields[col] = json.RawMessage(value)
+		case col == "invited_bidders":
+			var ids []string
+			for _, id := range strings.Split(value, ";") {
+				if id = strings.TrimSpace(id); id != "" {
+					ids = append(ids, id)
+				}
+			}
+			fields[col] = ids
+		default:
+			fields[col] = value
+		}
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package httputils
+
+import (
+	"strings"
+
+	"auction-platform/pkg/money"
+)
+
+// ParseCurrency upper-cases a currency code taken from a query parameter.
+func ParseCurrency(v string) money.Currency {
+	return money.Currency(strings.ToUpper(strings.TrimSpace(v)))
+}
+
+// ParseOptionalMoney returns nil for an empty query parameter.
+func ParseOptionalMoney(v string, c money.Currency) (*money.Money, error) {
+	if v == "" {
+		return nil, nil
+	}
+	m, err := money.Parse(v, c)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,33 @@
+package httputils
+
+import (
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// ParsePeriod accepts RFC 3339 timestamps or plain dates; a plain `to` date is
+// inclusive, so the returned end is the start of the following day.
+func ParsePeriod(from, to string) (time.Time, time.Time, error) {
+	start, err := parseTime(from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end, err := time.Parse(dateLayout, to)
+	if err == nil {
+		return start, end.AddDate(0, 0, 1), nil
+	}
+	end, err = time.Parse(time.RFC3339, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+func parseTime(v string) (time.Time, error) {
+	if t, err := time.Parse(dateLayout, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
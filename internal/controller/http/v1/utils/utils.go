@@ -3,11 +3,29 @@ package httputils
 import (
 	hd "auction-platform/internal/controller/http/v1/dto"
 	he "auction-platform/internal/controller/http/v1/errors"
+	"auction-platform/pkg/money"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	headerDeviceID    = "X-Device-ID"
+	maxDeviceIDLength = 200
+)
+
+// ClientDevice returns the caller's IP address and the device ID reported by
+// the client, which may be empty.
+func ClientDevice(c echo.Context) (string, string) {
+	deviceID := c.Request().Header.Get(headerDeviceID)
+	if len(deviceID) > maxDeviceIDLength {
+		deviceID = deviceID[:maxDeviceIDLength]
+	}
+	return c.RealIP(), deviceID
+}
+
 func NewErrReasonJSON(c echo.Context, httpCode int, msgCode he.ErrorCode, msg string) error {
 	err := c.JSON(httpCode, hd.ErrorOutput{
 		Error: hd.APIError{
@@ -20,3 +38,12 @@ func NewErrReasonJSON(c echo.Context, httpCode int, msgCode he.ErrorCode, msg st
 	}
 	return fmt.Errorf("%s: %s", msgCode, msg)
 }
+
+// NewBindErrJSON reports a failed Bind, keeping the reason when a money
+// amount could not be parsed exactly.
+func NewBindErrJSON(c echo.Context, err error) error {
+	if errors.Is(err, money.ErrTooPrecise) || errors.Is(err, money.ErrInvalidAmount) {
+		return NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidAmount, he.ErrInvalidAmount.Error())
+	}
+	return NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+}
@@ -0,0 +1,124 @@
+package httpapi
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type walletRoutes struct {
+	walletService service.Wallets
+}
+
+// newWalletRoutes leaves deposits to admins: nothing checks that money was
+// actually paid in, so users cannot credit themselves.
+func newWalletRoutes(g, adminG *echo.Group, wServ service.Wallets) {
+	r := &walletRoutes{walletService: wServ}
+
+	g.GET("", r.get)
+	g.POST("/withdraw", r.withdraw)
+	g.GET("/ledger", r.ledger)
+	adminG.POST("/wallet/deposit", r.deposit)
+}
+
+func (r *walletRoutes) get(c echo.Context) error {
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	w, err := r.walletService.GetWallet(c.Request().Context(), ownerID)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.WalletOutput{Wallet: hmap.ToWalletDTO(w)})
+}
+
+func (r *walletRoutes) deposit(c echo.Context) error {
+	var input hd.DepositInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewBindErrJSON(c, err)
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	w, err := r.walletService.Deposit(c.Request().Context(), input.OwnerID, input.Amount)
+	if err != nil {
+		if errors.Is(err, se.ErrInvalidAmount) {
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidAmount, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.WalletOutput{Wallet: hmap.ToWalletDTO(w)})
+}
+
+func (r *walletRoutes) withdraw(c echo.Context) error {
+	var input hd.WalletAmountInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewBindErrJSON(c, err)
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	w, err := r.walletService.Withdraw(c.Request().Context(), ownerID, input.Amount)
+	if err != nil {
+		if errors.Is(err, se.ErrInsufficientFunds) {
+			return ut.NewErrReasonJSON(c, http.StatusConflict, he.ErrCodeInsufficientFunds, err.Error())
+		}
+		if errors.Is(err, se.ErrInvalidAmount) {
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidAmount, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.WalletOutput{Wallet: hmap.ToWalletDTO(w)})
+}
+
+func (r *walletRoutes) ledger(c echo.Context) error {
+	var input hd.ListLedgerInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	entries, total, err := r.walletService.ListEntries(c.Request().Context(), ownerID, input.Page, input.PageSize)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListLedgerOutput{
+		Entries:    hmap.ToLedgerEntryDTOs(entries),
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(input.PageSize))),
+	})
+}
@@ -0,0 +1,91 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type watchlistRoutes struct {
+	watchlistService service.Watchlist
+}
+
+func newWatchlistRoutes(g *echo.Group, wServ service.Watchlist) {
+	r := &watchlistRoutes{watchlistService: wServ}
+
+	g.POST("", r.watch)
+	g.DELETE("", r.unwatch)
+	g.GET("", r.list)
+}
+
+func (r *watchlistRoutes) watch(c echo.Context) error {
+	var input hd.WatchInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	userID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.watchlistService.Watch(c.Request().Context(), userID, input.AuctionID); err != nil {
+		if errors.Is(err, se.ErrNotFoundAuction) {
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+func (r *watchlistRoutes) unwatch(c echo.Context) error {
+	var input hd.UnwatchInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	userID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.watchlistService.Unwatch(c.Request().Context(), userID, input.AuctionID); err != nil {
+		if errors.Is(err, se.ErrNotFoundWatch) {
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *watchlistRoutes) list(c echo.Context) error {
+	userID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	items, err := r.watchlistService.GetWatchlist(c.Request().Context(), userID)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.GetWatchlistOutput{
+		Items: hmap.ToWatchlistItemDTOs(items),
+	})
+}
@@ -0,0 +1,122 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	hd "auction-platform/internal/controller/http/v1/dto"
+	he "auction-platform/internal/controller/http/v1/errors"
+	hmap "auction-platform/internal/controller/http/v1/mappers"
+	ut "auction-platform/internal/controller/http/v1/utils"
+	"auction-platform/internal/service"
+	se "auction-platform/internal/service/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type webhookRoutes struct {
+	webhookService service.Webhooks
+}
+
+func newWebhookRoutes(g *echo.Group, wServ service.Webhooks) {
+	r := &webhookRoutes{webhookService: wServ}
+
+	g.POST("", r.create)
+	g.GET("", r.list)
+	g.DELETE("", r.delete)
+	g.GET("/deliveries", r.deliveries)
+}
+
+func (r *webhookRoutes) create(c echo.Context) error {
+	var input hd.CreateWebhookInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	w, err := r.webhookService.CreateWebhook(c.Request().Context(), hmap.ToCreateWebhookServiceInput(input, ownerID))
+	if err != nil {
+		if errors.Is(err, se.ErrInvalidWebhookURL) {
+			return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidWebhookURL, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	out := hmap.ToWebhookDTO(w)
+	out.Secret = w.Secret
+	return c.JSON(http.StatusCreated, hd.CreateWebhookOutput{Webhook: out})
+}
+
+func (r *webhookRoutes) list(c echo.Context) error {
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := r.webhookService.ListWebhooks(c.Request().Context(), ownerID)
+	if err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListWebhooksOutput{
+		Webhooks: hmap.ToWebhookDTOs(webhooks),
+	})
+}
+
+func (r *webhookRoutes) delete(c echo.Context) error {
+	var input hd.DeleteWebhookInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	if err := r.webhookService.DeleteWebhook(c.Request().Context(), ownerID, input.WebhookID); err != nil {
+		if errors.Is(err, se.ErrNotFoundWebhook) {
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *webhookRoutes) deliveries(c echo.Context) error {
+	var input hd.ListWebhookDeliveriesInput
+	if err := c.Bind(&input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, he.ErrInvalidParams.Error())
+	}
+	if err := c.Validate(input); err != nil {
+		return ut.NewErrReasonJSON(c, http.StatusBadRequest, he.ErrCodeInvalidParams, err.Error())
+	}
+
+	ownerID, err := ut.ResolveSubject(c, "")
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := r.webhookService.ListDeliveries(c.Request().Context(), ownerID, input.WebhookID, input.Limit)
+	if err != nil {
+		if errors.Is(err, se.ErrNotFoundWebhook) {
+			return ut.NewErrReasonJSON(c, http.StatusNotFound, he.ErrCodeNotFound, err.Error())
+		}
+		return ut.NewErrReasonJSON(c, http.StatusInternalServerError, he.ErrCodeInternalServer, he.ErrInternalServer.Error())
+	}
+
+	return c.JSON(http.StatusOK, hd.ListWebhookDeliveriesOutput{
+		Deliveries: hmap.ToWebhookDeliveryDTOs(deliveries),
+	})
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// BidderGroup is a named set of bidders a seller can invite to private
+// auctions in one go.
+type BidderGroup struct {
+	CreatedAt time.Time `db:"created_at"`
+	GroupID   int64     `db:"group_id"`
+	SellerID  string    `db:"seller_id"`
+	Name      string    `db:"name"`
+	Members   []string
+}
+
+// Allowlist is everyone invited to bid on a private auction, directly or
+// through a group.
+type Allowlist struct {
+	AuctionID string
+	BidderIDs []string
+	Groups    []BidderGroup
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+type APIKeyScope string
+
+const (
+	ScopeAuctionsRead  APIKeyScope = "auctions:read"
+	ScopeAuctionsWrite APIKeyScope = "auctions:write"
+	ScopeBidsRead      APIKeyScope = "bids:read"
+	ScopeBidsWrite     APIKeyScope = "bids:write"
+)
+
+type APIKey struct {
+	CreatedAt      time.Time  `db:"created_at"`
+	RotatedAt      *time.Time `db:"rotated_at"`
+	RevokedAt      *time.Time `db:"revoked_at"`
+	KeyID          int64      `db:"key_id"`
+	OwnerID        string     `db:"owner_id"`
+	Name           string     `db:"name"`
+	Prefix         string     `db:"prefix"`
+	Scopes         []string   `db:"scopes"`
+	RateLimitRPS   float64    `db:"rate_limit_rps"`
+	RateLimitBurst int        `db:"rate_limit_burst"`
+}
+
+func (k APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,25 +1,141 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
 
 type AuctionStatus string
 
 const (
-	AuctionStatusActive   AuctionStatus = "ACTIVE"
-	AuctionStatusFinished AuctionStatus = "FINISHED"
+	AuctionStatusActive    AuctionStatus = "ACTIVE"
+	AuctionStatusFinished  AuctionStatus = "FINISHED"
+	AuctionStatusCancelled AuctionStatus = "CANCELLED"
+)
+
+// AuctionType decides which way prices move. In a REVERSE auction the seller is
+// buying: start_price is a ceiling and the lowest bids win.
+type AuctionType string
+
+const (
+	AuctionTypeForward AuctionType = "FORWARD"
+	AuctionTypeReverse AuctionType = "REVERSE"
+)
+
+func (t AuctionType) IsValid() bool {
+	return t == AuctionTypeForward || t == AuctionTypeReverse
+}
+
+// AuctionVisibility controls who finds and bids on an auction. UNLISTED
+// auctions are left out of listings but open to anyone with the ID; PRIVATE
+// ones are also closed to bidders who are not on the allowlist.
+type AuctionVisibility string
+
+const (
+	VisibilityPublic   AuctionVisibility = "PUBLIC"
+	VisibilityUnlisted AuctionVisibility = "UNLISTED"
+	VisibilityPrivate  AuctionVisibility = "PRIVATE"
 )
 
+func (v AuctionVisibility) IsValid() bool {
+	return v == VisibilityPublic || v == VisibilityUnlisted || v == VisibilityPrivate
+}
+
 type Auction struct {
-	CreatedAt   *time.Time    `db:"created_at"`
-	EndsAt      *time.Time    `db:"ends_at"`
-	FinishedAt  *time.Time    `db:"finished_at"`
-	AuctionID   string        `db:"auction_id"`
-	Title       string        `db:"title"`
-	Description string        `db:"description"`
-	SellerID    string        `db:"seller_id"`
-	WinnerID    string        `db:"winner_id"`
-	StartPrice  float64       `db:"start_price"`
-	CurrentBid  float64       `db:"current_bid"`
-	MinStep     float64       `db:"min_step"`
-	Status      AuctionStatus `db:"status"`
+	CreatedAt   *time.Time        `db:"created_at"`
+	EndsAt      *time.Time        `db:"ends_at"`
+	FinishedAt  *time.Time        `db:"finished_at"`
+	AuctionID   string            `db:"auction_id"`
+	Title       string            `db:"title"`
+	Description string            `db:"description"`
+	Category    string            `db:"category"`
+	SellerID    string            `db:"seller_id"`
+	WinnerID    string            `db:"winner_id"`
+	StartPrice  money.Money       `db:"start_price"`
+	CurrentBid  money.Money       `db:"current_bid"`
+	MinStep     money.Money       `db:"min_step"`
+	Currency    money.Currency    `db:"currency"`
+	Increments  IncrementTable    `db:"increments"`
+	Quantity    int               `db:"quantity"`
+	Pricing     LotPricing        `db:"pricing"`
+	Type        AuctionType       `db:"auction_type"`
+	Visibility  AuctionVisibility `db:"visibility"`
+	Eligibility EligibilityRules  `db:"eligibility"`
+	Status      AuctionStatus     `db:"status"`
+	// ReservePrice is zero when the auction has no reserve.
+	ReservePrice money.Money  `db:"reserve_price"`
+	DurationMin  int          `db:"duration_min"`
+	Relist       RelistPolicy `db:"relist_policy"`
+	// RelistCount is how many times the lot was relisted before this
+	// auction, which RelistedFrom points back to.
+	RelistCount  int    `db:"relist_count"`
+	RelistedFrom string `db:"relisted_from"`
+	Converted    *ConvertedPrices
+	// SellerRating is filled in by the service; nil when the seller has not
+	// been rated yet.
+	SellerRating *RatingSummary
+	// Answers holds the answered questions the viewer may see; it is only
+	// filled in for a single auction lookup.
+	Answers []Question
+	Media   []Media
+}
+
+// SetCurrency tags every amount of the auction with c.
+func (a *Auction) SetCurrency(c money.Currency) {
+	a.Currency = c
+	a.StartPrice.Currency = c
+	a.CurrentBid.Currency = c
+	a.MinStep.Currency = c
+	a.ReservePrice.Currency = c
+	a.Increments = a.Increments.WithCurrency(c)
+	a.Eligibility.Deposit.Currency = c
+}
+
+func (a Auction) IsReverse() bool {
+	return a.Type == AuctionTypeReverse
+}
+
+func (a Auction) IsPrivate() bool {
+	return a.Visibility == VisibilityPrivate
+}
+
+// bidStep is the increment at the current bid, from the increment table when
+// the auction has one and from MinStep otherwise.
+func (a Auction) bidStep() money.Money {
+	if len(a.Increments) > 0 {
+		return a.Increments.Step(a.CurrentBid)
+	}
+	return a.MinStep
+}
+
+// NextMinBid is the lowest bid a forward auction accepts next.
+func (a Auction) NextMinBid() money.Money {
+	return a.CurrentBid.Add(a.bidStep())
+}
+
+// NextMaxBid is the highest bid a reverse auction accepts next.
+func (a Auction) NextMaxBid() money.Money {
+	return a.CurrentBid.Sub(a.bidStep())
+}
+
+// NextBid is the limit the next bid has to reach: NextMaxBid for reverse
+// auctions and NextMinBid otherwise.
+func (a Auction) NextBid() money.Money {
+	if a.IsReverse() {
+		return a.NextMaxBid()
+	}
+	return a.NextMinBid()
+}
+
+func (a Auction) AcceptsBid(amount money.Money) bool {
+	if a.IsReverse() {
+		return amount.Cmp(a.NextMaxBid()) <= 0
+	}
+	return amount.Cmp(a.NextMinBid()) >= 0
+}
+
+type SellerAuction struct {
+	Auction
+	BidsCount int `db:"bids_count"`
 }
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
 
 type BidStatus string
 
@@ -8,13 +12,63 @@ const (
 	BidStatusPending  BidStatus = "PENDING"
 	BidStatusAccepted BidStatus = "ACCEPTED"
 	BidStatusRejected BidStatus = "REJECTED"
+	// BidStatusUnderReview bids are held back from the auction until an
+	// administrator reviews the fraud flag raised against them.
+	BidStatusUnderReview BidStatus = "UNDER_REVIEW"
 )
 
 type Bid struct {
-	CreatedAt time.Time `db:"created_at"`
-	BidID     string    `db:"bid_id"`
-	AuctionID string    `db:"auction_id"`
-	BidderID  string    `db:"bidder_id"`
-	Amount    float64   `db:"amount"`
-	Status    BidStatus `db:"status"`
+	CreatedAt time.Time   `db:"created_at"`
+	BidID     string      `db:"bid_id"`
+	AuctionID string      `db:"auction_id"`
+	BidderID  string      `db:"bidder_id"`
+	Amount    money.Money `db:"amount"`
+	Quantity  int         `db:"quantity"`
+	Status    BidStatus   `db:"status"`
+}
+
+// BidRejectCode tells clients why a bid was rejected without parsing the
+// human-readable reason.
+type BidRejectCode string
+
+const (
+	BidRejectAuctionNotFound   BidRejectCode = "AUCTION_NOT_FOUND"
+	BidRejectAuctionEnded      BidRejectCode = "AUCTION_ENDED"
+	BidRejectSellerCannotBid   BidRejectCode = "SELLER_CANNOT_BID"
+	BidRejectNotInvited        BidRejectCode = "NOT_INVITED"
+	BidRejectInvalidAmount     BidRejectCode = "INVALID_AMOUNT"
+	BidRejectInvalidQuantity   BidRejectCode = "INVALID_QUANTITY"
+	BidRejectTooLow            BidRejectCode = "BID_TOO_LOW"
+	BidRejectTooHigh           BidRejectCode = "BID_TOO_HIGH"
+	BidRejectInsufficientFunds BidRejectCode = "INSUFFICIENT_FUNDS"
+	BidRejectByAdmin           BidRejectCode = "REJECTED_BY_ADMIN"
+	BidRejectBanned            BidRejectCode = "BANNED"
+	BidRejectBlockedBySeller   BidRejectCode = "BLOCKED_BY_SELLER"
+	BidRejectNotRegistered     BidRejectCode = "NOT_REGISTERED"
+	BidRejectAccountTooNew     BidRejectCode = "ACCOUNT_TOO_NEW"
+	BidRejectTooManyWinning    BidRejectCode = "TOO_MANY_WINNING"
+)
+
+type BidOutcome string
+
+const (
+	BidOutcomeInProgress BidOutcome = "IN_PROGRESS"
+	BidOutcomeWon        BidOutcome = "WON"
+	BidOutcomeLost       BidOutcome = "LOST"
+)
+
+type BidderAuction struct {
+	EndsAt        *time.Time     `db:"ends_at"`
+	FinishedAt    *time.Time     `db:"finished_at"`
+	AuctionID     string         `db:"auction_id"`
+	Title         string         `db:"title"`
+	WinnerID      string         `db:"winner_id"`
+	AuctionStatus AuctionStatus  `db:"status"`
+	CurrentBid    money.Money    `db:"current_bid"`
+	MaxBid        money.Money    `db:"max_bid"`
+	Currency      money.Currency `db:"currency"`
+	BidsCount     int            `db:"bids_count"`
+	IsLeading     bool           `db:"is_leading"`
+	HasWon        bool           `db:"has_won"`
+	Outcome       BidOutcome
 }
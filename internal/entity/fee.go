@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+type FeeType string
+
+const (
+	FeeListing    FeeType = "LISTING"
+	FeeFinalValue FeeType = "FINAL_VALUE"
+)
+
+// FeeTier charges Percent of the part of the sale price up to UpTo; the last
+// tier leaves UpTo at zero to cover everything above the previous one.
+type FeeTier struct {
+	UpTo    float64
+	Percent float64
+}
+
+type FeeSchedule struct {
+	ListingFee      float64
+	FinalValueTiers []FeeTier
+}
+
+type FeePolicy struct {
+	Default    FeeSchedule
+	Categories map[string]FeeSchedule
+}
+
+func (p FeePolicy) ScheduleFor(category string) FeeSchedule {
+	if s, ok := p.Categories[category]; ok {
+		return s
+	}
+	return p.Default
+}
+
+// ListingLine is the flat fee for putting an auction up, charged once per
+// auction however many orders it ends with. It reports false when the schedule
+// has no listing fee.
+func (s FeeSchedule) ListingLine(basis money.Money) (FeeLine, bool) {
+	if s.ListingFee <= 0 {
+		return FeeLine{}, false
+	}
+	return FeeLine{
+		Type:   FeeListing,
+		Basis:  basis,
+		Amount: money.FromFloat(s.ListingFee, basis.Currency),
+	}, true
+}
+
+// Lines splits amount across the final-value tiers and returns one fee line per
+// non-empty tier.
+func (s FeeSchedule) Lines(amount money.Money) []FeeLine {
+	var lines []FeeLine
+	lower := money.Zero(amount.Currency)
+	for _, t := range s.FinalValueTiers {
+		if amount.Cmp(lower) <= 0 {
+			break
+		}
+		upper := amount
+		if t.UpTo > 0 {
+			upper = money.Min(amount, money.FromFloat(t.UpTo, amount.Currency))
+		}
+		basis := upper.Sub(lower)
+		if basis.IsPositive() && t.Percent > 0 {
+			lines = append(lines, FeeLine{
+				Type:   FeeFinalValue,
+				Basis:  basis,
+				Rate:   t.Percent,
+				Amount: basis.Percent(t.Percent),
+			})
+		}
+		if t.UpTo <= 0 {
+			break
+		}
+		lower = money.FromFloat(t.UpTo, amount.Currency)
+	}
+	return lines
+}
+
+type FeeLine struct {
+	CreatedAt time.Time   `db:"created_at"`
+	FeeLineID int64       `db:"fee_line_id"`
+	OrderID   int64       `db:"order_id"`
+	SellerID  string      `db:"seller_id"`
+	Type      FeeType     `db:"fee_type"`
+	Basis     money.Money `db:"basis"`
+	Rate      float64     `db:"rate"`
+	Amount    money.Money `db:"amount"`
+}
+
+type StatementLine struct {
+	PaidAt    time.Time   `db:"paid_at"`
+	OrderID   int64       `db:"order_id"`
+	AuctionID string      `db:"auction_id"`
+	BuyerID   string      `db:"buyer_id"`
+	Amount    money.Money `db:"amount"`
+	FXRate    float64     `db:"fx_rate"`
+	Gross     money.Money `db:"gross"`
+	Fees      money.Money `db:"fees"`
+}
+
+func (l StatementLine) Net() money.Money {
+	return l.Gross.Sub(l.Fees)
+}
+
+type SellerStatement struct {
+	From       time.Time
+	To         time.Time
+	SellerID   string
+	Orders     int64
+	GrossSales money.Money
+	Fees       money.Money
+	NetPayout  money.Money
+	Lines      []StatementLine
+}
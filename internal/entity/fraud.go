@@ -0,0 +1,107 @@
+package entity
+
+import "time"
+
+type FraudSignal string
+
+const (
+	// FraudSignalSharedDevice: the bidder was seen on an IP address or device
+	// the seller has used.
+	FraudSignalSharedDevice FraudSignal = "SHARED_DEVICE"
+	// FraudSignalSingleSeller: every auction the bidder has bid on belongs to
+	// the same seller.
+	FraudSignalSingleSeller FraudSignal = "SINGLE_SELLER"
+	// FraudSignalRetractions: the bidder has had bids withdrawn repeatedly.
+	FraudSignalRetractions FraudSignal = "REPEATED_RETRACTIONS"
+	// FraudSignalBidAndLose: the bidder keeps bidding on the seller's auctions
+	// without ever winning them, as a shill pushing prices up would.
+	FraudSignalBidAndLose FraudSignal = "BID_AND_LOSE"
+)
+
+type FraudFlagStatus string
+
+const (
+	FraudFlagOpen      FraudFlagStatus = "OPEN"
+	FraudFlagConfirmed FraudFlagStatus = "CONFIRMED"
+	FraudFlagDismissed FraudFlagStatus = "DISMISSED"
+)
+
+func (s FraudFlagStatus) IsValid() bool {
+	switch s {
+	case FraudFlagOpen, FraudFlagConfirmed, FraudFlagDismissed:
+		return true
+	}
+	return false
+}
+
+type FraudFlag struct {
+	CreatedAt  time.Time       `db:"created_at"`
+	ReviewedAt *time.Time      `db:"reviewed_at"`
+	FlagID     int64           `db:"flag_id"`
+	BidID      string          `db:"bid_id"`
+	AuctionID  string          `db:"auction_id"`
+	BidderID   string          `db:"bidder_id"`
+	SellerID   string          `db:"seller_id"`
+	Score      int             `db:"score"`
+	Signals    []FraudSignal   `db:"signals"`
+	Held       bool            `db:"held"`
+	Status     FraudFlagStatus `db:"status"`
+	ReviewedBy string          `db:"reviewed_by"`
+	Note       string          `db:"note"`
+}
+
+// BidderSellerStats describes a bidder's history relative to one seller.
+type BidderSellerStats struct {
+	Sellers        int
+	SellerAuctions int
+	LostToSeller   int
+	Retractions    int
+}
+
+// FraudPolicy scores a bid by adding up the weights of the signals it raises.
+// Bids scoring FlagScore or more are flagged for review; with HoldBids set,
+// those scoring HoldScore or more are also held out of the auction meanwhile.
+type FraudPolicy struct {
+	Weights                 map[FraudSignal]int
+	FlagScore               int
+	HoldScore               int
+	HoldBids                bool
+	MinSingleSellerAuctions int
+	RetractionThreshold     int
+	LostAuctionThreshold    int
+}
+
+// Signals returns the signals raised by stats; sharedDevice is checked
+// separately against the seller's known devices.
+func (p FraudPolicy) Signals(stats BidderSellerStats, sharedDevice bool) []FraudSignal {
+	var signals []FraudSignal
+	if sharedDevice {
+		signals = append(signals, FraudSignalSharedDevice)
+	}
+	if stats.Sellers == 1 && p.MinSingleSellerAuctions > 0 && stats.SellerAuctions >= p.MinSingleSellerAuctions {
+		signals = append(signals, FraudSignalSingleSeller)
+	}
+	if p.RetractionThreshold > 0 && stats.Retractions >= p.RetractionThreshold {
+		signals = append(signals, FraudSignalRetractions)
+	}
+	if p.LostAuctionThreshold > 0 && stats.LostToSeller >= p.LostAuctionThreshold {
+		signals = append(signals, FraudSignalBidAndLose)
+	}
+	return signals
+}
+
+func (p FraudPolicy) Score(signals []FraudSignal) int {
+	score := 0
+	for _, s := range signals {
+		score += p.Weights[s]
+	}
+	return score
+}
+
+func (p FraudPolicy) ShouldFlag(score int) bool {
+	return score > 0 && score >= p.FlagScore
+}
+
+func (p FraudPolicy) ShouldHold(score int) bool {
+	return p.HoldBids && p.ShouldFlag(score) && score >= p.HoldScore
+}
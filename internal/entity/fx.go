@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+// FXRate is the number of money.DefaultCurrency units one unit of Currency
+// buys.
+type FXRate struct {
+	UpdatedAt time.Time      `db:"updated_at"`
+	Currency  money.Currency `db:"currency"`
+	Rate      float64        `db:"rate"`
+}
+
+type FXTable map[money.Currency]float64
+
+func NewFXTable(rates []FXRate) FXTable {
+	t := FXTable{money.DefaultCurrency: 1}
+	for _, r := range rates {
+		t[r.Currency] = r.Rate
+	}
+	return t
+}
+
+// Rate returns how many units of to one unit of from buys.
+func (t FXTable) Rate(from, to money.Currency) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	fromRate, ok := t[from]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := t[to]
+	if !ok {
+		return 0, false
+	}
+	return fromRate / toRate, true
+}
+
+// ConvertedPrices are an auction's prices in a viewer's currency at the
+// current rate. They are for display only.
+type ConvertedPrices struct {
+	Currency   money.Currency
+	Rate       float64
+	StartPrice money.Money
+	CurrentBid money.Money
+	MinStep    money.Money
+	NextBid    money.Money
+}
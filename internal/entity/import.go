@@ -0,0 +1,35 @@
+package entity
+
+type ImportRowStatus string
+
+const (
+	ImportRowValid   ImportRowStatus = "VALID"
+	ImportRowCreated ImportRowStatus = "CREATED"
+	ImportRowInvalid ImportRowStatus = "INVALID"
+	ImportRowFailed  ImportRowStatus = "FAILED"
+)
+
+// ImportRowResult reports one row of a bulk import by its line in the source
+// file. Rows of a dry run stop at VALID.
+type ImportRowResult struct {
+	Line      int
+	AuctionID string
+	Status    ImportRowStatus
+	Error     string
+}
+
+type ImportReport struct {
+	DryRun bool
+	Rows   []ImportRowResult
+}
+
+// Count returns how many rows ended with status.
+func (r ImportReport) Count(status ImportRowStatus) int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,64 @@
+package entity
+
+import "auction-platform/pkg/money"
+
+// IncrementTier applies Step while the current bid is below Below; the last
+// tier leaves Below at zero to cover everything above the previous one.
+type IncrementTier struct {
+	Below money.Money `json:"below"`
+	Step  money.Money `json:"step"`
+}
+
+type IncrementTable []IncrementTier
+
+// Step returns the increment for a current bid of amount.
+func (t IncrementTable) Step(amount money.Money) money.Money {
+	for _, tier := range t {
+		if tier.Below.IsZero() || amount.Cmp(tier.Below) < 0 {
+			return tier.Step
+		}
+	}
+	return t[len(t)-1].Step
+}
+
+// IsValid requires ascending bounds, positive steps that fit the currency and
+// an open last tier.
+func (t IncrementTable) IsValid() bool {
+	if len(t) == 0 {
+		return false
+	}
+	for i, tier := range t {
+		if !tier.Step.IsPositive() || tier.Step.Validate() != nil || tier.Below.Validate() != nil {
+			return false
+		}
+		last := i == len(t)-1
+		if last != tier.Below.IsZero() {
+			return false
+		}
+		if !last && (!tier.Below.IsPositive() || (i > 0 && tier.Below.Cmp(t[i-1].Below) <= 0)) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithCurrency returns a copy of the table with every amount in c.
+func (t IncrementTable) WithCurrency(c money.Currency) IncrementTable {
+	if t == nil {
+		return nil
+	}
+	out := make(IncrementTable, len(t))
+	for i, tier := range t {
+		tier.Below.Currency, tier.Step.Currency = c, c
+		out[i] = tier
+	}
+	return out
+}
+
+type IncrementPolicy struct {
+	Categories map[string]IncrementTable
+}
+
+func (p IncrementPolicy) TableFor(category string, c money.Currency) IncrementTable {
+	return p.Categories[category].WithCurrency(c)
+}
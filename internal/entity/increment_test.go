@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"testing"
+
+	"auction-platform/pkg/money"
+)
+
+func TestIncrementTableStep(t *testing.T) {
+	table := IncrementTable{
+		{Below: usd(10000), Step: usd(100)},
+		{Below: usd(100000), Step: usd(500)},
+		{Step: usd(2500)},
+	}.WithCurrency("USD")
+
+	tests := []struct {
+		name   string
+		amount int64
+		want   int64
+	}{
+		{name: "zero bid", amount: 0, want: 100},
+		{name: "inside first tier", amount: 9999, want: 100},
+		{name: "first bound starts second tier", amount: 10000, want: 500},
+		{name: "inside second tier", amount: 99999, want: 500},
+		{name: "second bound starts open tier", amount: 100000, want: 2500},
+		{name: "far above", amount: 10000000, want: 2500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Step(usd(tt.amount)); got != usd(tt.want) {
+				t.Errorf("Step(%s) = %s, want %s", usd(tt.amount), got, usd(tt.want))
+			}
+		})
+	}
+}
+
+func TestIncrementTableIsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    IncrementTable
+		currency money.Currency
+		want     bool
+	}{
+		{
+			name:  "single open tier",
+			table: IncrementTable{{Step: usd(100)}},
+			want:  true,
+		},
+		{
+			name:  "ascending bounds",
+			table: IncrementTable{{Below: usd(1000), Step: usd(50)}, {Below: usd(5000), Step: usd(100)}, {Step: usd(500)}},
+			want:  true,
+		},
+		{
+			name: "empty",
+			want: false,
+		},
+		{
+			name:  "last tier not open",
+			table: IncrementTable{{Below: usd(1000), Step: usd(50)}, {Below: usd(5000), Step: usd(100)}},
+			want:  false,
+		},
+		{
+			name:  "open tier before the last",
+			table: IncrementTable{{Step: usd(50)}, {Step: usd(100)}},
+			want:  false,
+		},
+		{
+			name:  "equal bounds",
+			table: IncrementTable{{Below: usd(1000), Step: usd(50)}, {Below: usd(1000), Step: usd(100)}, {Step: usd(500)}},
+			want:  false,
+		},
+		{
+			name:  "descending bounds",
+			table: IncrementTable{{Below: usd(5000), Step: usd(50)}, {Below: usd(1000), Step: usd(100)}, {Step: usd(500)}},
+			want:  false,
+		},
+		{
+			name:  "negative bound",
+			table: IncrementTable{{Below: usd(-100), Step: usd(50)}, {Step: usd(100)}},
+			want:  false,
+		},
+		{
+			name:  "zero step",
+			table: IncrementTable{{Below: usd(1000), Step: usd(0)}, {Step: usd(100)}},
+			want:  false,
+		},
+		{
+			name:  "negative open step",
+			table: IncrementTable{{Step: usd(-100)}},
+			want:  false,
+		},
+		{
+			name:     "step too precise for yen",
+			table:    IncrementTable{{Step: usd(150)}},
+			currency: "JPY",
+			want:     false,
+		},
+		{
+			name:     "whole yen steps",
+			table:    IncrementTable{{Below: usd(100000), Step: usd(1000)}, {Step: usd(5000)}},
+			currency: "JPY",
+			want:     true,
+		},
+		{
+			name:     "unsupported currency",
+			table:    IncrementTable{{Step: usd(100)}},
+			currency: "XXX",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency := tt.currency
+			if currency == "" {
+				currency = "USD"
+			}
+			if got := tt.table.WithCurrency(currency).IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package entity
+
+import "auction-platform/pkg/money"
+
+type LotPricing string
+
+const (
+	LotPricingPayAsBid LotPricing = "PAY_AS_BID"
+	LotPricingUniform  LotPricing = "UNIFORM"
+)
+
+func (p LotPricing) IsValid() bool {
+	return p == LotPricingPayAsBid || p == LotPricingUniform
+}
+
+// LotAllocation is the share of a lot won by one bid and the price per unit
+// the bidder pays for it.
+type LotAllocation struct {
+	Bid   Bid
+	Units int
+	Price money.Money
+}
+
+func (a LotAllocation) Amount() money.Money {
+	return a.Price.Mul(int64(a.Units))
+}
+
+// Allocate fills the lot from bids ordered best first; the last bid that fits
+// may be filled partially. Under uniform pricing every winner pays the lowest
+// winning bid, otherwise each pays their own. It ranks bids while the auction
+// runs and ignores the reserve; Award applies it at the close.
+func (a Auction) Allocate(bids []Bid) []LotAllocation {
+	var allocs []LotAllocation
+	left := a.Quantity
+	for _, b := range bids {
+		if left == 0 {
+			break
+		}
+		units := min(b.Quantity, left)
+		allocs = append(allocs, LotAllocation{Bid: b, Units: units, Price: b.Amount})
+		left -= units
+	}
+
+	if a.Pricing == LotPricingUniform && len(allocs) > 0 {
+		clearing := allocs[len(allocs)-1].Bid.Amount
+		for i := range allocs {
+			allocs[i].Price = clearing
+		}
+	}
+	return allocs
+}
+
+// Award settles the running allocations when the auction closes: bids short
+// of the reserve win nothing and uniform prices are set again among the bids
+// that remain. An auction left with no allocations ends unsold.
+func (a Auction) Award(allocs []LotAllocation) []LotAllocation {
+	bids := make([]Bid, 0, len(allocs))
+	for _, al := range allocs {
+		if !a.MeetsReserve(al.Bid.Amount) {
+			break
+		}
+		bids = append(bids, al.Bid)
+	}
+	return a.Allocate(bids)
+}
+
+// ClearingPrice is the lowest winning bid once the whole lot is taken and the
+// start price while units are still free.
+func (a Auction) ClearingPrice(allocs []LotAllocation) money.Money {
+	units := 0
+	for _, al := range allocs {
+		units += al.Units
+	}
+	if len(allocs) == 0 || units < a.Quantity {
+		return a.StartPrice
+	}
+	return allocs[len(allocs)-1].Bid.Amount
+}
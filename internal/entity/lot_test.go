@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"testing"
+
+	"auction-platform/pkg/money"
+)
+
+func usd(minor int64) money.Money {
+	return money.New(minor, "USD")
+}
+
+type wantAlloc struct {
+	bidder string
+	units  int
+	price  int64
+}
+
+func TestAllocate(t *testing.T) {
+	bid := func(bidder string, amount int64, qty int) Bid {
+		return Bid{BidderID: bidder, Amount: usd(amount), Quantity: qty}
+	}
+
+	tests := []struct {
+		name         string
+		auction      Auction
+		bids         []Bid
+		closed       bool
+		want         []wantAlloc
+		wantClearing int64
+	}{
+		{
+			name:         "no bids",
+			auction:      Auction{Quantity: 3, StartPrice: usd(1000), Pricing: LotPricingPayAsBid},
+			want:         nil,
+			wantClearing: 1000,
+		},
+		{
+			name:    "pay as bid fills in order",
+			auction: Auction{Quantity: 3, StartPrice: usd(1000), Pricing: LotPricingPayAsBid},
+			bids:    []Bid{bid("a", 1500, 1), bid("b", 1400, 2), bid("c", 1300, 1)},
+			want: []wantAlloc{
+				{"a", 1, 1500},
+				{"b", 2, 1400},
+			},
+			wantClearing: 1400,
+		},
+		{
+			name:    "last winner is filled partially",
+			auction: Auction{Quantity: 4, StartPrice: usd(1000), Pricing: LotPricingPayAsBid},
+			bids:    []Bid{bid("a", 1500, 3), bid("b", 1400, 5)},
+			want: []wantAlloc{
+				{"a", 3, 1500},
+				{"b", 1, 1400},
+			},
+			wantClearing: 1400,
+		},
+		{
+			name:    "uniform pricing charges the lowest winning bid",
+			auction: Auction{Quantity: 4, StartPrice: usd(1000), Pricing: LotPricingUniform},
+			bids:    []Bid{bid("a", 1500, 3), bid("b", 1400, 5), bid("c", 1300, 1)},
+			want: []wantAlloc{
+				{"a", 3, 1400},
+				{"b", 1, 1400},
+			},
+			wantClearing: 1400,
+		},
+		{
+			name:    "undersubscribed lot clears at the start price",
+			auction: Auction{Quantity: 5, StartPrice: usd(1000), Pricing: LotPricingUniform},
+			bids:    []Bid{bid("a", 1500, 1), bid("b", 1200, 2)},
+			want: []wantAlloc{
+				{"a", 1, 1200},
+				{"b", 2, 1200},
+			},
+			wantClearing: 1000,
+		},
+		{
+			name: "bids below the reserve win nothing",
+			auction: Auction{
+				Quantity: 3, StartPrice: usd(1000), ReservePrice: usd(1400), Pricing: LotPricingUniform,
+			},
+			bids:   []Bid{bid("a", 1500, 1), bid("b", 1400, 1), bid("c", 1399, 1)},
+			closed: true,
+			want: []wantAlloc{
+				{"a", 1, 1400},
+				{"b", 1, 1400},
+			},
+			wantClearing: 1000,
+		},
+		{
+			name: "nothing meets the reserve",
+			auction: Auction{
+				Quantity: 1, StartPrice: usd(1000), ReservePrice: usd(2000), Pricing: LotPricingPayAsBid,
+			},
+			bids:         []Bid{bid("a", 1500, 1)},
+			closed:       true,
+			want:         nil,
+			wantClearing: 1000,
+		},
+		{
+			name: "running auction ranks bids below the reserve",
+			auction: Auction{
+				Quantity: 2, StartPrice: usd(1000), ReservePrice: usd(2000), Pricing: LotPricingUniform,
+			},
+			bids: []Bid{bid("a", 1500, 1), bid("b", 1400, 2)},
+			want: []wantAlloc{
+				{"a", 1, 1400},
+				{"b", 1, 1400},
+			},
+			wantClearing: 1400,
+		},
+		{
+			name: "same bids win nothing at the close",
+			auction: Auction{
+				Quantity: 2, StartPrice: usd(1000), ReservePrice: usd(2000), Pricing: LotPricingUniform,
+			},
+			bids:         []Bid{bid("a", 1500, 1), bid("b", 1400, 2)},
+			closed:       true,
+			want:         nil,
+			wantClearing: 1000,
+		},
+		{
+			name: "reverse auction fills lowest first",
+			auction: Auction{
+				Type: AuctionTypeReverse, Quantity: 2, StartPrice: usd(5000), Pricing: LotPricingUniform,
+			},
+			bids: []Bid{bid("a", 3000, 1), bid("b", 3500, 1), bid("c", 4000, 1)},
+			want: []wantAlloc{
+				{"a", 1, 3500},
+				{"b", 1, 3500},
+			},
+			wantClearing: 3500,
+		},
+		{
+			name: "reverse reserve caps the winning bid",
+			auction: Auction{
+				Type: AuctionTypeReverse, Quantity: 3, StartPrice: usd(5000), ReservePrice: usd(3500),
+				Pricing: LotPricingPayAsBid,
+			},
+			bids:   []Bid{bid("a", 3000, 1), bid("b", 3500, 1), bid("c", 3600, 1)},
+			closed: true,
+			want: []wantAlloc{
+				{"a", 1, 3000},
+				{"b", 1, 3500},
+			},
+			wantClearing: 5000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.auction.Allocate(tt.bids)
+			if tt.closed {
+				got = tt.auction.Award(got)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Allocate returned %d allocations, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Bid.BidderID != w.bidder || g.Units != w.units || g.Price != usd(w.price) {
+					t.Errorf("allocation %d = %s x%d @ %s, want %s x%d @ %s",
+						i, g.Bid.BidderID, g.Units, g.Price, w.bidder, w.units, usd(w.price))
+				}
+				if g.Amount() != usd(w.price*int64(w.units)) {
+					t.Errorf("allocation %d amount = %s", i, g.Amount())
+				}
+			}
+			if clearing := tt.auction.ClearingPrice(got); clearing != usd(tt.wantClearing) {
+				t.Errorf("ClearingPrice = %s, want %s", clearing, usd(tt.wantClearing))
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+type MediaKind string
+
+const (
+	MediaKindImage    MediaKind = "IMAGE"
+	MediaKindDocument MediaKind = "DOCUMENT"
+)
+
+// mediaKinds lists the accepted content types, as sniffed from the upload.
+var mediaKinds = map[string]MediaKind{
+	"image/jpeg":      MediaKindImage,
+	"image/png":       MediaKindImage,
+	"image/gif":       MediaKindImage,
+	"image/webp":      MediaKindImage,
+	"application/pdf": MediaKindDocument,
+}
+
+// MediaKindOf reports the kind of a content type, or false when uploads of
+// that type are not accepted.
+func MediaKindOf(contentType string) (MediaKind, bool) {
+	kind, ok := mediaKinds[contentType]
+	return kind, ok
+}
+
+// Media is an image or attachment of an auction, listed by Position. URL and
+// ThumbnailURL are filled in by the service from the storage keys;
+// ThumbnailKey is empty when no thumbnail could be made.
+type Media struct {
+	CreatedAt    time.Time `db:"created_at"`
+	MediaID      int64     `db:"media_id"`
+	AuctionID    string    `db:"auction_id"`
+	Position     int       `db:"position"`
+	Kind         MediaKind `db:"kind"`
+	ContentType  string    `db:"content_type"`
+	Size         int64     `db:"size_bytes"`
+	FileName     string    `db:"file_name"`
+	Key          string    `db:"storage_key"`
+	ThumbnailKey string    `db:"thumbnail_key"`
+	URL          string
+	ThumbnailURL string
+}
+
+// Keys returns every storage key the media occupies.
+func (m Media) Keys() []string {
+	if m.ThumbnailKey == "" {
+		return []string{m.Key}
+	}
+	return []string{m.Key, m.ThumbnailKey}
+}
+
+type MediaPolicy struct {
+	MaxSize       int64
+	MaxPerAuction int
+	ThumbnailSize int
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationTypeOutbid     NotificationType = "OUTBID"
+	NotificationTypeEndingSoon NotificationType = "ENDING_SOON"
+	NotificationTypeWon        NotificationType = "WON"
+	NotificationTypeLost       NotificationType = "LOST"
+
+	NotificationTypeQuestionAnswered NotificationType = "QUESTION_ANSWERED"
+)
+
+type Notification struct {
+	CreatedAt      time.Time        `db:"created_at"`
+	ReadAt         *time.Time       `db:"read_at"`
+	NotificationID int64            `db:"notification_id"`
+	UserID         string           `db:"user_id"`
+	AuctionID      string           `db:"auction_id"`
+	Type           NotificationType `db:"type"`
+	Message        string           `db:"message"`
+	IsRead         bool             `db:"is_read"`
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+type OrderKind string
+
+const (
+	OrderKindWin          OrderKind = "WIN"
+	OrderKindSecondChance OrderKind = "SECOND_CHANCE"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusOffered   OrderStatus = "OFFERED"
+	OrderStatusPending   OrderStatus = "PENDING"
+	OrderStatusPaid      OrderStatus = "PAID"
+	OrderStatusUnpaid    OrderStatus = "UNPAID"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+)
+
+type OrderEventType string
+
+const (
+	OrderEventCreated       OrderEventType = "order.created"
+	OrderEventPaid          OrderEventType = "order.paid"
+	OrderEventUnpaid        OrderEventType = "order.unpaid"
+	OrderEventOfferCreated  OrderEventType = "order.offer_created"
+	OrderEventOfferAccepted OrderEventType = "order.offer_accepted"
+	OrderEventOfferDeclined OrderEventType = "order.offer_declined"
+	OrderEventOfferExpired  OrderEventType = "order.offer_expired"
+)
+
+type Order struct {
+	CreatedAt      time.Time   `db:"created_at"`
+	UpdatedAt      time.Time   `db:"updated_at"`
+	PaymentDueAt   *time.Time  `db:"payment_due_at"`
+	OfferExpiresAt *time.Time  `db:"offer_expires_at"`
+	PaidAt         *time.Time  `db:"paid_at"`
+	OrderID        int64       `db:"order_id"`
+	AuctionID      string      `db:"auction_id"`
+	BuyerID        string      `db:"buyer_id"`
+	SellerID       string      `db:"seller_id"`
+	Kind           OrderKind   `db:"kind"`
+	Status         OrderStatus `db:"status"`
+	Amount         money.Money `db:"amount"`
+	AmountPaid     money.Money `db:"amount_paid"`
+	Quantity       int         `db:"quantity"`
+	FXRate         float64     `db:"fx_rate"`
+}
+
+// SettlementAmount converts m from the order's currency into
+// money.DefaultCurrency at the rate snapshot taken when the order was created.
+func (o Order) SettlementAmount(m money.Money) money.Money {
+	return m.Convert(money.DefaultCurrency, o.FXRate)
+}
+
+func (o Order) AmountDue() money.Money {
+	return o.Amount.Sub(o.AmountPaid)
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// Question is asked by a buyer on an auction. Until the seller answers it
+// only the asker and the seller see it; a private answer stays visible to
+// those two alone.
+type Question struct {
+	CreatedAt  time.Time  `db:"created_at"`
+	AnsweredAt *time.Time `db:"answered_at"`
+	QuestionID int64      `db:"question_id"`
+	AuctionID  string     `db:"auction_id"`
+	AskerID    string     `db:"asker_id"`
+	Question   string     `db:"question"`
+	Answer     string     `db:"answer"`
+	Public     bool       `db:"is_public"`
+}
+
+func (q Question) IsAnswered() bool {
+	return q.AnsweredAt != nil
+}
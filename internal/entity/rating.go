@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+const (
+	MinRatingScore = 1
+	MaxRatingScore = 5
+)
+
+// RatingRole is the part the rated user played in the trade.
+type RatingRole string
+
+const (
+	RatingRoleSeller RatingRole = "SELLER"
+	RatingRoleBuyer  RatingRole = "BUYER"
+)
+
+// TradeParties are the buyer and seller of one order. In a reverse auction
+// the auction's owner is the buyer and the winning supplier the seller.
+type TradeParties struct {
+	BuyerID  string `db:"buyer_id"`
+	SellerID string `db:"seller_id"`
+}
+
+// Counterparty returns the other side of the trade for userID, and the role
+// that side played.
+func (t TradeParties) Counterparty(userID string) (string, RatingRole, bool) {
+	switch userID {
+	case t.BuyerID:
+		return t.SellerID, RatingRoleSeller, true
+	case t.SellerID:
+		return t.BuyerID, RatingRoleBuyer, true
+	}
+	return "", "", false
+}
+
+type Rating struct {
+	CreatedAt time.Time  `db:"created_at"`
+	AuctionID string     `db:"auction_id"`
+	RaterID   string     `db:"rater_id"`
+	RateeID   string     `db:"ratee_id"`
+	Role      RatingRole `db:"role"`
+	Score     int        `db:"score"`
+	Comment   string     `db:"comment"`
+}
+
+type RatingSummary struct {
+	Count   int
+	Average float64
+}
+
+type Reputation struct {
+	AsSeller RatingSummary
+	AsBuyer  RatingSummary
+}
+
+// Overall averages every rating the user received in either role.
+func (r Reputation) Overall() RatingSummary {
+	count := r.AsSeller.Count + r.AsBuyer.Count
+	if count == 0 {
+		return RatingSummary{}
+	}
+	sum := r.AsSeller.Average*float64(r.AsSeller.Count) + r.AsBuyer.Average*float64(r.AsBuyer.Count)
+	return RatingSummary{Count: count, Average: sum / float64(count)}
+}
+
+type UserProfile struct {
+	UserID     string
+	Reputation Reputation
+	Ratings    []Rating
+}
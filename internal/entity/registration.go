@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+// EligibilityRules are the conditions a bidder must meet before their bids on
+// an auction are considered. Zero values switch a rule off; bidders must never
+// be banned regardless. Users live with the identity provider, so
+// MinAccountAgeDays counts from the bidder's first wallet account instead of
+// a sign-up date.
+type EligibilityRules struct {
+	RequireRegistration bool        `json:"require_registration,omitempty"`
+	Deposit             money.Money `json:"deposit"`
+	MinAccountAgeDays   int         `json:"min_account_age_days,omitempty"`
+	MaxWinningAuctions  int         `json:"max_winning_auctions,omitempty"`
+}
+
+// IsValid requires a deposit that fits the currency and no negative limits. A
+// deposit is only taken at registration, so it needs RequireRegistration.
+func (r EligibilityRules) IsValid() bool {
+	if r.Deposit.Validate() != nil || r.Deposit.IsNegative() {
+		return false
+	}
+	if r.Deposit.IsPositive() && !r.RequireRegistration {
+		return false
+	}
+	return r.MinAccountAgeDays >= 0 && r.MaxWinningAuctions >= 0
+}
+
+func (r EligibilityRules) MinAccountAge() time.Duration {
+	return time.Duration(r.MinAccountAgeDays) * 24 * time.Hour
+}
+
+type RegistrationStatus string
+
+const (
+	RegistrationPending  RegistrationStatus = "PENDING"
+	RegistrationApproved RegistrationStatus = "APPROVED"
+	RegistrationDenied   RegistrationStatus = "DENIED"
+)
+
+func (s RegistrationStatus) IsValid() bool {
+	return s == RegistrationPending || s == RegistrationApproved || s == RegistrationDenied
+}
+
+// Registration is a bidder's request to take part in an auction that requires
+// approval. Deposit is held in money.DefaultCurrency until the auction settles
+// or the request is denied.
+type Registration struct {
+	CreatedAt  time.Time          `db:"created_at"`
+	UpdatedAt  time.Time          `db:"updated_at"`
+	AuctionID  string             `db:"auction_id"`
+	BidderID   string             `db:"bidder_id"`
+	Status     RegistrationStatus `db:"status"`
+	Deposit    money.Money        `db:"deposit"`
+	ReviewedBy string             `db:"reviewed_by"`
+	Reason     string             `db:"reason"`
+}
+
+// RegistrationReference is the ledger reference registration deposits are
+// held against, kept apart from the auction's bid holds.
+func RegistrationReference(auctionID string) string {
+	return "registration:" + auctionID
+}
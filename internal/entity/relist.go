@@ -0,0 +1,54 @@
+package entity
+
+import "auction-platform/pkg/money"
+
+const (
+	maxRelistings   = 52
+	maxRelistCutPct = 90
+)
+
+// RelistPolicy puts an auction that ends unsold, with no bids or none that
+// met the reserve, up again up to MaxRelists times. A forward auction's
+// start price drops by PriceCutPct percent on every relisting.
+type RelistPolicy struct {
+	MaxRelists  int `json:"max_relists,omitempty"`
+	PriceCutPct int `json:"price_cut_pct,omitempty"`
+}
+
+func (p RelistPolicy) IsValid() bool {
+	return p.MaxRelists >= 0 && p.MaxRelists <= maxRelistings &&
+		p.PriceCutPct >= 0 && p.PriceCutPct <= maxRelistCutPct
+}
+
+// MeetsReserve reports whether a bid of amount may win: at or above the
+// reserve in a forward auction and at or below it in a reverse one. Without
+// a reserve every bid does.
+func (a Auction) MeetsReserve(amount money.Money) bool {
+	if a.ReservePrice.IsZero() {
+		return true
+	}
+	if a.IsReverse() {
+		return amount.Cmp(a.ReservePrice) <= 0
+	}
+	return amount.Cmp(a.ReservePrice) >= 0
+}
+
+// CanRelist reports whether the relist policy has relistings left.
+// Auctions from before relisting existed have no duration to reuse.
+func (a Auction) CanRelist() bool {
+	return a.DurationMin > 0 && a.RelistCount < a.Relist.MaxRelists
+}
+
+// RelistStartPrice is the start price of the next relisting. The cut is
+// taken from the current start price, so it compounds; reverse auctions keep
+// their ceiling.
+func (a Auction) RelistStartPrice() money.Money {
+	if a.IsReverse() || a.Relist.PriceCutPct == 0 {
+		return a.StartPrice
+	}
+	price := a.StartPrice.Sub(a.StartPrice.Percent(float64(a.Relist.PriceCutPct)))
+	if !price.IsPositive() {
+		return a.StartPrice
+	}
+	return price
+}
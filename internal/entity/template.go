@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+// AuctionTemplate is a seller's saved set of auction settings that new
+// auctions can be started from. Names are unique per seller.
+type AuctionTemplate struct {
+	CreatedAt  time.Time        `db:"created_at"`
+	UpdatedAt  time.Time        `db:"updated_at"`
+	TemplateID int64            `db:"template_id"`
+	SellerID   string           `db:"seller_id"`
+	Name       string           `db:"name"`
+	Settings   TemplateSettings `db:"settings"`
+}
+
+// TemplateSettings holds everything of a new auction but its ID and seller.
+type TemplateSettings struct {
+	Title        string            `json:"title"`
+	Description  string            `json:"description,omitempty"`
+	Category     string            `json:"category,omitempty"`
+	StartPrice   money.Money       `json:"start_price"`
+	MinStep      money.Money       `json:"min_step"`
+	ReservePrice money.Money       `json:"reserve_price"`
+	Currency     money.Currency    `json:"currency"`
+	Increments   IncrementTable    `json:"increments,omitempty"`
+	Quantity     int               `json:"quantity"`
+	Pricing      LotPricing        `json:"pricing"`
+	Type         AuctionType       `json:"type"`
+	Visibility   AuctionVisibility `json:"visibility"`
+	Eligibility  EligibilityRules  `json:"eligibility"`
+	Invited      []string          `json:"invited,omitempty"`
+	DurationMin  int               `json:"duration_min"`
+	Relist       RelistPolicy      `json:"relist"`
+}
+
+// SetCurrency tags every amount of the settings with c; amounts come back
+// from JSON without one.
+func (s *TemplateSettings) SetCurrency(c money.Currency) {
+	s.Currency = c
+	s.StartPrice.Currency = c
+	s.MinStep.Currency = c
+	s.ReservePrice.Currency = c
+	s.Increments = s.Increments.WithCurrency(c)
+	s.Eligibility.Deposit.Currency = c
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+type Role string
+
+const (
+	RoleBuyer  Role = "buyer"
+	RoleSeller Role = "seller"
+	RoleAdmin  Role = "admin"
+)
+
+// Ban bars a user from the whole platform, until ExpiresAt when it is set.
+type Ban struct {
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt *time.Time `db:"expires_at"`
+	UserID    string     `db:"user_id"`
+	Reason    string     `db:"reason"`
+	BannedBy  string     `db:"banned_by"`
+}
+
+// BlockedBidder bars a bidder from one seller's auctions.
+type BlockedBidder struct {
+	CreatedAt time.Time `db:"created_at"`
+	SellerID  string    `db:"seller_id"`
+	BidderID  string    `db:"bidder_id"`
+	Reason    string    `db:"reason"`
+}
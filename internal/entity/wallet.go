@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
+
+// ExternalOwnerID owns the counterparty account for money entering and
+// leaving the platform, so every transfer has two sides.
+const ExternalOwnerID = "@external"
+
+type AccountType string
+
+const (
+	AccountAvailable AccountType = "AVAILABLE"
+	AccountHeld      AccountType = "HELD"
+	AccountExternal  AccountType = "EXTERNAL"
+)
+
+type LedgerEntryKind string
+
+const (
+	LedgerDeposit    LedgerEntryKind = "DEPOSIT"
+	LedgerWithdrawal LedgerEntryKind = "WITHDRAWAL"
+	LedgerHold       LedgerEntryKind = "HOLD"
+	LedgerRelease    LedgerEntryKind = "RELEASE"
+	LedgerCharge     LedgerEntryKind = "CHARGE"
+)
+
+type Account struct {
+	CreatedAt time.Time   `db:"created_at"`
+	AccountID int64       `db:"account_id"`
+	OwnerID   string      `db:"owner_id"`
+	Type      AccountType `db:"type"`
+	Balance   money.Money `db:"balance"`
+}
+
+type LedgerEntry struct {
+	CreatedAt   time.Time       `db:"created_at"`
+	EntryID     int64           `db:"entry_id"`
+	TransferID  int64           `db:"transfer_id"`
+	AccountID   int64           `db:"account_id"`
+	AccountType AccountType     `db:"type"`
+	Amount      money.Money     `db:"amount"`
+	Kind        LedgerEntryKind `db:"kind"`
+	Reference   string          `db:"reference"`
+}
+
+type Wallet struct {
+	OwnerID   string
+	Available money.Money
+	Held      money.Money
+}
+
+type Hold struct {
+	OwnerID string
+	Amount  money.Money
+}
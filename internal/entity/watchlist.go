@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type WatchlistItem struct {
+	CreatedAt time.Time `db:"created_at"`
+	UserID    string    `db:"user_id"`
+	AuctionID string    `db:"auction_id"`
+}
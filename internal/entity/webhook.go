@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+type WebhookEventType string
+
+const (
+	WebhookEventBidResult    WebhookEventType = "bid.result"
+	WebhookEventAuctionEnded WebhookEventType = "auction.ended"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySuccess WebhookDeliveryStatus = "SUCCESS"
+	WebhookDeliveryFailed  WebhookDeliveryStatus = "FAILED"
+)
+
+type Webhook struct {
+	CreatedAt  time.Time `db:"created_at"`
+	WebhookID  int64     `db:"webhook_id"`
+	OwnerID    string    `db:"owner_id"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	EventTypes []string  `db:"event_types"`
+	IsActive   bool      `db:"is_active"`
+}
+
+type WebhookDelivery struct {
+	CreatedAt    time.Time             `db:"created_at"`
+	DeliveredAt  *time.Time            `db:"delivered_at"`
+	DeliveryID   int64                 `db:"delivery_id"`
+	WebhookID    int64                 `db:"webhook_id"`
+	EventType    WebhookEventType      `db:"event_type"`
+	Payload      []byte                `db:"payload"`
+	Status       WebhookDeliveryStatus `db:"status"`
+	Attempts     int                   `db:"attempts"`
+	ResponseCode int                   `db:"response_code"`
+	Error        string                `db:"error"`
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrNoKeys       = errors.New("neither HS256 sign key nor JWKS file is configured")
+)
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+type JWTValidator struct {
+	parser  *jwt.Parser
+	hmacKey []byte
+	rsaKeys map[string]*rsa.PublicKey
+}
+
+func NewJWTValidator(signKey, jwksPath, issuer, audience string) (*JWTValidator, error) {
+	v := &JWTValidator{}
+
+	var methods []string
+	if signKey != "" {
+		v.hmacKey = []byte(signKey)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if jwksPath != "" {
+		keys, err := LoadJWKS(jwksPath)
+		if err != nil {
+			return nil, err
+		}
+		v.rsaKeys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods)}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+
+	v.parser = jwt.NewParser(opts...)
+	return v, nil
+}
+
+func (v *JWTValidator) Parse(tokenString string) (Claims, error) {
+	var claims Claims
+	_, err := v.parser.ParseWithClaims(tokenString, &claims, v.keyFunc)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+func (v *JWTValidator) keyFunc(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacKey, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		key, ok := v.rsaKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
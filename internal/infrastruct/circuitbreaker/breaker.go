@@ -3,13 +3,26 @@ package circuitbreaker
 import (
 	"auction-platform/internal/metrics"
 	"fmt"
+	"sort"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/sony/gobreaker"
 )
 
+type State struct {
+	Name                 string
+	State                string
+	Requests             uint32
+	TotalSuccesses       uint32
+	TotalFailures        uint32
+	ConsecutiveSuccesses uint32
+	ConsecutiveFailures  uint32
+}
+
 type CircuitBreaker struct {
 	breakers map[string]*gobreaker.CircuitBreaker
+	mu       sync.RWMutex
 	metrics  *metrics.Metrics
 }
 
@@ -21,6 +34,28 @@ func New(m *metrics.Metrics) *CircuitBreaker {
 }
 
 func (cb *CircuitBreaker) Register(name string, settings gobreaker.Settings) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.register(name, settings)
+}
+
+func (cb *CircuitBreaker) RegisterIfAbsent(name string, settings gobreaker.Settings) {
+	cb.mu.RLock()
+	_, exists := cb.breakers[name]
+	cb.mu.RUnlock()
+	if exists {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if _, exists = cb.breakers[name]; exists {
+		return
+	}
+	cb.register(name, settings)
+}
+
+func (cb *CircuitBreaker) register(name string, settings gobreaker.Settings) {
 	settings.Name = name
 	settings.OnStateChange = func(name string, from gobreaker.State, to gobreaker.State) {
 		log.Warnf("Circuit breaker [%s]: %s -> %s", name, from.String(), to.String())
@@ -41,9 +76,32 @@ func (cb *CircuitBreaker) Register(name string, settings gobreaker.Settings) {
 }
 
 func (cb *CircuitBreaker) Execute(name string, fn func() (any, error)) (any, error) {
+	cb.mu.RLock()
 	breaker, ok := cb.breakers[name]
+	cb.mu.RUnlock()
 	if !ok {
 		return nil, fmt.Errorf("circuit breaker %q not registered", name)
 	}
 	return breaker.Execute(fn)
 }
+
+func (cb *CircuitBreaker) States() []State {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	states := make([]State, 0, len(cb.breakers))
+	for name, breaker := range cb.breakers {
+		counts := breaker.Counts()
+		states = append(states, State{
+			Name:                 name,
+			State:                breaker.State().String(),
+			Requests:             counts.Requests,
+			TotalSuccesses:       counts.TotalSuccesses,
+			TotalFailures:        counts.TotalFailures,
+			ConsecutiveSuccesses: counts.ConsecutiveSuccesses,
+			ConsecutiveFailures:  counts.ConsecutiveFailures,
+		})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
@@ -0,0 +1,25 @@
+package delivery
+
+import (
+	"context"
+
+	e "auction-platform/internal/entity"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type LogDeliverer struct{}
+
+func NewLogDeliverer() *LogDeliverer {
+	return &LogDeliverer{}
+}
+
+func (d *LogDeliverer) Deliver(_ context.Context, n e.Notification) error {
+	log.WithFields(log.Fields{
+		"notification_id": n.NotificationID,
+		"user_id":         n.UserID,
+		"auction_id":      n.AuctionID,
+		"type":            n.Type,
+	}).Info(n.Message)
+	return nil
+}
@@ -0,0 +1,73 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrConflict = errors.New("idempotency key changed state concurrently")
+
+type Record struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+type Store struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+func NewStore(rdb *redis.Client, prefix string) *Store {
+	return &Store{rdb: rdb, prefix: prefix}
+}
+
+// Reserve claims key for a new request. When the key is already taken it
+// returns the stored record and false instead.
+func (s *Store) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (Record, bool, error) {
+	pending, err := json.Marshal(Record{Fingerprint: fingerprint})
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	ok, err := s.rdb.SetNX(ctx, s.prefix+":"+key, pending, lockTTL).Result()
+	if err != nil {
+		return Record{}, false, err
+	}
+	if ok {
+		return Record{}, true, nil
+	}
+
+	raw, err := s.rdb.Get(ctx, s.prefix+":"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Record{}, false, ErrConflict
+	}
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	var rec Record
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return Record{}, false, err
+	}
+	return rec, false, nil
+}
+
+func (s *Store) Complete(ctx context.Context, key string, rec Record, ttl time.Duration) error {
+	rec.Completed = true
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, s.prefix+":"+key, raw, ttl).Err()
+}
+
+func (s *Store) Release(ctx context.Context, key string) error {
+	return s.rdb.Del(ctx, s.prefix+":"+key).Err()
+}
@@ -1,27 +1,70 @@
 package kafkadto
 
-import "time"
+import (
+	"time"
+
+	"auction-platform/pkg/money"
+)
 
 type BidPlacedEvent struct {
-	BidID     string    `json:"bid_id"`
-	AuctionID string    `json:"auction_id"`
-	BidderID  string    `json:"bidder_id"`
-	Amount    float64   `json:"amount"`
-	Timestamp time.Time `json:"timestamp"`
+	BidID     string      `json:"bid_id"`
+	AuctionID string      `json:"auction_id"`
+	BidderID  string      `json:"bidder_id"`
+	Amount    money.Money `json:"amount"`
+	Currency  string      `json:"currency"`
+	Quantity  int         `json:"quantity"`
+	ClientIP  string      `json:"client_ip,omitempty"`
+	DeviceID  string      `json:"device_id,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
 }
 
 type BidResultEvent struct {
-	BidID     string  `json:"bid_id"`
-	AuctionID string  `json:"auction_id"`
-	BidderID  string  `json:"bidder_id"`
-	Amount    float64 `json:"amount"`
-	Status    string  `json:"status"`
-	Reason    string  `json:"reason,omitempty"`
+	BidID     string      `json:"bid_id"`
+	AuctionID string      `json:"auction_id"`
+	BidderID  string      `json:"bidder_id"`
+	Amount    money.Money `json:"amount"`
+	Currency  string      `json:"currency"`
+	Quantity  int         `json:"quantity"`
+	Status    string      `json:"status"`
+	Code      string      `json:"code,omitempty"`
+	Reason    string      `json:"reason,omitempty"`
+	Outbid    []string    `json:"outbid,omitempty"`
 }
 
+// AuctionEndedEvent keeps WinnerID and FinalPrice for the top winner and the
+// clearing price; Winners lists every bidder who won units of the lot.
 type AuctionEndedEvent struct {
-	AuctionID  string  `json:"auction_id"`
-	WinnerID   string  `json:"winner_id,omitempty"`
-	FinalPrice float64 `json:"final_price"`
-	TotalBids  int     `json:"total_bids"`
+	AuctionID  string          `json:"auction_id"`
+	WinnerID   string          `json:"winner_id,omitempty"`
+	FinalPrice money.Money     `json:"final_price"`
+	Currency   string          `json:"currency"`
+	Quantity   int             `json:"quantity"`
+	Pricing    string          `json:"pricing"`
+	Winners    []AuctionWinner `json:"winners"`
+	TotalBids  int             `json:"total_bids"`
+}
+
+type AuctionWinner struct {
+	BidderID string      `json:"bidder_id"`
+	Units    int         `json:"units"`
+	Price    money.Money `json:"price"`
+	Amount   money.Money `json:"amount"`
+}
+
+type OrderEvent struct {
+	Event          string      `json:"event"`
+	OrderID        int64       `json:"order_id"`
+	AuctionID      string      `json:"auction_id"`
+	BuyerID        string      `json:"buyer_id"`
+	SellerID       string      `json:"seller_id"`
+	Kind           string      `json:"kind"`
+	Status         string      `json:"status"`
+	Amount         money.Money `json:"amount"`
+	AmountDue      money.Money `json:"amount_due"`
+	Quantity       int         `json:"quantity"`
+	Currency       string      `json:"currency"`
+	FXRate         float64     `json:"fx_rate"`
+	PaymentDueAt   *time.Time  `json:"payment_due_at,omitempty"`
+	OfferExpiresAt *time.Time  `json:"offer_expires_at,omitempty"`
+	Timestamp      time.Time   `json:"timestamp"`
 }
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const defaultIdleTTL = 10 * time.Minute
+
+type localEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type LocalLimiter struct {
+	entries map[string]*localEntry
+	mu      sync.Mutex
+	idleTTL time.Duration
+}
+
+// NewLocalLimiter falls back to defaultIdleTTL when idleTTL is not positive,
+// since Start sweeps on a ticker of that period.
+func NewLocalLimiter(idleTTL time.Duration) *LocalLimiter {
+	if idleTTL <= 0 {
+		log.Warnf("Invalid rate limiter idle ttl %s, using %s", idleTTL, defaultIdleTTL)
+		idleTTL = defaultIdleTTL
+	}
+
+	return &LocalLimiter{
+		entries: make(map[string]*localEntry),
+		idleTTL: idleTTL,
+	}
+}
+
+func (l *LocalLimiter) Allow(_ context.Context, key string, rps float64, burst int) (Result, error) {
+	now := time.Now()
+	limit := rate.Limit(rps)
+
+	l.mu.Lock()
+	entry, exists := l.entries[key]
+	if !exists {
+		entry = &localEntry{limiter: rate.NewLimiter(limit, burst)}
+		l.entries[key] = entry
+	}
+	entry.lastSeen = now
+	l.mu.Unlock()
+
+	lim := entry.limiter
+	if lim.Limit() != limit {
+		lim.SetLimitAt(now, limit)
+	}
+	if lim.Burst() != burst {
+		lim.SetBurstAt(now, burst)
+	}
+
+	res := Result{Limit: burst}
+	if lim.AllowN(now, 1) {
+		res.Allowed = true
+	} else {
+		r := lim.ReserveN(now, 1)
+		res.RetryAfter = r.DelayFrom(now)
+		r.CancelAt(now)
+	}
+
+	tokens := max(lim.TokensAt(now), 0)
+	res.Remaining = int(tokens)
+	res.Reset = time.Duration((float64(burst) - tokens) / rps * float64(time.Second))
+	return res, nil
+}
+
+func (l *LocalLimiter) Start(ctx context.Context) {
+	ticker := time.NewTicker(l.idleTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n := l.evictIdle(time.Now()); n > 0 {
+				log.Debugf("Evicted %d idle rate limiter entries", n)
+			}
+		}
+	}
+}
+
+func (l *LocalLimiter) evictIdle(now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	evicted := 0
+	for key, entry := range l.entries {
+		if now.Sub(entry.lastSeen) > l.idleTTL {
+			delete(l.entries, key)
+			evicted++
+		}
+	}
+	return evicted
+}
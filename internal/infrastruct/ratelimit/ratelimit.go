@@ -0,0 +1,18 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, rps float64, burst int) (Result, error)
+}
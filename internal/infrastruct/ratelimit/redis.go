@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucket refills at ARGV[1] tokens per second up to ARGV[2] and takes one
+// token per call. Time comes from the Redis server so replicas share a clock.
+// Returns {allowed, remaining, retry_after_ms, reset_ms}.
+var tokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.max(1, math.ceil(burst * 1000 / rate)))
+
+return {allowed, math.floor(tokens), retry_after, math.ceil((burst - tokens) * 1000 / rate)}
+`)
+
+type RedisLimiter struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+func NewRedisLimiter(rdb *redis.Client, prefix string) *RedisLimiter {
+	return &RedisLimiter{rdb: rdb, prefix: prefix}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, rps float64, burst int) (Result, error) {
+	vals, err := tokenBucket.Run(ctx, l.rdb, []string{l.prefix + ":" + key}, rps, burst).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    vals[0] == 1,
+		Limit:      burst,
+		Remaining:  int(vals[1]),
+		RetryAfter: time.Duration(vals[2]) * time.Millisecond,
+		Reset:      time.Duration(vals[3]) * time.Millisecond,
+	}, nil
+}
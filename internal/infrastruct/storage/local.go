@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	errutils "auction-platform/pkg/errors"
+)
+
+// LocalStorage keeps objects as files under root. Keys are slash separated
+// and become paths relative to root; baseURL is where the files are served.
+type LocalStorage struct {
+	root    string
+	baseURL string
+}
+
+func NewLocalStorage(root, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	return &LocalStorage{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
+
+// Put writes through a temporary file so readers never see a partial object.
+func (s *LocalStorage) Put(_ context.Context, key string, data []byte, _ string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errutils.WrapPathErr(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return errutils.WrapPathErr(os.Rename(tmp.Name(), p))
+}
+
+// Delete treats a missing object as already deleted.
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.baseURL + "/" + (&url.URL{Path: key}).EscapedPath()
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+var ErrForbiddenTarget = errors.New("webhook URL must use https and resolve only to public addresses")
+
+// CheckTarget accepts https URLs whose host resolves only to public
+// addresses, so webhooks cannot be pointed at the platform's own network.
+// The sender checks again when dialing, which also covers DNS changes and
+// redirects.
+func CheckTarget(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return ErrForbiddenTarget
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return ErrForbiddenTarget
+	}
+	for _, addr := range addrs {
+		if !isPublic(addr) {
+			return ErrForbiddenTarget
+		}
+	}
+	return nil
+}
+
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() && !addr.IsUnspecified() && !addr.IsLoopback() && !addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast() && !addr.IsMulticast()
+}
+
+// guardDial refuses connections to non-public addresses.
+func guardDial(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !isPublic(addr) {
+		return ErrForbiddenTarget
+	}
+	return nil
+}
@@ -18,6 +18,9 @@ type Metrics struct {
 	ActiveAuctions     prometheus.Gauge
 	BidAmountHistogram prometheus.Histogram
 
+	NotificationsCreated *prometheus.CounterVec
+	WebhookDeliveries    *prometheus.CounterVec
+
 	KafkaMessagesProduced *prometheus.CounterVec
 	KafkaMessagesConsumed *prometheus.CounterVec
 	KafkaProduceErrors    *prometheus.CounterVec
@@ -77,6 +80,13 @@ func New() *Metrics {
 			Buckets: []float64{1, 5, 10, 50, 100, 500, 1000, 5000, 10000},
 		}),
 
+		NotificationsCreated: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "auction_notifications_created_total",
+		}, []string{"type"}),
+		WebhookDeliveries: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "auction_webhook_deliveries_total",
+		}, []string{"event", "status"}),
+
 		KafkaMessagesProduced: promauto.NewCounterVec(prometheus.CounterOpts{
 			Name: "auction_kafka_produced_total",
 		}, []string{"topic"}),
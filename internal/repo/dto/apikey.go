@@ -0,0 +1,18 @@
+package repodto
+
+type CreateAPIKeyInput struct {
+	OwnerID        string
+	Name           string
+	Prefix         string
+	KeyHash        string
+	Scopes         []string
+	RateLimitRPS   float64
+	RateLimitBurst int
+}
+
+type RotateAPIKeyInput struct {
+	KeyID   int64
+	OwnerID string
+	Prefix  string
+	KeyHash string
+}
@@ -1,14 +1,38 @@
 package repodto
 
+import "auction-platform/pkg/money"
+
 import e "auction-platform/internal/entity"
 
 type CreateAuctionInput struct {
 	AuctionID   string
 	Title       string
 	Description string
+	Category    string
 	SellerID    string
-	StartPrice  float64
-	MinStep     float64
+	StartPrice  money.Money
+	MinStep     money.Money
+	Currency    money.Currency
+	Increments  e.IncrementTable
+	Quantity    int
+	Pricing     e.LotPricing
+	Type        e.AuctionType
+	Visibility  e.AuctionVisibility
+	Eligibility e.EligibilityRules
 	Status      e.AuctionStatus
 	EndsAt      string
+	// RelistedFrom and RelistCount are only set when relisting.
+	ReservePrice money.Money
+	DurationMin  int
+	Relist       e.RelistPolicy
+	RelistCount  int
+	RelistedFrom string
+}
+
+// ListActiveAuctionsInput prices are in money.DefaultCurrency.
+type ListActiveAuctionsInput struct {
+	Limit    int
+	Offset   int
+	MinPrice *money.Money
+	MaxPrice *money.Money
 }
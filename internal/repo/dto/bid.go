@@ -1,11 +1,14 @@
 package repodto
 
+import "auction-platform/pkg/money"
+
 import e "auction-platform/internal/entity"
 
 type CreateBidInput struct {
 	BidID     string
 	AuctionID string
 	BidderID  string
-	Amount    float64
+	Amount    money.Money
+	Quantity  int
 	Status    e.BidStatus
 }
@@ -0,0 +1,20 @@
+package repodto
+
+import e "auction-platform/internal/entity"
+
+type CreateFraudFlagInput struct {
+	BidID     string
+	AuctionID string
+	BidderID  string
+	SellerID  string
+	Score     int
+	Signals   []e.FraudSignal
+	Held      bool
+}
+
+type ReviewFraudFlagInput struct {
+	FlagID     int64
+	Status     e.FraudFlagStatus
+	ReviewedBy string
+	Note       string
+}
@@ -0,0 +1,15 @@
+package repodto
+
+import e "auction-platform/internal/entity"
+
+type CreateMediaInput struct {
+	AuctionID    string
+	Kind         e.MediaKind
+	ContentType  string
+	Size         int64
+	FileName     string
+	Key          string
+	ThumbnailKey string
+	// MaxPerAuction caps how many media the auction may hold.
+	MaxPerAuction int
+}
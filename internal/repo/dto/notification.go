@@ -0,0 +1,10 @@
+package repodto
+
+import e "auction-platform/internal/entity"
+
+type CreateNotificationInput struct {
+	UserID    string
+	AuctionID string
+	Type      e.NotificationType
+	Message   string
+}
@@ -0,0 +1,38 @@
+package repodto
+
+import (
+	"time"
+
+	e "auction-platform/internal/entity"
+
+	"auction-platform/pkg/money"
+)
+
+type CreateOrderInput struct {
+	AuctionID      string
+	BuyerID        string
+	SellerID       string
+	Kind           e.OrderKind
+	Status         e.OrderStatus
+	Amount         money.Money
+	AmountPaid     money.Money
+	Quantity       int
+	FXRate         float64
+	PaymentDueAt   *time.Time
+	OfferExpiresAt *time.Time
+}
+
+type TransitionOrderInput struct {
+	OrderID      int64
+	From         e.OrderStatus
+	To           e.OrderStatus
+	PaymentDueAt *time.Time
+	AmountPaid   *money.Money
+}
+
+type ListOrdersInput struct {
+	BuyerID  string
+	SellerID string
+	Limit    int
+	Offset   int
+}
@@ -0,0 +1,13 @@
+package repodto
+
+type CreateQuestionInput struct {
+	AuctionID string
+	AskerID   string
+	Question  string
+}
+
+type AnswerQuestionInput struct {
+	QuestionID int64
+	Answer     string
+	Public     bool
+}
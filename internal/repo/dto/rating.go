@@ -0,0 +1,12 @@
+package repodto
+
+import e "auction-platform/internal/entity"
+
+type CreateRatingInput struct {
+	AuctionID string
+	RaterID   string
+	RateeID   string
+	Role      e.RatingRole
+	Score     int
+	Comment   string
+}
@@ -0,0 +1,20 @@
+package repodto
+
+import (
+	e "auction-platform/internal/entity"
+	"auction-platform/pkg/money"
+)
+
+type CreateRegistrationInput struct {
+	AuctionID string
+	BidderID  string
+	Deposit   money.Money
+}
+
+type ReviewRegistrationInput struct {
+	AuctionID  string
+	BidderID   string
+	Status     e.RegistrationStatus
+	ReviewedBy string
+	Reason     string
+}
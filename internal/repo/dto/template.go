@@ -0,0 +1,9 @@
+package repodto
+
+import e "auction-platform/internal/entity"
+
+type SaveTemplateInput struct {
+	SellerID string
+	Name     string
+	Settings e.TemplateSettings
+}
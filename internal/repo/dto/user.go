@@ -0,0 +1,16 @@
+package repodto
+
+import "time"
+
+type CreateBanInput struct {
+	UserID    string
+	Reason    string
+	BannedBy  string
+	ExpiresAt *time.Time
+}
+
+type BlockBidderInput struct {
+	SellerID string
+	BidderID string
+	Reason   string
+}
@@ -0,0 +1,13 @@
+package repodto
+
+import "auction-platform/pkg/money"
+
+import e "auction-platform/internal/entity"
+
+type TransferInput struct {
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        money.Money
+	Kind          e.LedgerEntryKind
+	Reference     string
+}
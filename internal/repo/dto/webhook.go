@@ -0,0 +1,24 @@
+package repodto
+
+import e "auction-platform/internal/entity"
+
+type CreateWebhookInput struct {
+	OwnerID    string
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+type CreateWebhookDeliveryInput struct {
+	WebhookID int64
+	EventType e.WebhookEventType
+	Payload   []byte
+}
+
+type FinishWebhookDeliveryInput struct {
+	DeliveryID   int64
+	Status       e.WebhookDeliveryStatus
+	Attempts     int
+	ResponseCode int
+	Error        string
+}
@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrLimitReached      = errors.New("limit reached")
 )
@@ -0,0 +1,161 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+var apiKeyColumns = []string{
+	"key_id", "owner_id", "name", "prefix", "scopes", "rate_limit_rps",
+	"rate_limit_burst", "created_at", "rotated_at", "revoked_at",
+}
+
+type APIKeyRepo struct {
+	*postgres.Postgres
+}
+
+func NewAPIKeyRepo(pg *postgres.Postgres) *APIKeyRepo {
+	return &APIKeyRepo{pg}
+}
+
+func scanAPIKey(row pgx.Row) (e.APIKey, error) {
+	var k e.APIKey
+	err := row.Scan(
+		&k.KeyID, &k.OwnerID, &k.Name, &k.Prefix, &k.Scopes, &k.RateLimitRPS,
+		&k.RateLimitBurst, &k.CreatedAt, &k.RotatedAt, &k.RevokedAt,
+	)
+	return k, err
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, in rd.CreateAPIKeyInput) (e.APIKey, error) {
+	sql, args, _ := r.Builder.
+		Insert("api_keys").
+		Columns("owner_id", "name", "prefix", "key_hash", "scopes", "rate_limit_rps", "rate_limit_burst").
+		Values(in.OwnerID, in.Name, in.Prefix, in.KeyHash, in.Scopes, in.RateLimitRPS, in.RateLimitBurst).
+		Suffix("RETURNING " + strings.Join(apiKeyColumns, ", ")).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	k, err := scanAPIKey(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return e.APIKey{}, errutils.WrapPathErr(err)
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepo) GetActiveByHash(ctx context.Context, keyHash string) (e.APIKey, error) {
+	sql, args, _ := r.Builder.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		Where("key_hash = ? AND revoked_at IS NULL", keyHash).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	k, err := scanAPIKey(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.APIKey{}, re.ErrNotFound
+		}
+		return e.APIKey{}, errutils.WrapPathErr(err)
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepo) ListByOwner(ctx context.Context, ownerID string) ([]e.APIKey, error) {
+	sql, args, _ := r.Builder.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		Where("owner_id = ?", ownerID).
+		OrderBy("key_id").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var keys []e.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepo) Rotate(ctx context.Context, in rd.RotateAPIKeyInput) (e.APIKey, error) {
+	sql, args, _ := r.Builder.
+		Update("api_keys").
+		Set("prefix", in.Prefix).
+		Set("key_hash", in.KeyHash).
+		Set("rotated_at", squirrel.Expr("NOW()")).
+		Where("key_id = ? AND owner_id = ? AND revoked_at IS NULL", in.KeyID, in.OwnerID).
+		Suffix("RETURNING " + strings.Join(apiKeyColumns, ", ")).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	k, err := scanAPIKey(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.APIKey{}, re.ErrNotFound
+		}
+		return e.APIKey{}, errutils.WrapPathErr(err)
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, keyID int64, ownerID string) error {
+	sql, args, _ := r.Builder.
+		Update("api_keys").
+		Set("revoked_at", squirrel.Expr("NOW()")).
+		Where("key_id = ? AND owner_id = ? AND revoked_at IS NULL", keyID, ownerID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepo) SetQuota(ctx context.Context, keyID int64, rps float64, burst int) (e.APIKey, error) {
+	sql, args, _ := r.Builder.
+		Update("api_keys").
+		Set("rate_limit_rps", rps).
+		Set("rate_limit_burst", burst).
+		Where("key_id = ?", keyID).
+		Suffix("RETURNING " + strings.Join(apiKeyColumns, ", ")).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	k, err := scanAPIKey(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.APIKey{}, re.ErrNotFound
+		}
+		return e.APIKey{}, errutils.WrapPathErr(err)
+	}
+	return k, nil
+}
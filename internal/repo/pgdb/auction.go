@@ -3,13 +3,16 @@ package pgdb
 import (
 	"context"
 	"errors"
+	"time"
 
 	e "auction-platform/internal/entity"
 	rd "auction-platform/internal/repo/dto"
 	re "auction-platform/internal/repo/errors"
 	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
 	"auction-platform/pkg/postgres"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -24,20 +27,34 @@ func NewAuctionRepo(pg *postgres.Postgres) *AuctionRepo {
 }
 
 func (r *AuctionRepo) Create(ctx context.Context, in rd.CreateAuctionInput) (e.Auction, error) {
+	var increments, relistedFrom any
+	if len(in.Increments) > 0 {
+		increments = in.Increments
+	}
+	if in.RelistedFrom != "" {
+		relistedFrom = in.RelistedFrom
+	}
+
 	sql, args, _ := r.Builder.
 		Insert("auctions").
-		Columns("auction_id", "title", "description", "seller_id", "start_price", "current_bid", "min_step", "status", "ends_at").
-		Values(in.AuctionID, in.Title, in.Description, in.SellerID, in.StartPrice, in.StartPrice, in.MinStep, in.Status, in.EndsAt).
-		Suffix("RETURNING auction_id, title, description, seller_id, start_price, current_bid, min_step, status, ends_at, created_at").
+		Columns("auction_id", "title", "description", "category", "seller_id", "start_price", "current_bid", "min_step", "currency",
+			"increments", "quantity", "pricing", "auction_type", "visibility", "eligibility", "status", "ends_at",
+			"reserve_price", "duration_min", "relist_policy", "relist_count", "relisted_from").
+		Values(in.AuctionID, in.Title, in.Description, in.Category, in.SellerID, in.StartPrice, in.StartPrice, in.MinStep, in.Currency,
+			increments, in.Quantity, in.Pricing, in.Type, in.Visibility, in.Eligibility, in.Status, in.EndsAt,
+			in.ReservePrice, in.DurationMin, in.Relist, in.RelistCount, relistedFrom).
+		Suffix("RETURNING auction_id, title, description, category, seller_id, start_price, current_bid, min_step, currency, " +
+			"increments, quantity, pricing, auction_type, visibility, eligibility, status, ends_at, created_at, " +
+			"reserve_price, duration_min, relist_policy, relist_count, COALESCE(relisted_from, '')").
 		ToSql()
 
 	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
 
 	var a e.Auction
 	err := conn.QueryRow(ctx, sql, args...).Scan(
-		&a.AuctionID, &a.Title, &a.Description, &a.SellerID,
-		&a.StartPrice, &a.CurrentBid, &a.MinStep, &a.Status,
-		&a.EndsAt, &a.CreatedAt,
+		&a.AuctionID, &a.Title, &a.Description, &a.Category, &a.SellerID,
+		&a.StartPrice, &a.CurrentBid, &a.MinStep, &a.Currency, &a.Increments, &a.Quantity, &a.Pricing, &a.Type, &a.Visibility, &a.Eligibility, &a.Status,
+		&a.EndsAt, &a.CreatedAt, &a.ReservePrice, &a.DurationMin, &a.Relist, &a.RelistCount, &a.RelistedFrom,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -46,14 +63,16 @@ func (r *AuctionRepo) Create(ctx context.Context, in rd.CreateAuctionInput) (e.A
 		}
 		return e.Auction{}, errutils.WrapPathErr(err)
 	}
+	a.SetCurrency(a.Currency)
 	return a, nil
 }
 
 func (r *AuctionRepo) GetByID(ctx context.Context, auctionID string) (e.Auction, error) {
 	sql, args, _ := r.Builder.
-		Select("auction_id", "title", "description", "seller_id", "start_price",
-			"current_bid", "min_step", "status", "COALESCE(winner_id, '') AS winner_id",
-			"ends_at", "created_at", "finished_at").
+		Select("auction_id", "title", "description", "category", "seller_id", "start_price",
+			"current_bid", "min_step", "currency", "increments", "quantity", "pricing", "auction_type", "visibility", "eligibility", "status",
+			"COALESCE(winner_id, '') AS winner_id", "ends_at", "created_at", "finished_at",
+			"reserve_price", "duration_min", "relist_policy", "relist_count", "COALESCE(relisted_from, '') AS relisted_from").
 		From("auctions").
 		Where("auction_id = ?", auctionID).
 		ToSql()
@@ -62,9 +81,10 @@ func (r *AuctionRepo) GetByID(ctx context.Context, auctionID string) (e.Auction,
 
 	var a e.Auction
 	err := conn.QueryRow(ctx, sql, args...).Scan(
-		&a.AuctionID, &a.Title, &a.Description, &a.SellerID,
-		&a.StartPrice, &a.CurrentBid, &a.MinStep, &a.Status,
+		&a.AuctionID, &a.Title, &a.Description, &a.Category, &a.SellerID,
+		&a.StartPrice, &a.CurrentBid, &a.MinStep, &a.Currency, &a.Increments, &a.Quantity, &a.Pricing, &a.Type, &a.Visibility, &a.Eligibility, &a.Status,
 		&a.WinnerID, &a.EndsAt, &a.CreatedAt, &a.FinishedAt,
+		&a.ReservePrice, &a.DurationMin, &a.Relist, &a.RelistCount, &a.RelistedFrom,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -72,17 +92,31 @@ func (r *AuctionRepo) GetByID(ctx context.Context, auctionID string) (e.Auction,
 		}
 		return e.Auction{}, errutils.WrapPathErr(err)
 	}
+	a.SetCurrency(a.Currency)
 	return a, nil
 }
 
-func (r *AuctionRepo) ListActive(ctx context.Context, limit, offset int) ([]e.Auction, int64, error) {
+// ListActive filters on the current bid converted into money.DefaultCurrency
+// with the stored FX rates. Auctions in a currency without a rate never match
+// a price filter.
+func (r *AuctionRepo) ListActive(ctx context.Context, in rd.ListActiveAuctionsInput) ([]e.Auction, int64, error) {
 	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
 
+	where := squirrel.And{squirrel.Expr("a.status = ? AND a.ends_at > NOW() AND a.visibility = ?",
+		e.AuctionStatusActive, e.VisibilityPublic)}
+	if in.MinPrice != nil {
+		where = append(where, squirrel.Expr("a.current_bid * fx.rate >= ?", *in.MinPrice))
+	}
+	if in.MaxPrice != nil {
+		where = append(where, squirrel.Expr("a.current_bid * fx.rate <= ?", *in.MaxPrice))
+	}
+
 	var total int64
 	countSQL, countArgs, _ := r.Builder.
 		Select("COUNT(*)").
-		From("auctions").
-		Where("status = ? AND ends_at > NOW()", e.AuctionStatusActive).
+		From("auctions a").
+		LeftJoin("fx_rates fx ON fx.currency = a.currency").
+		Where(where).
 		ToSql()
 
 	if err := conn.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
@@ -90,13 +124,15 @@ func (r *AuctionRepo) ListActive(ctx context.Context, limit, offset int) ([]e.Au
 	}
 
 	sql, args, _ := r.Builder.
-		Select("auction_id", "title", "description", "seller_id", "start_price",
-			"current_bid", "min_step", "status", "ends_at", "created_at").
-		From("auctions").
-		Where("status = ? AND ends_at > NOW()", e.AuctionStatusActive).
-		OrderBy("ends_at ASC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
+		Select("a.auction_id", "a.title", "a.description", "a.category", "a.seller_id", "a.start_price",
+			"a.current_bid", "a.min_step", "a.currency", "a.increments", "a.quantity", "a.pricing", "a.auction_type", "a.visibility", "a.eligibility", "a.status",
+			"a.ends_at", "a.created_at").
+		From("auctions a").
+		LeftJoin("fx_rates fx ON fx.currency = a.currency").
+		Where(where).
+		OrderBy("a.ends_at ASC").
+		Limit(uint64(in.Limit)).
+		Offset(uint64(in.Offset)).
 		ToSql()
 
 	rows, err := conn.Query(ctx, sql, args...)
@@ -109,19 +145,20 @@ func (r *AuctionRepo) ListActive(ctx context.Context, limit, offset int) ([]e.Au
 	for rows.Next() {
 		var a e.Auction
 		if err := rows.Scan(
-			&a.AuctionID, &a.Title, &a.Description, &a.SellerID,
-			&a.StartPrice, &a.CurrentBid, &a.MinStep, &a.Status,
+			&a.AuctionID, &a.Title, &a.Description, &a.Category, &a.SellerID,
+			&a.StartPrice, &a.CurrentBid, &a.MinStep, &a.Currency, &a.Increments, &a.Quantity, &a.Pricing, &a.Type, &a.Visibility, &a.Eligibility, &a.Status,
 			&a.EndsAt, &a.CreatedAt,
 		); err != nil {
 			return nil, 0, errutils.WrapPathErr(err)
 		}
+		a.SetCurrency(a.Currency)
 		auctions = append(auctions, a)
 	}
 
 	return auctions, total, nil
 }
 
-func (r *AuctionRepo) UpdateCurrentBid(ctx context.Context, auctionID string, amount float64) error {
+func (r *AuctionRepo) UpdateCurrentBid(ctx context.Context, auctionID string, amount money.Money) error {
 	sql, args, _ := r.Builder.
 		Update("auctions").
 		Set("current_bid", amount).
@@ -139,7 +176,7 @@ func (r *AuctionRepo) UpdateCurrentBid(ctx context.Context, auctionID string, am
 	return nil
 }
 
-func (r *AuctionRepo) FinishAuction(ctx context.Context, auctionID string, winnerID string, finalPrice float64) error {
+func (r *AuctionRepo) FinishAuction(ctx context.Context, auctionID string, winnerID string, finalPrice money.Money) error {
 	builder := r.Builder.
 		Update("auctions").
 		Set("status", e.AuctionStatusFinished).
@@ -162,7 +199,9 @@ func (r *AuctionRepo) FinishAuction(ctx context.Context, auctionID string, winne
 
 func (r *AuctionRepo) GetExpired(ctx context.Context) ([]e.Auction, error) {
 	sql, args, _ := r.Builder.
-		Select("auction_id", "title", "seller_id", "start_price", "current_bid", "min_step", "status", "ends_at").
+		Select("auction_id", "title", "category", "seller_id", "start_price", "current_bid", "min_step", "currency",
+			"quantity", "pricing", "auction_type", "visibility", "status", "ends_at",
+			"reserve_price", "duration_min", "relist_policy", "relist_count").
 		From("auctions").
 		Where("status = ? AND ends_at <= NOW()", e.AuctionStatusActive).
 		ToSql()
@@ -178,12 +217,196 @@ func (r *AuctionRepo) GetExpired(ctx context.Context) ([]e.Auction, error) {
 	for rows.Next() {
 		var a e.Auction
 		if err := rows.Scan(
-			&a.AuctionID, &a.Title, &a.SellerID, &a.StartPrice,
-			&a.CurrentBid, &a.MinStep, &a.Status, &a.EndsAt,
+			&a.AuctionID, &a.Title, &a.Category, &a.SellerID, &a.StartPrice,
+			&a.CurrentBid, &a.MinStep, &a.Currency, &a.Quantity, &a.Pricing, &a.Type, &a.Visibility, &a.Status, &a.EndsAt,
+			&a.ReservePrice, &a.DurationMin, &a.Relist, &a.RelistCount,
 		); err != nil {
 			return nil, errutils.WrapPathErr(err)
 		}
+		a.SetCurrency(a.Currency)
 		auctions = append(auctions, a)
 	}
 	return auctions, nil
 }
+
+func (r *AuctionRepo) ListBySeller(ctx context.Context, sellerID string, limit, offset int) ([]e.SellerAuction, int64, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var total int64
+	countSQL, countArgs, _ := r.Builder.
+		Select("COUNT(*)").
+		From("auctions").
+		Where("seller_id = ?", sellerID).
+		ToSql()
+
+	if err := conn.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+
+	sql, args, _ := r.Builder.
+		Select("a.auction_id", "a.title", "a.description", "a.category", "a.seller_id", "a.start_price",
+			"a.current_bid", "a.min_step", "a.currency", "a.increments", "a.quantity", "a.pricing", "a.auction_type", "a.visibility", "a.eligibility", "a.status",
+			"COALESCE(a.winner_id, '') AS winner_id", "a.ends_at", "a.created_at", "a.finished_at", "COUNT(b.bid_id) AS bids_count",
+			"a.reserve_price", "a.relist_policy", "a.relist_count", "COALESCE(a.relisted_from, '') AS relisted_from").
+		From("auctions a").
+		LeftJoin("bids b ON b.auction_id = a.auction_id").
+		Where("a.seller_id = ?", sellerID).
+		GroupBy("a.auction_id").
+		OrderBy("a.created_at DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var auctions []e.SellerAuction
+	for rows.Next() {
+		var a e.SellerAuction
+		if err := rows.Scan(
+			&a.AuctionID, &a.Title, &a.Description, &a.Category, &a.SellerID,
+			&a.StartPrice, &a.CurrentBid, &a.MinStep, &a.Currency, &a.Increments, &a.Quantity, &a.Pricing, &a.Type, &a.Visibility, &a.Eligibility, &a.Status,
+			&a.WinnerID, &a.EndsAt, &a.CreatedAt, &a.FinishedAt, &a.BidsCount,
+			&a.ReservePrice, &a.Relist, &a.RelistCount, &a.RelistedFrom,
+		); err != nil {
+			return nil, 0, errutils.WrapPathErr(err)
+		}
+		a.SetCurrency(a.Currency)
+		auctions = append(auctions, a)
+	}
+
+	return auctions, total, nil
+}
+
+func (r *AuctionRepo) GetEndingWithin(ctx context.Context, window time.Duration) ([]e.Auction, error) {
+	sql, args, _ := r.Builder.
+		Select("auction_id", "title", "seller_id", "current_bid", "currency", "status", "ends_at").
+		From("auctions").
+		Where("status = ? AND ends_at > NOW() AND ends_at <= NOW() + ?::interval", e.AuctionStatusActive, window.String()).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var auctions []e.Auction
+	for rows.Next() {
+		var a e.Auction
+		if err := rows.Scan(
+			&a.AuctionID, &a.Title, &a.SellerID, &a.CurrentBid, &a.Currency, &a.Status, &a.EndsAt,
+		); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		a.SetCurrency(a.Currency)
+		auctions = append(auctions, a)
+	}
+	return auctions, nil
+}
+
+func (r *AuctionRepo) ForceEnd(ctx context.Context, auctionID string) error {
+	sql, args, _ := r.Builder.
+		Update("auctions").
+		Set("ends_at", squirrel.Expr("NOW()")).
+		Where("auction_id = ? AND status = ?", auctionID, e.AuctionStatusActive).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AuctionRepo) Cancel(ctx context.Context, auctionID string) error {
+	sql, args, _ := r.Builder.
+		Update("auctions").
+		Set("status", e.AuctionStatusCancelled).
+		Set("finished_at", squirrel.Expr("NOW()")).
+		Where("auction_id = ? AND status = ?", auctionID, e.AuctionStatusActive).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+// ExistingIDs returns which of the given auction IDs are already taken.
+func (r *AuctionRepo) ExistingIDs(ctx context.Context, auctionIDs []string) ([]string, error) {
+	sql, args, _ := r.Builder.
+		Select("auction_id").
+		From("auctions").
+		Where(squirrel.Eq{"auction_id": auctionIDs}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var existing []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		existing = append(existing, id)
+	}
+	return existing, nil
+}
+
+// Delete removes an auction nobody has bid on or registered for, and fails
+// with ErrNotFound otherwise.
+func (r *AuctionRepo) Delete(ctx context.Context, auctionID string) error {
+	sql, args, _ := r.Builder.
+		Delete("auctions").
+		Where("auction_id = ?", auctionID).
+		Where("NOT EXISTS (SELECT 1 FROM bids b WHERE b.auction_id = auctions.auction_id)").
+		Where("NOT EXISTS (SELECT 1 FROM auction_registrations ar WHERE ar.auction_id = auctions.auction_id)").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AuctionRepo) UpdateVisibility(ctx context.Context, auctionID string, visibility e.AuctionVisibility) error {
+	sql, args, _ := r.Builder.
+		Update("auctions").
+		Set("visibility", visibility).
+		Where("auction_id = ?", auctionID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package pgdb
+
+import (
+	"context"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+)
+
+const activeBanExpr = "(expires_at IS NULL OR expires_at > NOW())"
+
+type BanRepo struct {
+	*postgres.Postgres
+}
+
+func NewBanRepo(pg *postgres.Postgres) *BanRepo {
+	return &BanRepo{pg}
+}
+
+func (r *BanRepo) Create(ctx context.Context, in rd.CreateBanInput) (e.Ban, error) {
+	sql, args, _ := r.Builder.
+		Insert("user_bans").
+		Columns("user_id", "reason", "banned_by", "expires_at").
+		Values(in.UserID, in.Reason, in.BannedBy, in.ExpiresAt).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET reason = EXCLUDED.reason, banned_by = EXCLUDED.banned_by, " +
+			"expires_at = EXCLUDED.expires_at, created_at = NOW() " +
+			"RETURNING user_id, reason, banned_by, created_at, expires_at").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var b e.Ban
+	err := conn.QueryRow(ctx, sql, args...).Scan(&b.UserID, &b.Reason, &b.BannedBy, &b.CreatedAt, &b.ExpiresAt)
+	if err != nil {
+		return e.Ban{}, errutils.WrapPathErr(err)
+	}
+	return b, nil
+}
+
+func (r *BanRepo) Delete(ctx context.Context, userID string) error {
+	sql, args, _ := r.Builder.
+		Delete("user_bans").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+// IsBanned ignores expired bans.
+func (r *BanRepo) IsBanned(ctx context.Context, userID string) (bool, error) {
+	sql, args, _ := r.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("user_bans").
+		Where("user_id = ? AND "+activeBanExpr, userID).
+		Suffix(")").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var banned bool
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&banned); err != nil {
+		return false, errutils.WrapPathErr(err)
+	}
+	return banned, nil
+}
+
+// List returns the bans that have not expired.
+func (r *BanRepo) List(ctx context.Context) ([]e.Ban, error) {
+	sql, args, _ := r.Builder.
+		Select("user_id", "reason", "banned_by", "created_at", "expires_at").
+		From("user_bans").
+		Where(activeBanExpr).
+		OrderBy("created_at DESC").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var bans []e.Ban
+	for rows.Next() {
+		var b e.Ban
+		if err := rows.Scan(&b.UserID, &b.Reason, &b.BannedBy, &b.CreatedAt, &b.ExpiresAt); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		bans = append(bans, b)
+	}
+	return bans, nil
+}
+
+func (r *BanRepo) Block(ctx context.Context, in rd.BlockBidderInput) (e.BlockedBidder, error) {
+	sql, args, _ := r.Builder.
+		Insert("seller_blocked_bidders").
+		Columns("seller_id", "bidder_id", "reason").
+		Values(in.SellerID, in.BidderID, in.Reason).
+		Suffix("ON CONFLICT (seller_id, bidder_id) DO UPDATE SET reason = EXCLUDED.reason " +
+			"RETURNING seller_id, bidder_id, reason, created_at").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var b e.BlockedBidder
+	err := conn.QueryRow(ctx, sql, args...).Scan(&b.SellerID, &b.BidderID, &b.Reason, &b.CreatedAt)
+	if err != nil {
+		return e.BlockedBidder{}, errutils.WrapPathErr(err)
+	}
+	return b, nil
+}
+
+func (r *BanRepo) Unblock(ctx context.Context, sellerID, bidderID string) error {
+	sql, args, _ := r.Builder.
+		Delete("seller_blocked_bidders").
+		Where("seller_id = ? AND bidder_id = ?", sellerID, bidderID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *BanRepo) IsBlocked(ctx context.Context, sellerID, bidderID string) (bool, error) {
+	sql, args, _ := r.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("seller_blocked_bidders").
+		Where("seller_id = ? AND bidder_id = ?", sellerID, bidderID).
+		Suffix(")").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var blocked bool
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&blocked); err != nil {
+		return false, errutils.WrapPathErr(err)
+	}
+	return blocked, nil
+}
+
+func (r *BanRepo) ListBlocked(ctx context.Context, sellerID string) ([]e.BlockedBidder, error) {
+	sql, args, _ := r.Builder.
+		Select("seller_id", "bidder_id", "reason", "created_at").
+		From("seller_blocked_bidders").
+		Where("seller_id = ?", sellerID).
+		OrderBy("created_at DESC").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var blocked []e.BlockedBidder
+	for rows.Next() {
+		var b e.BlockedBidder
+		if err := rows.Scan(&b.SellerID, &b.BidderID, &b.Reason, &b.CreatedAt); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		blocked = append(blocked, b)
+	}
+	return blocked, nil
+}
@@ -10,7 +10,10 @@ import (
 	errutils "auction-platform/pkg/errors"
 	"auction-platform/pkg/postgres"
 
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type BidRepo struct {
@@ -24,18 +27,23 @@ func NewBidRepo(pg *postgres.Postgres) *BidRepo {
 func (r *BidRepo) Create(ctx context.Context, in rd.CreateBidInput) (e.Bid, error) {
 	sql, args, _ := r.Builder.
 		Insert("bids").
-		Columns("bid_id", "auction_id", "bidder_id", "amount", "status").
-		Values(in.BidID, in.AuctionID, in.BidderID, in.Amount, in.Status).
-		Suffix("RETURNING bid_id, auction_id, bidder_id, amount, status, created_at").
+		Columns("bid_id", "auction_id", "bidder_id", "amount", "currency", "quantity", "status").
+		Values(in.BidID, in.AuctionID, in.BidderID, in.Amount,
+			squirrel.Expr("(SELECT currency FROM auctions WHERE auction_id = ?)", in.AuctionID), in.Quantity, in.Status).
+		Suffix("RETURNING bid_id, auction_id, bidder_id, amount, currency, quantity, status, created_at").
 		ToSql()
 
 	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
 
 	var b e.Bid
 	err := conn.QueryRow(ctx, sql, args...).Scan(
-		&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Status, &b.CreatedAt,
+		&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Amount.Currency, &b.Quantity, &b.Status, &b.CreatedAt,
 	)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return e.Bid{}, re.ErrAlreadyExists
+		}
 		return e.Bid{}, errutils.WrapPathErr(err)
 	}
 	return b, nil
@@ -59,12 +67,144 @@ func (r *BidRepo) UpdateStatus(ctx context.Context, bidID string, status e.BidSt
 	return nil
 }
 
-func (r *BidRepo) GetHighestByAuction(ctx context.Context, auctionID string) (e.Bid, error) {
+func (r *BidRepo) ListByAuction(ctx context.Context, auctionID string, limit int) ([]e.Bid, error) {
 	sql, args, _ := r.Builder.
-		Select("bid_id", "auction_id", "bidder_id", "amount", "status", "created_at").
+		Select("bid_id", "auction_id", "bidder_id", "amount", "currency", "quantity", "status", "created_at").
 		From("bids").
-		Where("auction_id = ? AND status IN (?, ?)", auctionID, e.BidStatusAccepted, e.BidStatusPending).
+		Where("auction_id = ?", auctionID).
 		OrderBy("amount DESC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var bids []e.Bid
+	for rows.Next() {
+		var b e.Bid
+		if err := rows.Scan(&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Amount.Currency, &b.Quantity, &b.Status, &b.CreatedAt); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		bids = append(bids, b)
+	}
+	return bids, nil
+}
+
+func (r *BidRepo) CountByAuction(ctx context.Context, auctionID string) (int, error) {
+	sql, args, _ := r.Builder.
+		Select("COUNT(*)").
+		From("bids").
+		Where("auction_id = ?", auctionID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var count int
+	err := conn.QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, errutils.WrapPathErr(err)
+	}
+	return count, nil
+}
+
+// isLeadingExpr tells whether the bidder's best accepted bid sets the current
+// price. It expects bids b grouped by auctions a and takes the reverse auction
+// type and the accepted status twice.
+const isLeadingExpr = "COALESCE(CASE WHEN a.auction_type = ? THEN MIN(b.amount) FILTER (WHERE b.status = ?) <= a.current_bid " +
+	"ELSE MAX(b.amount) FILTER (WHERE b.status = ?) >= a.current_bid END, false)"
+
+// ListAuctionsByBidder reports the bidder's best bid on each auction as
+// max_bid: the highest in a forward auction and the lowest in a reverse one.
+func (r *BidRepo) ListAuctionsByBidder(ctx context.Context, bidderID string, limit, offset int) ([]e.BidderAuction, int64, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var total int64
+	countSQL, countArgs, _ := r.Builder.
+		Select("COUNT(DISTINCT auction_id)").
+		From("bids").
+		Where("bidder_id = ?", bidderID).
+		ToSql()
+
+	if err := conn.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+
+	sql, args, _ := r.Builder.
+		Select("a.auction_id", "a.title", "a.status", "a.current_bid", "a.currency",
+			"COALESCE(a.winner_id, '') AS winner_id", "a.ends_at", "a.finished_at").
+		Column("CASE WHEN a.auction_type = ? THEN MIN(b.amount) ELSE MAX(b.amount) END AS max_bid", e.AuctionTypeReverse).
+		Column("COUNT(b.bid_id) AS bids_count").
+		Column(isLeadingExpr+" AS is_leading", e.AuctionTypeReverse, e.BidStatusAccepted, e.BidStatusAccepted).
+		Column("EXISTS (SELECT 1 FROM orders o WHERE o.auction_id = a.auction_id AND ? IN (o.buyer_id, o.seller_id) AND o.kind = ?) AS has_won",
+			bidderID, e.OrderKindWin).
+		From("bids b").
+		Join("auctions a ON a.auction_id = b.auction_id").
+		Where("b.bidder_id = ?", bidderID).
+		GroupBy("a.auction_id").
+		OrderBy("a.ends_at DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var auctions []e.BidderAuction
+	for rows.Next() {
+		var a e.BidderAuction
+		if err := rows.Scan(
+			&a.AuctionID, &a.Title, &a.AuctionStatus, &a.CurrentBid, &a.Currency,
+			&a.WinnerID, &a.EndsAt, &a.FinishedAt,
+			&a.MaxBid, &a.BidsCount, &a.IsLeading, &a.HasWon,
+		); err != nil {
+			return nil, 0, errutils.WrapPathErr(err)
+		}
+		a.CurrentBid.Currency, a.MaxBid.Currency = a.Currency, a.Currency
+		auctions = append(auctions, a)
+	}
+
+	return auctions, total, nil
+}
+
+// CountLeading counts the other active auctions the bidder is currently
+// winning.
+func (r *BidRepo) CountLeading(ctx context.Context, bidderID, excludeAuctionID string) (int, error) {
+	leading := r.Builder.
+		Select("a.auction_id").
+		From("bids b").
+		Join("auctions a ON a.auction_id = b.auction_id").
+		Where("b.bidder_id = ? AND a.status = ? AND a.auction_id <> ?", bidderID, e.AuctionStatusActive, excludeAuctionID).
+		GroupBy("a.auction_id").
+		Having(isLeadingExpr, e.AuctionTypeReverse, e.BidStatusAccepted, e.BidStatusAccepted)
+
+	sql, args, _ := r.Builder.
+		Select("COUNT(*)").
+		FromSelect(leading, "leading").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var count int
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, errutils.WrapPathErr(err)
+	}
+	return count, nil
+}
+
+// GetRunnerUp returns the best accepted bid from a bidder who has no order on
+// the auction yet.
+func (r *BidRepo) GetRunnerUp(ctx context.Context, auctionID string) (e.Bid, error) {
+	sql, args, _ := r.Builder.
+		Select("bid_id", "auction_id", "bidder_id", "amount", "currency", "quantity", "status", "created_at").
+		From("bids b").
+		Where("auction_id = ? AND status = ?", auctionID, e.BidStatusAccepted).
+		Where("NOT EXISTS (SELECT 1 FROM orders o WHERE o.auction_id = b.auction_id AND o.buyer_id = b.bidder_id)").
+		OrderBy("amount DESC", "created_at ASC").
 		Limit(1).
 		ToSql()
 
@@ -72,7 +212,7 @@ func (r *BidRepo) GetHighestByAuction(ctx context.Context, auctionID string) (e.
 
 	var b e.Bid
 	err := conn.QueryRow(ctx, sql, args...).Scan(
-		&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Status, &b.CreatedAt,
+		&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Amount.Currency, &b.Quantity, &b.Status, &b.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -83,13 +223,11 @@ func (r *BidRepo) GetHighestByAuction(ctx context.Context, auctionID string) (e.
 	return b, nil
 }
 
-func (r *BidRepo) ListByAuction(ctx context.Context, auctionID string, limit int) ([]e.Bid, error) {
+func (r *BidRepo) ListBidderIDs(ctx context.Context, auctionID string) ([]string, error) {
 	sql, args, _ := r.Builder.
-		Select("bid_id", "auction_id", "bidder_id", "amount", "status", "created_at").
+		Select("DISTINCT bidder_id").
 		From("bids").
 		Where("auction_id = ?", auctionID).
-		OrderBy("amount DESC").
-		Limit(uint64(limit)).
 		ToSql()
 
 	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
@@ -99,29 +237,73 @@ func (r *BidRepo) ListByAuction(ctx context.Context, auctionID string, limit int
 	}
 	defer rows.Close()
 
-	var bids []e.Bid
+	var bidders []string
 	for rows.Next() {
-		var b e.Bid
-		if err := rows.Scan(&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Status, &b.CreatedAt); err != nil {
+		var bidderID string
+		if err := rows.Scan(&bidderID); err != nil {
 			return nil, errutils.WrapPathErr(err)
 		}
-		bids = append(bids, b)
+		bidders = append(bidders, bidderID)
 	}
-	return bids, nil
+	return bidders, nil
 }
 
-func (r *BidRepo) CountByAuction(ctx context.Context, auctionID string) (int, error) {
+func (r *BidRepo) GetByID(ctx context.Context, bidID string) (e.Bid, error) {
 	sql, args, _ := r.Builder.
-		Select("COUNT(*)").
+		Select("bid_id", "auction_id", "bidder_id", "amount", "currency", "quantity", "status", "created_at").
 		From("bids").
-		Where("auction_id = ?", auctionID).
+		Where("bid_id = ?", bidID).
 		ToSql()
 
 	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
-	var count int
-	err := conn.QueryRow(ctx, sql, args...).Scan(&count)
+
+	var b e.Bid
+	err := conn.QueryRow(ctx, sql, args...).Scan(
+		&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Amount.Currency, &b.Quantity, &b.Status, &b.CreatedAt,
+	)
 	if err != nil {
-		return 0, errutils.WrapPathErr(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Bid{}, re.ErrNotFound
+		}
+		return e.Bid{}, errutils.WrapPathErr(err)
 	}
-	return count, nil
+	return b, nil
+}
+
+// ListActive returns the latest accepted bid of every bidder on the auction,
+// best first: highest amount, or lowest with lowestFirst, then earliest.
+func (r *BidRepo) ListActive(ctx context.Context, auctionID string, lowestFirst bool) ([]e.Bid, error) {
+	latest := r.Builder.
+		Select("DISTINCT ON (bidder_id) bid_id", "auction_id", "bidder_id", "amount", "currency", "quantity", "status", "created_at").
+		From("bids").
+		Where("auction_id = ? AND status = ?", auctionID, e.BidStatusAccepted).
+		OrderBy("bidder_id", "created_at DESC")
+
+	byAmount := "amount DESC"
+	if lowestFirst {
+		byAmount = "amount ASC"
+	}
+
+	sql, args, _ := r.Builder.
+		Select("bid_id", "auction_id", "bidder_id", "amount", "currency", "quantity", "status", "created_at").
+		FromSelect(latest, "latest").
+		OrderBy(byAmount, "created_at ASC").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var bids []e.Bid
+	for rows.Next() {
+		var b e.Bid
+		if err := rows.Scan(&b.BidID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Amount.Currency, &b.Quantity, &b.Status, &b.CreatedAt); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		bids = append(bids, b)
+	}
+	return bids, nil
 }
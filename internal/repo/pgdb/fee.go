@@ -0,0 +1,152 @@
+package pgdb
+
+import (
+	"context"
+	"time"
+
+	e "auction-platform/internal/entity"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+)
+
+type FeeRepo struct {
+	*postgres.Postgres
+}
+
+func NewFeeRepo(pg *postgres.Postgres) *FeeRepo {
+	return &FeeRepo{pg}
+}
+
+func (r *FeeRepo) CreateLines(ctx context.Context, lines []e.FeeLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	q := r.Builder.
+		Insert("fee_lines").
+		Columns("order_id", "seller_id", "fee_type", "basis", "rate", "amount")
+	for _, l := range lines {
+		q = q.Values(l.OrderID, l.SellerID, l.Type, l.Basis, l.Rate, l.Amount)
+	}
+	sql, args, _ := q.ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+// HasListingFee reports whether an order of the auction already carries the
+// listing fee.
+func (r *FeeRepo) HasListingFee(ctx context.Context, auctionID string) (bool, error) {
+	sql, args, _ := r.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("fee_lines f").
+		Join("orders o ON o.order_id = f.order_id").
+		Where("o.auction_id = ? AND f.fee_type = ?", auctionID, e.FeeListing).
+		Suffix(")").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var found bool
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&found); err != nil {
+		return false, errutils.WrapPathErr(err)
+	}
+	return found, nil
+}
+
+func (r *FeeRepo) ListByOrder(ctx context.Context, orderID int64) ([]e.FeeLine, error) {
+	sql, args, _ := r.Builder.
+		Select("fee_line_id", "order_id", "seller_id", "fee_type", "basis", "rate", "amount", "created_at").
+		From("fee_lines").
+		Where("order_id = ?", orderID).
+		OrderBy("fee_line_id").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var lines []e.FeeLine
+	for rows.Next() {
+		var l e.FeeLine
+		if err := rows.Scan(
+			&l.FeeLineID, &l.OrderID, &l.SellerID, &l.Type, &l.Basis, &l.Rate, &l.Amount, &l.CreatedAt,
+		); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// ListStatementLines returns the seller's orders paid in [from, to) with the
+// total fees charged on each. Gross is converted into money.DefaultCurrency at
+// the order's rate snapshot; fees are charged in that currency already.
+func (r *FeeRepo) ListStatementLines(ctx context.Context, sellerID string, from, to time.Time) ([]e.StatementLine, error) {
+	sql, args, _ := r.Builder.
+		Select("o.order_id", "o.auction_id", "o.buyer_id", "o.amount", "o.currency", "o.fx_rate",
+			"ROUND(o.amount * o.fx_rate, 2)", "COALESCE(SUM(f.amount), 0)", "o.paid_at").
+		From("orders o").
+		LeftJoin("fee_lines f ON f.order_id = o.order_id").
+		Where("o.seller_id = ? AND o.status = ? AND o.paid_at >= ? AND o.paid_at < ?",
+			sellerID, e.OrderStatusPaid, from, to).
+		GroupBy("o.order_id").
+		OrderBy("o.paid_at", "o.order_id").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var lines []e.StatementLine
+	for rows.Next() {
+		var l e.StatementLine
+		if err := rows.Scan(
+			&l.OrderID, &l.AuctionID, &l.BuyerID, &l.Amount, &l.Amount.Currency, &l.FXRate,
+			&l.Gross, &l.Fees, &l.PaidAt,
+		); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// SummarizeBySeller totals gross sales and fees of orders paid in [from, to)
+// for every seller.
+func (r *FeeRepo) SummarizeBySeller(ctx context.Context, from, to time.Time) ([]e.SellerStatement, error) {
+	sql, args, _ := r.Builder.
+		Select("o.seller_id", "COUNT(*)", "COALESCE(SUM(ROUND(o.amount * o.fx_rate, 2)), 0)", "COALESCE(SUM(f.fees), 0)").
+		From("orders o").
+		LeftJoin("(SELECT order_id, SUM(amount) AS fees FROM fee_lines GROUP BY order_id) f ON f.order_id = o.order_id").
+		Where("o.status = ? AND o.paid_at >= ? AND o.paid_at < ?", e.OrderStatusPaid, from, to).
+		GroupBy("o.seller_id").
+		OrderBy("o.seller_id").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var statements []e.SellerStatement
+	for rows.Next() {
+		st := e.SellerStatement{From: from, To: to}
+		if err := rows.Scan(&st.SellerID, &st.Orders, &st.GrossSales, &st.Fees); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		statements = append(statements, st)
+	}
+	return statements, nil
+}
@@ -0,0 +1,224 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const fraudFlagColumns = "flag_id, bid_id, auction_id, bidder_id, seller_id, score, signals, held, status, " +
+	"reviewed_by, note, created_at, reviewed_at"
+
+type FraudRepo struct {
+	*postgres.Postgres
+}
+
+func NewFraudRepo(pg *postgres.Postgres) *FraudRepo {
+	return &FraudRepo{pg}
+}
+
+func scanFraudFlag(row pgx.Row) (e.FraudFlag, error) {
+	var f e.FraudFlag
+	var signals []string
+	err := row.Scan(&f.FlagID, &f.BidID, &f.AuctionID, &f.BidderID, &f.SellerID, &f.Score, &signals, &f.Held,
+		&f.Status, &f.ReviewedBy, &f.Note, &f.CreatedAt, &f.ReviewedAt)
+	for _, s := range signals {
+		f.Signals = append(f.Signals, e.FraudSignal(s))
+	}
+	return f, err
+}
+
+func (r *FraudRepo) RecordDevice(ctx context.Context, userID, ip, deviceID string) error {
+	sql, args, _ := r.Builder.
+		Insert("user_devices").
+		Columns("user_id", "ip", "device_id").
+		Values(userID, ip, deviceID).
+		Suffix("ON CONFLICT (user_id, ip, device_id) DO UPDATE SET last_seen_at = NOW()").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+// SharesDevice reports whether userID has been seen on ip or deviceID. Empty
+// values never match.
+func (r *FraudRepo) SharesDevice(ctx context.Context, userID, ip, deviceID string) (bool, error) {
+	sql, args, _ := r.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("user_devices").
+		Where("user_id = ? AND ((ip <> '' AND ip = ?) OR (device_id <> '' AND device_id = ?))", userID, ip, deviceID).
+		Suffix(")").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var shared bool
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&shared); err != nil {
+		return false, errutils.WrapPathErr(err)
+	}
+	return shared, nil
+}
+
+func (r *FraudRepo) RecordRetraction(ctx context.Context, bidID, auctionID, bidderID string) error {
+	sql, args, _ := r.Builder.
+		Insert("bid_retractions").
+		Columns("bid_id", "auction_id", "bidder_id").
+		Values(bidID, auctionID, bidderID).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+// BidderSellerStats counts the sellers bidderID has bid with, the auctions of
+// sellerID they bid on and the finished ones they did not win, and their
+// retracted bids.
+func (r *FraudRepo) BidderSellerStats(ctx context.Context, bidderID, sellerID string) (e.BidderSellerStats, error) {
+	sql, args, _ := r.Builder.
+		Select("COUNT(DISTINCT a.seller_id)").
+		Column("COUNT(DISTINCT a.auction_id) FILTER (WHERE a.seller_id = ?)", sellerID).
+		Column("COUNT(DISTINCT a.auction_id) FILTER (WHERE a.seller_id = ? AND a.status = ? "+
+			"AND COALESCE(a.winner_id, '') <> b.bidder_id)", sellerID, e.AuctionStatusFinished).
+		Column("(SELECT COUNT(*) FROM bid_retractions r WHERE r.bidder_id = ?)", bidderID).
+		From("bids b").
+		Join("auctions a ON a.auction_id = b.auction_id").
+		Where("b.bidder_id = ?", bidderID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var stats e.BidderSellerStats
+	err := conn.QueryRow(ctx, sql, args...).Scan(&stats.Sellers, &stats.SellerAuctions, &stats.LostToSeller, &stats.Retractions)
+	if err != nil {
+		return e.BidderSellerStats{}, errutils.WrapPathErr(err)
+	}
+	return stats, nil
+}
+
+// CreateFlag fails with ErrAlreadyExists when the bid is already flagged.
+func (r *FraudRepo) CreateFlag(ctx context.Context, in rd.CreateFraudFlagInput) (e.FraudFlag, error) {
+	signals := make([]string, 0, len(in.Signals))
+	for _, s := range in.Signals {
+		signals = append(signals, string(s))
+	}
+
+	sql, args, _ := r.Builder.
+		Insert("fraud_flags").
+		Columns("bid_id", "auction_id", "bidder_id", "seller_id", "score", "signals", "held").
+		Values(in.BidID, in.AuctionID, in.BidderID, in.SellerID, in.Score, signals, in.Held).
+		Suffix("ON CONFLICT (bid_id) DO NOTHING RETURNING " + fraudFlagColumns).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	flag, err := scanFraudFlag(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.FraudFlag{}, re.ErrAlreadyExists
+		}
+		return e.FraudFlag{}, errutils.WrapPathErr(err)
+	}
+	return flag, nil
+}
+
+func (r *FraudRepo) GetFlag(ctx context.Context, flagID int64) (e.FraudFlag, error) {
+	sql, args, _ := r.Builder.
+		Select(fraudFlagColumns).
+		From("fraud_flags").
+		Where("flag_id = ?", flagID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	flag, err := scanFraudFlag(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.FraudFlag{}, re.ErrNotFound
+		}
+		return e.FraudFlag{}, errutils.WrapPathErr(err)
+	}
+	return flag, nil
+}
+
+// ListFlags returns flags with the highest score first, all of them when status
+// is empty.
+func (r *FraudRepo) ListFlags(ctx context.Context, status e.FraudFlagStatus, limit, offset int) ([]e.FraudFlag, int64, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	where := squirrel.Eq{}
+	if status != "" {
+		where["status"] = status
+	}
+
+	var total int64
+	countSQL, countArgs, _ := r.Builder.
+		Select("COUNT(*)").
+		From("fraud_flags").
+		Where(where).
+		ToSql()
+
+	if err := conn.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+
+	sql, args, _ := r.Builder.
+		Select(fraudFlagColumns).
+		From("fraud_flags").
+		Where(where).
+		OrderBy("score DESC", "created_at ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var flags []e.FraudFlag
+	for rows.Next() {
+		flag, err := scanFraudFlag(rows)
+		if err != nil {
+			return nil, 0, errutils.WrapPathErr(err)
+		}
+		flags = append(flags, flag)
+	}
+	return flags, total, nil
+}
+
+// ReviewFlag closes an open flag. It fails with ErrNotFound when there is no
+// open flag to close.
+func (r *FraudRepo) ReviewFlag(ctx context.Context, in rd.ReviewFraudFlagInput) (e.FraudFlag, error) {
+	sql, args, _ := r.Builder.
+		Update("fraud_flags").
+		Set("status", in.Status).
+		Set("reviewed_by", in.ReviewedBy).
+		Set("note", in.Note).
+		Set("reviewed_at", squirrel.Expr("NOW()")).
+		Where("flag_id = ? AND status = ?", in.FlagID, e.FraudFlagOpen).
+		Suffix("RETURNING " + fraudFlagColumns).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	flag, err := scanFraudFlag(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.FraudFlag{}, re.ErrNotFound
+		}
+		return e.FraudFlag{}, errutils.WrapPathErr(err)
+	}
+	return flag, nil
+}
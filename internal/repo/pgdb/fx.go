@@ -0,0 +1,64 @@
+package pgdb
+
+import (
+	"context"
+
+	e "auction-platform/internal/entity"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+)
+
+type FXRepo struct {
+	*postgres.Postgres
+}
+
+func NewFXRepo(pg *postgres.Postgres) *FXRepo {
+	return &FXRepo{pg}
+}
+
+func (r *FXRepo) List(ctx context.Context) ([]e.FXRate, error) {
+	sql, args, _ := r.Builder.
+		Select("currency", "rate", "updated_at").
+		From("fx_rates").
+		OrderBy("currency").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var rates []e.FXRate
+	for rows.Next() {
+		var fx e.FXRate
+		if err := rows.Scan(&fx.Currency, &fx.Rate, &fx.UpdatedAt); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		rates = append(rates, fx)
+	}
+	return rates, nil
+}
+
+func (r *FXRepo) Upsert(ctx context.Context, rates []e.FXRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	q := r.Builder.
+		Insert("fx_rates").
+		Columns("currency", "rate")
+	for _, fx := range rates {
+		q = q.Values(fx.Currency, fx.Rate)
+	}
+	sql, args, _ := q.
+		Suffix("ON CONFLICT (currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = NOW()").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var groupColumns = []string{
+	"g.group_id", "g.seller_id", "g.name", "g.created_at",
+	"COALESCE(ARRAY_AGG(m.bidder_id ORDER BY m.bidder_id) FILTER (WHERE m.bidder_id IS NOT NULL), '{}') AS members",
+}
+
+type GroupRepo struct {
+	*postgres.Postgres
+}
+
+func NewGroupRepo(pg *postgres.Postgres) *GroupRepo {
+	return &GroupRepo{pg}
+}
+
+// selectGroups selects bidder groups together with their members.
+func (r *GroupRepo) selectGroups() squirrel.SelectBuilder {
+	return r.Builder.
+		Select(groupColumns...).
+		From("bidder_groups g").
+		LeftJoin("bidder_group_members m ON m.group_id = g.group_id").
+		GroupBy("g.group_id").
+		OrderBy("g.name ASC")
+}
+
+func (r *GroupRepo) query(ctx context.Context, builder squirrel.SelectBuilder) ([]e.BidderGroup, error) {
+	sql, args, _ := builder.ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var groups []e.BidderGroup
+	for rows.Next() {
+		var g e.BidderGroup
+		if err := rows.Scan(&g.GroupID, &g.SellerID, &g.Name, &g.CreatedAt, &g.Members); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+func (r *GroupRepo) Create(ctx context.Context, sellerID, name string) (e.BidderGroup, error) {
+	sql, args, _ := r.Builder.
+		Insert("bidder_groups").
+		Columns("seller_id", "name").
+		Values(sellerID, name).
+		Suffix("RETURNING group_id, seller_id, name, created_at").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var g e.BidderGroup
+	err := conn.QueryRow(ctx, sql, args...).Scan(&g.GroupID, &g.SellerID, &g.Name, &g.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return e.BidderGroup{}, re.ErrAlreadyExists
+		}
+		return e.BidderGroup{}, errutils.WrapPathErr(err)
+	}
+	return g, nil
+}
+
+func (r *GroupRepo) GetByID(ctx context.Context, groupID int64) (e.BidderGroup, error) {
+	groups, err := r.query(ctx, r.selectGroups().Where("g.group_id = ?", groupID))
+	if err != nil {
+		return e.BidderGroup{}, err
+	}
+	if len(groups) == 0 {
+		return e.BidderGroup{}, re.ErrNotFound
+	}
+	return groups[0], nil
+}
+
+func (r *GroupRepo) ListBySeller(ctx context.Context, sellerID string) ([]e.BidderGroup, error) {
+	return r.query(ctx, r.selectGroups().Where("g.seller_id = ?", sellerID))
+}
+
+// ListByAuction returns the groups invited to an auction.
+func (r *GroupRepo) ListByAuction(ctx context.Context, auctionID string) ([]e.BidderGroup, error) {
+	return r.query(ctx, r.selectGroups().
+		Join("auction_invited_groups ig ON ig.group_id = g.group_id").
+		Where("ig.auction_id = ?", auctionID))
+}
+
+func (r *GroupRepo) Delete(ctx context.Context, groupID int64) error {
+	sql, args, _ := r.Builder.
+		Delete("bidder_groups").
+		Where("group_id = ?", groupID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *GroupRepo) AddMembers(ctx context.Context, groupID int64, bidderIDs []string) error {
+	if len(bidderIDs) == 0 {
+		return nil
+	}
+
+	builder := r.Builder.
+		Insert("bidder_group_members").
+		Columns("group_id", "bidder_id")
+	for _, bidderID := range bidderIDs {
+		builder = builder.Values(groupID, bidderID)
+	}
+	sql, args, _ := builder.Suffix("ON CONFLICT DO NOTHING").ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func (r *GroupRepo) RemoveMembers(ctx context.Context, groupID int64, bidderIDs []string) error {
+	if len(bidderIDs) == 0 {
+		return nil
+	}
+
+	sql, args, _ := r.Builder.
+		Delete("bidder_group_members").
+		Where(squirrel.Eq{"group_id": groupID, "bidder_id": bidderIDs}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
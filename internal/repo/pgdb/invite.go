@@ -0,0 +1,141 @@
+package pgdb
+
+import (
+	"context"
+
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type InviteRepo struct {
+	*postgres.Postgres
+}
+
+func NewInviteRepo(pg *postgres.Postgres) *InviteRepo {
+	return &InviteRepo{pg}
+}
+
+func (r *InviteRepo) AddMany(ctx context.Context, auctionID string, bidderIDs []string) error {
+	if len(bidderIDs) == 0 {
+		return nil
+	}
+
+	builder := r.Builder.
+		Insert("auction_invites").
+		Columns("auction_id", "bidder_id")
+	for _, bidderID := range bidderIDs {
+		builder = builder.Values(auctionID, bidderID)
+	}
+	sql, args, _ := builder.Suffix("ON CONFLICT DO NOTHING").ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func (r *InviteRepo) RemoveMany(ctx context.Context, auctionID string, bidderIDs []string) error {
+	if len(bidderIDs) == 0 {
+		return nil
+	}
+
+	sql, args, _ := r.Builder.
+		Delete("auction_invites").
+		Where(squirrel.Eq{"auction_id": auctionID, "bidder_id": bidderIDs}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func (r *InviteRepo) AddGroups(ctx context.Context, auctionID string, groupIDs []int64) error {
+	if len(groupIDs) == 0 {
+		return nil
+	}
+
+	builder := r.Builder.
+		Insert("auction_invited_groups").
+		Columns("auction_id", "group_id")
+	for _, groupID := range groupIDs {
+		builder = builder.Values(auctionID, groupID)
+	}
+	sql, args, _ := builder.Suffix("ON CONFLICT DO NOTHING").ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func (r *InviteRepo) RemoveGroups(ctx context.Context, auctionID string, groupIDs []int64) error {
+	if len(groupIDs) == 0 {
+		return nil
+	}
+
+	sql, args, _ := r.Builder.
+		Delete("auction_invited_groups").
+		Where(squirrel.Eq{"auction_id": auctionID, "group_id": groupIDs}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func (r *InviteRepo) ListByAuction(ctx context.Context, auctionID string) ([]string, error) {
+	sql, args, _ := r.Builder.
+		Select("bidder_id").
+		From("auction_invites").
+		Where("auction_id = ?", auctionID).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var bidders []string
+	for rows.Next() {
+		var bidderID string
+		if err := rows.Scan(&bidderID); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		bidders = append(bidders, bidderID)
+	}
+	return bidders, nil
+}
+
+// IsInvited reports whether the bidder is on the auction's allowlist, either
+// directly or through one of the invited groups.
+func (r *InviteRepo) IsInvited(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	sql, args, _ := r.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("auction_invites").
+		Where("auction_id = ? AND bidder_id = ?", auctionID, bidderID).
+		Suffix(`) OR EXISTS (
+			SELECT 1 FROM auction_invited_groups ig
+			JOIN bidder_group_members m ON m.group_id = ig.group_id
+			WHERE ig.auction_id = ? AND m.bidder_id = ?
+		)`, auctionID, bidderID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var invited bool
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&invited); err != nil {
+		return false, errutils.WrapPathErr(err)
+	}
+	return invited, nil
+}
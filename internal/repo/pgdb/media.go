@@ -0,0 +1,187 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const mediaColumns = "media_id, auction_id, position, kind, content_type, size_bytes, file_name, storage_key, thumbnail_key, created_at"
+
+type MediaRepo struct {
+	*postgres.Postgres
+}
+
+func NewMediaRepo(pg *postgres.Postgres) *MediaRepo {
+	return &MediaRepo{pg}
+}
+
+func scanMedia(row pgx.Row) (e.Media, error) {
+	var m e.Media
+	err := row.Scan(&m.MediaID, &m.AuctionID, &m.Position, &m.Kind, &m.ContentType, &m.Size, &m.FileName,
+		&m.Key, &m.ThumbnailKey, &m.CreatedAt)
+	return m, err
+}
+
+// Create appends the media after the last item of the auction, or fails with
+// re.ErrLimitReached once the auction holds MaxPerAuction items. It locks the
+// auction row so concurrent uploads count one at a time, and must run inside
+// a transaction.
+func (r *MediaRepo) Create(ctx context.Context, in rd.CreateMediaInput) (e.Media, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	sql, args, _ := r.Builder.
+		Select("auction_id").
+		From("auctions").
+		Where("auction_id = ?", in.AuctionID).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	var locked string
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&locked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Media{}, re.ErrNotFound
+		}
+		return e.Media{}, errutils.WrapPathErr(err)
+	}
+
+	count, err := r.CountByAuction(ctx, in.AuctionID)
+	if err != nil {
+		return e.Media{}, err
+	}
+	if count >= in.MaxPerAuction {
+		return e.Media{}, re.ErrLimitReached
+	}
+
+	sql, args, _ = r.Builder.
+		Insert("auction_media").
+		Columns("auction_id", "position", "kind", "content_type", "size_bytes", "file_name", "storage_key", "thumbnail_key").
+		Values(in.AuctionID,
+			squirrel.Expr("(SELECT COALESCE(MAX(position), -1) + 1 FROM auction_media WHERE auction_id = ?)", in.AuctionID),
+			in.Kind, in.ContentType, in.Size, in.FileName, in.Key, in.ThumbnailKey).
+		Suffix("RETURNING " + mediaColumns).
+		ToSql()
+
+	media, err := scanMedia(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return e.Media{}, errutils.WrapPathErr(err)
+	}
+	return media, nil
+}
+
+func (r *MediaRepo) GetByID(ctx context.Context, mediaID int64) (e.Media, error) {
+	sql, args, _ := r.Builder.
+		Select(mediaColumns).
+		From("auction_media").
+		Where("media_id = ?", mediaID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	media, err := scanMedia(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Media{}, re.ErrNotFound
+		}
+		return e.Media{}, errutils.WrapPathErr(err)
+	}
+	return media, nil
+}
+
+func (r *MediaRepo) CountByAuction(ctx context.Context, auctionID string) (int, error) {
+	sql, args, _ := r.Builder.
+		Select("COUNT(*)").
+		From("auction_media").
+		Where("auction_id = ?", auctionID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var count int
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, errutils.WrapPathErr(err)
+	}
+	return count, nil
+}
+
+func (r *MediaRepo) ListByAuction(ctx context.Context, auctionID string) ([]e.Media, error) {
+	byAuction, err := r.ListByAuctions(ctx, []string{auctionID})
+	if err != nil {
+		return nil, err
+	}
+	return byAuction[auctionID], nil
+}
+
+// ListByAuctions returns the media of each auction in display order, leaving
+// out auctions without any.
+func (r *MediaRepo) ListByAuctions(ctx context.Context, auctionIDs []string) (map[string][]e.Media, error) {
+	sql, args, _ := r.Builder.
+		Select(mediaColumns).
+		From("auction_media").
+		Where(squirrel.Eq{"auction_id": auctionIDs}).
+		OrderBy("auction_id", "position").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	byAuction := make(map[string][]e.Media, len(auctionIDs))
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		byAuction[media.AuctionID] = append(byAuction[media.AuctionID], media)
+	}
+	return byAuction, nil
+}
+
+func (r *MediaRepo) Delete(ctx context.Context, mediaID int64) error {
+	sql, args, _ := r.Builder.
+		Delete("auction_media").
+		Where("media_id = ?", mediaID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+// Reorder numbers the given media of the auction in the order listed. The
+// position constraint is deferred, so it must run inside a transaction that
+// covers every item.
+func (r *MediaRepo) Reorder(ctx context.Context, auctionID string, mediaIDs []int64) error {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	for i, id := range mediaIDs {
+		sql, args, _ := r.Builder.
+			Update("auction_media").
+			Set("position", i).
+			Where("media_id = ? AND auction_id = ?", id, auctionID).
+			ToSql()
+
+		cmdTag, err := conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return errutils.WrapPathErr(err)
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return re.ErrNotFound
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type NotificationRepo struct {
+	*postgres.Postgres
+}
+
+func NewNotificationRepo(pg *postgres.Postgres) *NotificationRepo {
+	return &NotificationRepo{pg}
+}
+
+func (r *NotificationRepo) Create(ctx context.Context, in rd.CreateNotificationInput) (e.Notification, error) {
+	sql, args, _ := r.Builder.
+		Insert("notifications").
+		Columns("user_id", "auction_id", "type", "message").
+		Values(in.UserID, in.AuctionID, in.Type, in.Message).
+		Suffix("ON CONFLICT DO NOTHING RETURNING notification_id, user_id, auction_id, type, message, is_read, created_at").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var n e.Notification
+	err := conn.QueryRow(ctx, sql, args...).Scan(
+		&n.NotificationID, &n.UserID, &n.AuctionID, &n.Type, &n.Message, &n.IsRead, &n.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Notification{}, re.ErrAlreadyExists
+		}
+		return e.Notification{}, errutils.WrapPathErr(err)
+	}
+	return n, nil
+}
+
+func (r *NotificationRepo) ListByUser(ctx context.Context, userID string, unreadOnly bool, limit, offset int) ([]e.Notification, int64, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	where := squirrel.Eq{"user_id": userID}
+	if unreadOnly {
+		where["is_read"] = false
+	}
+
+	var total int64
+	countSQL, countArgs, _ := r.Builder.
+		Select("COUNT(*)").
+		From("notifications").
+		Where(where).
+		ToSql()
+
+	if err := conn.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+
+	sql, args, _ := r.Builder.
+		Select("notification_id", "user_id", "auction_id", "type", "message", "is_read", "created_at", "read_at").
+		From("notifications").
+		Where(where).
+		OrderBy("created_at DESC", "notification_id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var notifications []e.Notification
+	for rows.Next() {
+		var n e.Notification
+		if err := rows.Scan(
+			&n.NotificationID, &n.UserID, &n.AuctionID, &n.Type,
+			&n.Message, &n.IsRead, &n.CreatedAt, &n.ReadAt,
+		); err != nil {
+			return nil, 0, errutils.WrapPathErr(err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, total, nil
+}
+
+func (r *NotificationRepo) CountUnread(ctx context.Context, userID string) (int64, error) {
+	sql, args, _ := r.Builder.
+		Select("COUNT(*)").
+		From("notifications").
+		Where("user_id = ? AND is_read = FALSE", userID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var count int64
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, errutils.WrapPathErr(err)
+	}
+	return count, nil
+}
+
+func (r *NotificationRepo) MarkRead(ctx context.Context, userID string, notificationIDs []int64) (int64, error) {
+	builder := r.Builder.
+		Update("notifications").
+		Set("is_read", true).
+		Set("read_at", squirrel.Expr("NOW()")).
+		Where("user_id = ? AND is_read = FALSE", userID)
+
+	if len(notificationIDs) > 0 {
+		builder = builder.Where(squirrel.Eq{"notification_id": notificationIDs})
+	}
+
+	sql, args, _ := builder.ToSql()
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, errutils.WrapPathErr(err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
@@ -0,0 +1,213 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+var orderColumns = []string{
+	"order_id", "auction_id", "buyer_id", "seller_id", "kind", "status", "amount", "amount_paid",
+	"quantity", "currency", "fx_rate", "payment_due_at", "offer_expires_at", "paid_at", "created_at", "updated_at",
+}
+
+type OrderRepo struct {
+	*postgres.Postgres
+}
+
+func NewOrderRepo(pg *postgres.Postgres) *OrderRepo {
+	return &OrderRepo{pg}
+}
+
+func scanOrder(row pgx.Row) (e.Order, error) {
+	var o e.Order
+	err := row.Scan(
+		&o.OrderID, &o.AuctionID, &o.BuyerID, &o.SellerID, &o.Kind, &o.Status, &o.Amount, &o.AmountPaid,
+		&o.Quantity, &o.Amount.Currency, &o.FXRate, &o.PaymentDueAt, &o.OfferExpiresAt, &o.PaidAt, &o.CreatedAt, &o.UpdatedAt,
+	)
+	o.AmountPaid.Currency = o.Amount.Currency
+	return o, err
+}
+
+func (r *OrderRepo) Create(ctx context.Context, in rd.CreateOrderInput) (e.Order, error) {
+	sql, args, _ := r.Builder.
+		Insert("orders").
+		Columns("auction_id", "buyer_id", "seller_id", "kind", "status", "amount", "amount_paid",
+			"quantity", "currency", "fx_rate", "payment_due_at", "offer_expires_at", "paid_at").
+		Values(in.AuctionID, in.BuyerID, in.SellerID, in.Kind, in.Status, in.Amount, in.AmountPaid,
+			in.Quantity, in.Amount.Currency, in.FXRate, in.PaymentDueAt, in.OfferExpiresAt,
+			squirrel.Expr("CASE WHEN ? = 'PAID' THEN NOW() END", in.Status)).
+		Suffix("ON CONFLICT (auction_id, buyer_id, kind) DO NOTHING RETURNING " + strings.Join(orderColumns, ", ")).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	o, err := scanOrder(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Order{}, re.ErrAlreadyExists
+		}
+		return e.Order{}, errutils.WrapPathErr(err)
+	}
+	return o, nil
+}
+
+func (r *OrderRepo) GetByID(ctx context.Context, orderID int64) (e.Order, error) {
+	sql, args, _ := r.Builder.
+		Select(orderColumns...).
+		From("orders").
+		Where("order_id = ?", orderID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	o, err := scanOrder(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Order{}, re.ErrNotFound
+		}
+		return e.Order{}, errutils.WrapPathErr(err)
+	}
+	return o, nil
+}
+
+func (r *OrderRepo) List(ctx context.Context, in rd.ListOrdersInput) ([]e.Order, int64, error) {
+	where := squirrel.Eq{}
+	if in.BuyerID != "" {
+		where["buyer_id"] = in.BuyerID
+	}
+	if in.SellerID != "" {
+		where["seller_id"] = in.SellerID
+	}
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	countSQL, countArgs, _ := r.Builder.
+		Select("COUNT(*)").
+		From("orders").
+		Where(where).
+		ToSql()
+
+	var total int64
+	if err := conn.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+
+	sql, args, _ := r.Builder.
+		Select(orderColumns...).
+		From("orders").
+		Where(where).
+		OrderBy("created_at DESC", "order_id DESC").
+		Limit(uint64(in.Limit)).
+		Offset(uint64(in.Offset)).
+		ToSql()
+
+	orders, err := r.query(ctx, sql, args)
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+func (r *OrderRepo) ListOverdue(ctx context.Context) ([]e.Order, error) {
+	sql, args, _ := r.Builder.
+		Select(orderColumns...).
+		From("orders").
+		Where(squirrel.Or{
+			squirrel.Expr("status = ? AND payment_due_at < NOW()", e.OrderStatusPending),
+			squirrel.Expr("status = ? AND offer_expires_at < NOW()", e.OrderStatusOffered),
+		}).
+		OrderBy("order_id").
+		ToSql()
+
+	return r.query(ctx, sql, args)
+}
+
+// ListTradeParties returns the buyer and seller of each order on the auction,
+// leaving out second-chance offers that are still open, were declined or
+// expired.
+func (r *OrderRepo) ListTradeParties(ctx context.Context, auctionID string) ([]e.TradeParties, error) {
+	sql, args, _ := r.Builder.
+		Select("DISTINCT buyer_id", "seller_id").
+		From("orders").
+		Where("auction_id = ?", auctionID).
+		Where(squirrel.NotEq{"status": []e.OrderStatus{e.OrderStatusOffered, e.OrderStatusCancelled}}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var parties []e.TradeParties
+	for rows.Next() {
+		var p e.TradeParties
+		if err := rows.Scan(&p.BuyerID, &p.SellerID); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		parties = append(parties, p)
+	}
+	return parties, nil
+}
+
+func (r *OrderRepo) query(ctx context.Context, sql string, args []any) ([]e.Order, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var orders []e.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+// Transition moves an order from one status to another and returns
+// ErrNotFound when the order is not in the expected status any more.
+func (r *OrderRepo) Transition(ctx context.Context, in rd.TransitionOrderInput) (e.Order, error) {
+	q := r.Builder.
+		Update("orders").
+		Set("status", in.To).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where("order_id = ? AND status = ?", in.OrderID, in.From)
+	if in.PaymentDueAt != nil {
+		q = q.Set("payment_due_at", *in.PaymentDueAt)
+	}
+	if in.AmountPaid != nil {
+		q = q.Set("amount_paid", *in.AmountPaid)
+	}
+	if in.To == e.OrderStatusPaid {
+		q = q.Set("paid_at", squirrel.Expr("NOW()"))
+	}
+
+	sql, args, _ := q.Suffix("RETURNING " + strings.Join(orderColumns, ", ")).ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	o, err := scanOrder(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Order{}, re.ErrNotFound
+		}
+		return e.Order{}, errutils.WrapPathErr(err)
+	}
+	return o, nil
+}
@@ -0,0 +1,123 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const questionColumns = "question_id, auction_id, asker_id, question, answer, is_public, created_at, answered_at"
+
+type QuestionRepo struct {
+	*postgres.Postgres
+}
+
+func NewQuestionRepo(pg *postgres.Postgres) *QuestionRepo {
+	return &QuestionRepo{pg}
+}
+
+func scanQuestion(row pgx.Row) (e.Question, error) {
+	var q e.Question
+	err := row.Scan(&q.QuestionID, &q.AuctionID, &q.AskerID, &q.Question, &q.Answer, &q.Public, &q.CreatedAt, &q.AnsweredAt)
+	return q, err
+}
+
+func (r *QuestionRepo) Create(ctx context.Context, in rd.CreateQuestionInput) (e.Question, error) {
+	sql, args, _ := r.Builder.
+		Insert("auction_questions").
+		Columns("auction_id", "asker_id", "question").
+		Values(in.AuctionID, in.AskerID, in.Question).
+		Suffix("RETURNING " + questionColumns).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	question, err := scanQuestion(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return e.Question{}, errutils.WrapPathErr(err)
+	}
+	return question, nil
+}
+
+func (r *QuestionRepo) GetByID(ctx context.Context, questionID int64) (e.Question, error) {
+	sql, args, _ := r.Builder.
+		Select(questionColumns).
+		From("auction_questions").
+		Where("question_id = ?", questionID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	question, err := scanQuestion(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Question{}, re.ErrNotFound
+		}
+		return e.Question{}, errutils.WrapPathErr(err)
+	}
+	return question, nil
+}
+
+// Answer fails with ErrNotFound when the question does not exist or has
+// already been answered.
+func (r *QuestionRepo) Answer(ctx context.Context, in rd.AnswerQuestionInput) (e.Question, error) {
+	sql, args, _ := r.Builder.
+		Update("auction_questions").
+		Set("answer", in.Answer).
+		Set("is_public", in.Public).
+		Set("answered_at", squirrel.Expr("NOW()")).
+		Where("question_id = ?", in.QuestionID).
+		Where("answered_at IS NULL").
+		Suffix("RETURNING " + questionColumns).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	question, err := scanQuestion(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Question{}, re.ErrNotFound
+		}
+		return e.Question{}, errutils.WrapPathErr(err)
+	}
+	return question, nil
+}
+
+// ListByAuction returns every question to the seller. Anyone else gets the
+// publicly answered questions plus their own.
+func (r *QuestionRepo) ListByAuction(ctx context.Context, auctionID, viewerID string, seller bool) ([]e.Question, error) {
+	q := r.Builder.
+		Select(questionColumns).
+		From("auction_questions").
+		Where("auction_id = ?", auctionID).
+		OrderBy("created_at", "question_id")
+	if !seller {
+		q = q.Where(squirrel.Or{
+			squirrel.Expr("answered_at IS NOT NULL AND is_public"),
+			squirrel.Eq{"asker_id": viewerID},
+		})
+	}
+	sql, args, _ := q.ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var questions []e.Question
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
@@ -0,0 +1,140 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const ratingColumns = "auction_id, rater_id, ratee_id, role, score, comment, created_at"
+
+type RatingRepo struct {
+	*postgres.Postgres
+}
+
+func NewRatingRepo(pg *postgres.Postgres) *RatingRepo {
+	return &RatingRepo{pg}
+}
+
+func scanRating(row pgx.Row) (e.Rating, error) {
+	var r e.Rating
+	err := row.Scan(&r.AuctionID, &r.RaterID, &r.RateeID, &r.Role, &r.Score, &r.Comment, &r.CreatedAt)
+	return r, err
+}
+
+// Create fails with ErrAlreadyExists when the rater has already rated the
+// ratee for this auction.
+func (r *RatingRepo) Create(ctx context.Context, in rd.CreateRatingInput) (e.Rating, error) {
+	sql, args, _ := r.Builder.
+		Insert("ratings").
+		Columns("auction_id", "rater_id", "ratee_id", "role", "score", "comment").
+		Values(in.AuctionID, in.RaterID, in.RateeID, in.Role, in.Score, in.Comment).
+		Suffix("ON CONFLICT DO NOTHING RETURNING " + ratingColumns).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rating, err := scanRating(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Rating{}, re.ErrAlreadyExists
+		}
+		return e.Rating{}, errutils.WrapPathErr(err)
+	}
+	return rating, nil
+}
+
+func (r *RatingRepo) ListByRatee(ctx context.Context, rateeID string, limit int) ([]e.Rating, error) {
+	sql, args, _ := r.Builder.
+		Select(ratingColumns).
+		From("ratings").
+		Where("ratee_id = ?", rateeID).
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var ratings []e.Rating
+	for rows.Next() {
+		rating, err := scanRating(rows)
+		if err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		ratings = append(ratings, rating)
+	}
+	return ratings, nil
+}
+
+func (r *RatingRepo) Reputation(ctx context.Context, userID string) (e.Reputation, error) {
+	sql, args, _ := r.Builder.
+		Select("role", "COUNT(*)", "AVG(score)::FLOAT8").
+		From("ratings").
+		Where("ratee_id = ?", userID).
+		GroupBy("role").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return e.Reputation{}, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var rep e.Reputation
+	for rows.Next() {
+		var role e.RatingRole
+		var s e.RatingSummary
+		if err := rows.Scan(&role, &s.Count, &s.Average); err != nil {
+			return e.Reputation{}, errutils.WrapPathErr(err)
+		}
+		switch role {
+		case e.RatingRoleSeller:
+			rep.AsSeller = s
+		case e.RatingRoleBuyer:
+			rep.AsBuyer = s
+		}
+	}
+	return rep, nil
+}
+
+// SellerSummaries returns the ratings each seller received as a seller,
+// leaving out sellers nobody has rated.
+func (r *RatingRepo) SellerSummaries(ctx context.Context, sellerIDs []string) (map[string]e.RatingSummary, error) {
+	sql, args, _ := r.Builder.
+		Select("ratee_id", "COUNT(*)", "AVG(score)::FLOAT8").
+		From("ratings").
+		Where(squirrel.Eq{"ratee_id": sellerIDs, "role": e.RatingRoleSeller}).
+		GroupBy("ratee_id").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	summaries := make(map[string]e.RatingSummary, len(sellerIDs))
+	for rows.Next() {
+		var sellerID string
+		var s e.RatingSummary
+		if err := rows.Scan(&sellerID, &s.Count, &s.Average); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		summaries[sellerID] = s
+	}
+	return summaries, nil
+}
@@ -0,0 +1,149 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const registrationColumns = "auction_id, bidder_id, status, deposit, reviewed_by, reason, created_at, updated_at"
+
+type RegistrationRepo struct {
+	*postgres.Postgres
+}
+
+func NewRegistrationRepo(pg *postgres.Postgres) *RegistrationRepo {
+	return &RegistrationRepo{pg}
+}
+
+func scanRegistration(row pgx.Row) (e.Registration, error) {
+	var reg e.Registration
+	err := row.Scan(&reg.AuctionID, &reg.BidderID, &reg.Status, &reg.Deposit, &reg.ReviewedBy, &reg.Reason,
+		&reg.CreatedAt, &reg.UpdatedAt)
+	reg.Deposit.Currency = money.DefaultCurrency
+	return reg, err
+}
+
+// Create files a pending registration. A bidder who was denied may ask again;
+// any other existing registration fails with ErrAlreadyExists.
+func (r *RegistrationRepo) Create(ctx context.Context, in rd.CreateRegistrationInput) (e.Registration, error) {
+	sql, args, _ := r.Builder.
+		Insert("auction_registrations").
+		Columns("auction_id", "bidder_id", "status", "deposit").
+		Values(in.AuctionID, in.BidderID, e.RegistrationPending, in.Deposit).
+		Suffix("ON CONFLICT (auction_id, bidder_id) DO UPDATE SET status = EXCLUDED.status, deposit = EXCLUDED.deposit, "+
+			"reviewed_by = '', reason = '', created_at = NOW(), updated_at = NOW() "+
+			"WHERE auction_registrations.status = ? RETURNING "+registrationColumns, e.RegistrationDenied).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	reg, err := scanRegistration(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Registration{}, re.ErrAlreadyExists
+		}
+		return e.Registration{}, errutils.WrapPathErr(err)
+	}
+	return reg, nil
+}
+
+func (r *RegistrationRepo) Get(ctx context.Context, auctionID, bidderID string) (e.Registration, error) {
+	sql, args, _ := r.Builder.
+		Select(registrationColumns).
+		From("auction_registrations").
+		Where("auction_id = ? AND bidder_id = ?", auctionID, bidderID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	reg, err := scanRegistration(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Registration{}, re.ErrNotFound
+		}
+		return e.Registration{}, errutils.WrapPathErr(err)
+	}
+	return reg, nil
+}
+
+// ListByAuction returns the auction's registrations, all of them when status
+// is empty.
+func (r *RegistrationRepo) ListByAuction(ctx context.Context, auctionID string, status e.RegistrationStatus) ([]e.Registration, error) {
+	where := squirrel.Eq{"auction_id": auctionID}
+	if status != "" {
+		where["status"] = status
+	}
+
+	sql, args, _ := r.Builder.
+		Select(registrationColumns).
+		From("auction_registrations").
+		Where(where).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var regs []e.Registration
+	for rows.Next() {
+		reg, err := scanRegistration(rows)
+		if err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		regs = append(regs, reg)
+	}
+	return regs, nil
+}
+
+// Review decides a pending registration. It fails with ErrNotFound when there
+// is no pending registration to decide.
+func (r *RegistrationRepo) Review(ctx context.Context, in rd.ReviewRegistrationInput) (e.Registration, error) {
+	sql, args, _ := r.Builder.
+		Update("auction_registrations").
+		Set("status", in.Status).
+		Set("reviewed_by", in.ReviewedBy).
+		Set("reason", in.Reason).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where("auction_id = ? AND bidder_id = ? AND status = ?", in.AuctionID, in.BidderID, e.RegistrationPending).
+		Suffix("RETURNING " + registrationColumns).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	reg, err := scanRegistration(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Registration{}, re.ErrNotFound
+		}
+		return e.Registration{}, errutils.WrapPathErr(err)
+	}
+	return reg, nil
+}
+
+func (r *RegistrationRepo) IsApproved(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	sql, args, _ := r.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("auction_registrations").
+		Where("auction_id = ? AND bidder_id = ? AND status = ?", auctionID, bidderID, e.RegistrationApproved).
+		Suffix(")").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var approved bool
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&approved); err != nil {
+		return false, errutils.WrapPathErr(err)
+	}
+	return approved, nil
+}
@@ -0,0 +1,113 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const templateColumns = "template_id, seller_id, name, settings, created_at, updated_at"
+
+type TemplateRepo struct {
+	*postgres.Postgres
+}
+
+func NewTemplateRepo(pg *postgres.Postgres) *TemplateRepo {
+	return &TemplateRepo{pg}
+}
+
+func scanTemplate(row pgx.Row) (e.AuctionTemplate, error) {
+	var t e.AuctionTemplate
+	err := row.Scan(&t.TemplateID, &t.SellerID, &t.Name, &t.Settings, &t.CreatedAt, &t.UpdatedAt)
+	t.Settings.SetCurrency(t.Settings.Currency)
+	return t, err
+}
+
+// Save creates the template or, when the seller already has one under the
+// same name, replaces its settings.
+func (r *TemplateRepo) Save(ctx context.Context, in rd.SaveTemplateInput) (e.AuctionTemplate, error) {
+	sql, args, _ := r.Builder.
+		Insert("auction_templates").
+		Columns("seller_id", "name", "settings").
+		Values(in.SellerID, in.Name, in.Settings).
+		Suffix("ON CONFLICT (seller_id, name) DO UPDATE SET settings = EXCLUDED.settings, updated_at = NOW() " +
+			"RETURNING " + templateColumns).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	template, err := scanTemplate(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return e.AuctionTemplate{}, errutils.WrapPathErr(err)
+	}
+	return template, nil
+}
+
+func (r *TemplateRepo) GetByID(ctx context.Context, templateID int64) (e.AuctionTemplate, error) {
+	sql, args, _ := r.Builder.
+		Select(templateColumns).
+		From("auction_templates").
+		Where("template_id = ?", templateID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	template, err := scanTemplate(conn.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.AuctionTemplate{}, re.ErrNotFound
+		}
+		return e.AuctionTemplate{}, errutils.WrapPathErr(err)
+	}
+	return template, nil
+}
+
+func (r *TemplateRepo) ListBySeller(ctx context.Context, sellerID string) ([]e.AuctionTemplate, error) {
+	sql, args, _ := r.Builder.
+		Select(templateColumns).
+		From("auction_templates").
+		Where("seller_id = ?", sellerID).
+		OrderBy("name").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var templates []e.AuctionTemplate
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// Delete fails with ErrNotFound unless the template belongs to sellerID.
+func (r *TemplateRepo) Delete(ctx context.Context, templateID int64, sellerID string) error {
+	sql, args, _ := r.Builder.
+		Delete("auction_templates").
+		Where(squirrel.Eq{"template_id": templateID, "seller_id": sellerID}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type WalletRepo struct {
+	*postgres.Postgres
+}
+
+func NewWalletRepo(pg *postgres.Postgres) *WalletRepo {
+	return &WalletRepo{pg}
+}
+
+func (r *WalletRepo) GetOrCreateAccount(ctx context.Context, ownerID string, accountType e.AccountType) (e.Account, error) {
+	sql, args, _ := r.Builder.
+		Insert("accounts").
+		Columns("owner_id", "type").
+		Values(ownerID, accountType).
+		Suffix("ON CONFLICT (owner_id, type) DO UPDATE SET owner_id = EXCLUDED.owner_id " +
+			"RETURNING account_id, owner_id, type, balance, created_at").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var a e.Account
+	err := conn.QueryRow(ctx, sql, args...).Scan(&a.AccountID, &a.OwnerID, &a.Type, &a.Balance, &a.CreatedAt)
+	if err != nil {
+		return e.Account{}, errutils.WrapPathErr(err)
+	}
+	return a, nil
+}
+
+func (r *WalletRepo) ListAccounts(ctx context.Context, ownerID string) ([]e.Account, error) {
+	sql, args, _ := r.Builder.
+		Select("account_id", "owner_id", "type", "balance", "created_at").
+		From("accounts").
+		Where("owner_id = ?", ownerID).
+		OrderBy("account_id").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var accounts []e.Account
+	for rows.Next() {
+		var a e.Account
+		if err := rows.Scan(&a.AccountID, &a.OwnerID, &a.Type, &a.Balance, &a.CreatedAt); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, nil
+}
+
+// FirstAccountAt returns when the owner's first account was opened.
+func (r *WalletRepo) FirstAccountAt(ctx context.Context, ownerID string) (time.Time, error) {
+	sql, args, _ := r.Builder.
+		Select("MIN(created_at)").
+		From("accounts").
+		Where("owner_id = ?", ownerID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	var openedAt *time.Time
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&openedAt); err != nil {
+		return time.Time{}, errutils.WrapPathErr(err)
+	}
+	if openedAt == nil {
+		return time.Time{}, re.ErrNotFound
+	}
+	return *openedAt, nil
+}
+
+// Transfer writes both ledger entries and moves the balances. It must run
+// inside a transaction; a balance going below zero fails with
+// ErrInsufficientFunds.
+func (r *WalletRepo) Transfer(ctx context.Context, in rd.TransferInput) (int64, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var transferID int64
+	if err := conn.QueryRow(ctx, "SELECT nextval('ledger_transfer_seq')").Scan(&transferID); err != nil {
+		return 0, errutils.WrapPathErr(err)
+	}
+
+	sql, args, _ := r.Builder.
+		Insert("ledger_entries").
+		Columns("transfer_id", "account_id", "amount", "kind", "reference").
+		Values(transferID, in.FromAccountID, in.Amount.Neg(), in.Kind, in.Reference).
+		Values(transferID, in.ToAccountID, in.Amount, in.Kind, in.Reference).
+		ToSql()
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		return 0, errutils.WrapPathErr(err)
+	}
+
+	// Lock rows in a fixed order so concurrent transfers cannot deadlock.
+	updates := []struct {
+		accountID int64
+		delta     money.Money
+	}{{in.FromAccountID, in.Amount.Neg()}, {in.ToAccountID, in.Amount}}
+	if in.ToAccountID < in.FromAccountID {
+		updates[0], updates[1] = updates[1], updates[0]
+	}
+
+	for _, u := range updates {
+		sql, args, _ := r.Builder.
+			Update("accounts").
+			Set("balance", squirrel.Expr("balance + ?", u.delta)).
+			Where("account_id = ?", u.accountID).
+			ToSql()
+		if _, err := conn.Exec(ctx, sql, args...); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+				return 0, re.ErrInsufficientFunds
+			}
+			return 0, errutils.WrapPathErr(err)
+		}
+	}
+
+	return transferID, nil
+}
+
+func (r *WalletRepo) HeldAmount(ctx context.Context, ownerID, reference string) (money.Money, error) {
+	sql, args, _ := r.Builder.
+		Select("COALESCE(SUM(le.amount), 0)").
+		From("ledger_entries le").
+		Join("accounts a ON a.account_id = le.account_id").
+		Where(squirrel.Eq{"a.owner_id": ownerID, "a.type": e.AccountHeld, "le.reference": reference}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var held money.Money
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&held); err != nil {
+		return money.Money{}, errutils.WrapPathErr(err)
+	}
+	return held, nil
+}
+
+// ChargedAmount is how much of the owner's held funds for reference has been
+// charged, i.e. the bid deposit already collected at settlement.
+func (r *WalletRepo) ChargedAmount(ctx context.Context, ownerID, reference string) (money.Money, error) {
+	sql, args, _ := r.Builder.
+		Select("COALESCE(-SUM(le.amount), 0)").
+		From("ledger_entries le").
+		Join("accounts a ON a.account_id = le.account_id").
+		Where(squirrel.Eq{
+			"a.owner_id":   ownerID,
+			"a.type":       e.AccountHeld,
+			"le.kind":      e.LedgerCharge,
+			"le.reference": reference,
+		}).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var charged money.Money
+	if err := conn.QueryRow(ctx, sql, args...).Scan(&charged); err != nil {
+		return money.Money{}, errutils.WrapPathErr(err)
+	}
+	return charged, nil
+}
+
+func (r *WalletRepo) ListHolds(ctx context.Context, reference string) ([]e.Hold, error) {
+	sql, args, _ := r.Builder.
+		Select("a.owner_id", "SUM(le.amount)").
+		From("ledger_entries le").
+		Join("accounts a ON a.account_id = le.account_id").
+		Where(squirrel.Eq{"a.type": e.AccountHeld, "le.reference": reference}).
+		GroupBy("a.owner_id").
+		Having("SUM(le.amount) > 0").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var holds []e.Hold
+	for rows.Next() {
+		var h e.Hold
+		if err := rows.Scan(&h.OwnerID, &h.Amount); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		holds = append(holds, h)
+	}
+	return holds, nil
+}
+
+func (r *WalletRepo) ListEntries(ctx context.Context, ownerID string, limit, offset int) ([]e.LedgerEntry, int64, error) {
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	countSQL, countArgs, _ := r.Builder.
+		Select("COUNT(*)").
+		From("ledger_entries le").
+		Join("accounts a ON a.account_id = le.account_id").
+		Where("a.owner_id = ?", ownerID).
+		ToSql()
+
+	var total int64
+	if err := conn.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+
+	sql, args, _ := r.Builder.
+		Select("le.entry_id", "le.transfer_id", "le.account_id", "a.type", "le.amount",
+			"le.kind", "le.reference", "le.created_at").
+		From("ledger_entries le").
+		Join("accounts a ON a.account_id = le.account_id").
+		Where("a.owner_id = ?", ownerID).
+		OrderBy("le.created_at DESC", "le.entry_id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var entries []e.LedgerEntry
+	for rows.Next() {
+		var le e.LedgerEntry
+		if err := rows.Scan(
+			&le.EntryID, &le.TransferID, &le.AccountID, &le.AccountType, &le.Amount,
+			&le.Kind, &le.Reference, &le.CreatedAt,
+		); err != nil {
+			return nil, 0, errutils.WrapPathErr(err)
+		}
+		entries = append(entries, le)
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,109 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type WatchlistRepo struct {
+	*postgres.Postgres
+}
+
+func NewWatchlistRepo(pg *postgres.Postgres) *WatchlistRepo {
+	return &WatchlistRepo{pg}
+}
+
+func (r *WatchlistRepo) Add(ctx context.Context, userID, auctionID string) error {
+	sql, args, _ := r.Builder.
+		Insert("watchlist").
+		Columns("user_id", "auction_id").
+		Values(userID, auctionID).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	if _, err := conn.Exec(ctx, sql, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return re.ErrNotFound
+		}
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func (r *WatchlistRepo) Remove(ctx context.Context, userID, auctionID string) error {
+	sql, args, _ := r.Builder.
+		Delete("watchlist").
+		Where("user_id = ? AND auction_id = ?", userID, auctionID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WatchlistRepo) ListByUser(ctx context.Context, userID string) ([]e.WatchlistItem, error) {
+	sql, args, _ := r.Builder.
+		Select("user_id", "auction_id", "created_at").
+		From("watchlist").
+		Where("user_id = ?", userID).
+		OrderBy("created_at DESC").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var items []e.WatchlistItem
+	for rows.Next() {
+		var w e.WatchlistItem
+		if err := rows.Scan(&w.UserID, &w.AuctionID, &w.CreatedAt); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		items = append(items, w)
+	}
+	return items, nil
+}
+
+func (r *WatchlistRepo) ListWatchers(ctx context.Context, auctionID string) ([]string, error) {
+	sql, args, _ := r.Builder.
+		Select("user_id").
+		From("watchlist").
+		Where("auction_id = ?", auctionID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		users = append(users, userID)
+	}
+	return users, nil
+}
@@ -0,0 +1,195 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/postgres"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type WebhookRepo struct {
+	*postgres.Postgres
+}
+
+func NewWebhookRepo(pg *postgres.Postgres) *WebhookRepo {
+	return &WebhookRepo{pg}
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, in rd.CreateWebhookInput) (e.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Insert("webhooks").
+		Columns("owner_id", "url", "secret", "event_types").
+		Values(in.OwnerID, in.URL, in.Secret, in.EventTypes).
+		Suffix("RETURNING webhook_id, owner_id, url, secret, event_types, is_active, created_at").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var w e.Webhook
+	err := conn.QueryRow(ctx, sql, args...).Scan(
+		&w.WebhookID, &w.OwnerID, &w.URL, &w.Secret, &w.EventTypes, &w.IsActive, &w.CreatedAt,
+	)
+	if err != nil {
+		return e.Webhook{}, errutils.WrapPathErr(err)
+	}
+	return w, nil
+}
+
+func (r *WebhookRepo) GetByID(ctx context.Context, webhookID int64) (e.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Select("webhook_id", "owner_id", "url", "secret", "event_types", "is_active", "created_at").
+		From("webhooks").
+		Where("webhook_id = ?", webhookID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var w e.Webhook
+	err := conn.QueryRow(ctx, sql, args...).Scan(
+		&w.WebhookID, &w.OwnerID, &w.URL, &w.Secret, &w.EventTypes, &w.IsActive, &w.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return e.Webhook{}, re.ErrNotFound
+		}
+		return e.Webhook{}, errutils.WrapPathErr(err)
+	}
+	return w, nil
+}
+
+func (r *WebhookRepo) ListByOwner(ctx context.Context, ownerID string) ([]e.Webhook, error) {
+	return r.list(ctx, squirrel.Eq{"owner_id": ownerID})
+}
+
+func (r *WebhookRepo) ListActiveByEvent(ctx context.Context, eventType e.WebhookEventType) ([]e.Webhook, error) {
+	return r.list(ctx, squirrel.And{
+		squirrel.Eq{"is_active": true},
+		squirrel.Expr("? = ANY(event_types)", string(eventType)),
+	})
+}
+
+func (r *WebhookRepo) list(ctx context.Context, where squirrel.Sqlizer) ([]e.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Select("webhook_id", "owner_id", "url", "secret", "event_types", "is_active", "created_at").
+		From("webhooks").
+		Where(where).
+		OrderBy("webhook_id").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var webhooks []e.Webhook
+	for rows.Next() {
+		var w e.Webhook
+		if err := rows.Scan(
+			&w.WebhookID, &w.OwnerID, &w.URL, &w.Secret, &w.EventTypes, &w.IsActive, &w.CreatedAt,
+		); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) Delete(ctx context.Context, webhookID int64, ownerID string) error {
+	sql, args, _ := r.Builder.
+		Delete("webhooks").
+		Where("webhook_id = ? AND owner_id = ?", webhookID, ownerID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) CreateDelivery(ctx context.Context, in rd.CreateWebhookDeliveryInput) (e.WebhookDelivery, error) {
+	sql, args, _ := r.Builder.
+		Insert("webhook_deliveries").
+		Columns("webhook_id", "event_type", "payload", "status").
+		Values(in.WebhookID, in.EventType, in.Payload, e.WebhookDeliveryPending).
+		Suffix("RETURNING delivery_id, webhook_id, event_type, payload, status, attempts, response_code, error, created_at").
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+
+	var d e.WebhookDelivery
+	err := conn.QueryRow(ctx, sql, args...).Scan(
+		&d.DeliveryID, &d.WebhookID, &d.EventType, &d.Payload, &d.Status,
+		&d.Attempts, &d.ResponseCode, &d.Error, &d.CreatedAt,
+	)
+	if err != nil {
+		return e.WebhookDelivery{}, errutils.WrapPathErr(err)
+	}
+	return d, nil
+}
+
+func (r *WebhookRepo) FinishDelivery(ctx context.Context, in rd.FinishWebhookDeliveryInput) error {
+	sql, args, _ := r.Builder.
+		Update("webhook_deliveries").
+		Set("status", in.Status).
+		Set("attempts", in.Attempts).
+		Set("response_code", in.ResponseCode).
+		Set("error", in.Error).
+		Set("delivered_at", squirrel.Expr("NOW()")).
+		Where("delivery_id = ?", in.DeliveryID).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	cmdTag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return re.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]e.WebhookDelivery, error) {
+	sql, args, _ := r.Builder.
+		Select("delivery_id", "webhook_id", "event_type", "payload", "status",
+			"attempts", "response_code", "error", "created_at", "delivered_at").
+		From("webhook_deliveries").
+		Where("webhook_id = ?", webhookID).
+		OrderBy("created_at DESC", "delivery_id DESC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	conn := r.CtxGetter.DefaultTrOrDB(ctx, r.Pool)
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	defer rows.Close()
+
+	var deliveries []e.WebhookDelivery
+	for rows.Next() {
+		var d e.WebhookDelivery
+		if err := rows.Scan(
+			&d.DeliveryID, &d.WebhookID, &d.EventType, &d.Payload, &d.Status,
+			&d.Attempts, &d.ResponseCode, &d.Error, &d.CreatedAt, &d.DeliveredAt,
+		); err != nil {
+			return nil, errutils.WrapPathErr(err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
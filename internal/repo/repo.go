@@ -2,8 +2,10 @@ package repo
 
 import (
 	"auction-platform/internal/repo/pgdb"
+	"auction-platform/pkg/money"
 	"auction-platform/pkg/postgres"
 	"context"
+	"time"
 
 	e "auction-platform/internal/entity"
 	rd "auction-platform/internal/repo/dto"
@@ -12,28 +14,221 @@ import (
 type Auctions interface {
 	Create(ctx context.Context, in rd.CreateAuctionInput) (e.Auction, error)
 	GetByID(ctx context.Context, auctionID string) (e.Auction, error)
-	ListActive(ctx context.Context, limit, offset int) ([]e.Auction, int64, error)
-	UpdateCurrentBid(ctx context.Context, auctionID string, amount float64) error
-	FinishAuction(ctx context.Context, auctionID string, winnerID string, finalPrice float64) error
+	ListActive(ctx context.Context, in rd.ListActiveAuctionsInput) ([]e.Auction, int64, error)
+	UpdateCurrentBid(ctx context.Context, auctionID string, amount money.Money) error
+	FinishAuction(ctx context.Context, auctionID string, winnerID string, finalPrice money.Money) error
 	GetExpired(ctx context.Context) ([]e.Auction, error)
+	ListBySeller(ctx context.Context, sellerID string, limit, offset int) ([]e.SellerAuction, int64, error)
+	GetEndingWithin(ctx context.Context, window time.Duration) ([]e.Auction, error)
+	ForceEnd(ctx context.Context, auctionID string) error
+	Cancel(ctx context.Context, auctionID string) error
+	UpdateVisibility(ctx context.Context, auctionID string, visibility e.AuctionVisibility) error
+	Delete(ctx context.Context, auctionID string) error
+	ExistingIDs(ctx context.Context, auctionIDs []string) ([]string, error)
 }
 
 type Bids interface {
 	Create(ctx context.Context, in rd.CreateBidInput) (e.Bid, error)
 	UpdateStatus(ctx context.Context, bidID string, status e.BidStatus) error
-	GetHighestByAuction(ctx context.Context, auctionID string) (e.Bid, error)
 	ListByAuction(ctx context.Context, auctionID string, limit int) ([]e.Bid, error)
 	CountByAuction(ctx context.Context, auctionID string) (int, error)
+	ListAuctionsByBidder(ctx context.Context, bidderID string, limit, offset int) ([]e.BidderAuction, int64, error)
+	ListBidderIDs(ctx context.Context, auctionID string) ([]string, error)
+	GetByID(ctx context.Context, bidID string) (e.Bid, error)
+	ListActive(ctx context.Context, auctionID string, lowestFirst bool) ([]e.Bid, error)
+	GetRunnerUp(ctx context.Context, auctionID string) (e.Bid, error)
+	CountLeading(ctx context.Context, bidderID, excludeAuctionID string) (int, error)
+}
+
+type Invites interface {
+	AddMany(ctx context.Context, auctionID string, bidderIDs []string) error
+	RemoveMany(ctx context.Context, auctionID string, bidderIDs []string) error
+	AddGroups(ctx context.Context, auctionID string, groupIDs []int64) error
+	RemoveGroups(ctx context.Context, auctionID string, groupIDs []int64) error
+	ListByAuction(ctx context.Context, auctionID string) ([]string, error)
+	IsInvited(ctx context.Context, auctionID, bidderID string) (bool, error)
+}
+
+type BidderGroups interface {
+	Create(ctx context.Context, sellerID, name string) (e.BidderGroup, error)
+	GetByID(ctx context.Context, groupID int64) (e.BidderGroup, error)
+	ListBySeller(ctx context.Context, sellerID string) ([]e.BidderGroup, error)
+	ListByAuction(ctx context.Context, auctionID string) ([]e.BidderGroup, error)
+	Delete(ctx context.Context, groupID int64) error
+	AddMembers(ctx context.Context, groupID int64, bidderIDs []string) error
+	RemoveMembers(ctx context.Context, groupID int64, bidderIDs []string) error
+}
+
+type Registrations interface {
+	Create(ctx context.Context, in rd.CreateRegistrationInput) (e.Registration, error)
+	Get(ctx context.Context, auctionID, bidderID string) (e.Registration, error)
+	ListByAuction(ctx context.Context, auctionID string, status e.RegistrationStatus) ([]e.Registration, error)
+	Review(ctx context.Context, in rd.ReviewRegistrationInput) (e.Registration, error)
+	IsApproved(ctx context.Context, auctionID, bidderID string) (bool, error)
+}
+
+type Fraud interface {
+	RecordDevice(ctx context.Context, userID, ip, deviceID string) error
+	SharesDevice(ctx context.Context, userID, ip, deviceID string) (bool, error)
+	RecordRetraction(ctx context.Context, bidID, auctionID, bidderID string) error
+	BidderSellerStats(ctx context.Context, bidderID, sellerID string) (e.BidderSellerStats, error)
+	CreateFlag(ctx context.Context, in rd.CreateFraudFlagInput) (e.FraudFlag, error)
+	GetFlag(ctx context.Context, flagID int64) (e.FraudFlag, error)
+	ListFlags(ctx context.Context, status e.FraudFlagStatus, limit, offset int) ([]e.FraudFlag, int64, error)
+	ReviewFlag(ctx context.Context, in rd.ReviewFraudFlagInput) (e.FraudFlag, error)
+}
+
+type Watchlist interface {
+	Add(ctx context.Context, userID, auctionID string) error
+	Remove(ctx context.Context, userID, auctionID string) error
+	ListByUser(ctx context.Context, userID string) ([]e.WatchlistItem, error)
+	ListWatchers(ctx context.Context, auctionID string) ([]string, error)
+}
+
+type Notifications interface {
+	Create(ctx context.Context, in rd.CreateNotificationInput) (e.Notification, error)
+	ListByUser(ctx context.Context, userID string, unreadOnly bool, limit, offset int) ([]e.Notification, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID string, notificationIDs []int64) (int64, error)
+}
+
+type Webhooks interface {
+	Create(ctx context.Context, in rd.CreateWebhookInput) (e.Webhook, error)
+	GetByID(ctx context.Context, webhookID int64) (e.Webhook, error)
+	ListByOwner(ctx context.Context, ownerID string) ([]e.Webhook, error)
+	ListActiveByEvent(ctx context.Context, eventType e.WebhookEventType) ([]e.Webhook, error)
+	Delete(ctx context.Context, webhookID int64, ownerID string) error
+	CreateDelivery(ctx context.Context, in rd.CreateWebhookDeliveryInput) (e.WebhookDelivery, error)
+	FinishDelivery(ctx context.Context, in rd.FinishWebhookDeliveryInput) error
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]e.WebhookDelivery, error)
+}
+
+type Bans interface {
+	Create(ctx context.Context, in rd.CreateBanInput) (e.Ban, error)
+	Delete(ctx context.Context, userID string) error
+	IsBanned(ctx context.Context, userID string) (bool, error)
+	List(ctx context.Context) ([]e.Ban, error)
+	Block(ctx context.Context, in rd.BlockBidderInput) (e.BlockedBidder, error)
+	Unblock(ctx context.Context, sellerID, bidderID string) error
+	IsBlocked(ctx context.Context, sellerID, bidderID string) (bool, error)
+	ListBlocked(ctx context.Context, sellerID string) ([]e.BlockedBidder, error)
+}
+
+type APIKeys interface {
+	Create(ctx context.Context, in rd.CreateAPIKeyInput) (e.APIKey, error)
+	GetActiveByHash(ctx context.Context, keyHash string) (e.APIKey, error)
+	ListByOwner(ctx context.Context, ownerID string) ([]e.APIKey, error)
+	Rotate(ctx context.Context, in rd.RotateAPIKeyInput) (e.APIKey, error)
+	Revoke(ctx context.Context, keyID int64, ownerID string) error
+	SetQuota(ctx context.Context, keyID int64, rps float64, burst int) (e.APIKey, error)
+}
+
+type Wallets interface {
+	GetOrCreateAccount(ctx context.Context, ownerID string, accountType e.AccountType) (e.Account, error)
+	ListAccounts(ctx context.Context, ownerID string) ([]e.Account, error)
+	Transfer(ctx context.Context, in rd.TransferInput) (int64, error)
+	HeldAmount(ctx context.Context, ownerID, reference string) (money.Money, error)
+	ChargedAmount(ctx context.Context, ownerID, reference string) (money.Money, error)
+	ListHolds(ctx context.Context, reference string) ([]e.Hold, error)
+	ListEntries(ctx context.Context, ownerID string, limit, offset int) ([]e.LedgerEntry, int64, error)
+	FirstAccountAt(ctx context.Context, ownerID string) (time.Time, error)
+}
+
+type Orders interface {
+	Create(ctx context.Context, in rd.CreateOrderInput) (e.Order, error)
+	GetByID(ctx context.Context, orderID int64) (e.Order, error)
+	List(ctx context.Context, in rd.ListOrdersInput) ([]e.Order, int64, error)
+	ListOverdue(ctx context.Context) ([]e.Order, error)
+	Transition(ctx context.Context, in rd.TransitionOrderInput) (e.Order, error)
+	ListTradeParties(ctx context.Context, auctionID string) ([]e.TradeParties, error)
+}
+
+type Ratings interface {
+	Create(ctx context.Context, in rd.CreateRatingInput) (e.Rating, error)
+	ListByRatee(ctx context.Context, rateeID string, limit int) ([]e.Rating, error)
+	Reputation(ctx context.Context, userID string) (e.Reputation, error)
+	SellerSummaries(ctx context.Context, sellerIDs []string) (map[string]e.RatingSummary, error)
+}
+
+type Questions interface {
+	Create(ctx context.Context, in rd.CreateQuestionInput) (e.Question, error)
+	GetByID(ctx context.Context, questionID int64) (e.Question, error)
+	Answer(ctx context.Context, in rd.AnswerQuestionInput) (e.Question, error)
+	ListByAuction(ctx context.Context, auctionID, viewerID string, seller bool) ([]e.Question, error)
+}
+
+type Media interface {
+	Create(ctx context.Context, in rd.CreateMediaInput) (e.Media, error)
+	GetByID(ctx context.Context, mediaID int64) (e.Media, error)
+	CountByAuction(ctx context.Context, auctionID string) (int, error)
+	ListByAuction(ctx context.Context, auctionID string) ([]e.Media, error)
+	ListByAuctions(ctx context.Context, auctionIDs []string) (map[string][]e.Media, error)
+	Delete(ctx context.Context, mediaID int64) error
+	Reorder(ctx context.Context, auctionID string, mediaIDs []int64) error
+}
+
+type Templates interface {
+	Save(ctx context.Context, in rd.SaveTemplateInput) (e.AuctionTemplate, error)
+	GetByID(ctx context.Context, templateID int64) (e.AuctionTemplate, error)
+	ListBySeller(ctx context.Context, sellerID string) ([]e.AuctionTemplate, error)
+	Delete(ctx context.Context, templateID int64, sellerID string) error
+}
+
+type Fees interface {
+	CreateLines(ctx context.Context, lines []e.FeeLine) error
+	HasListingFee(ctx context.Context, auctionID string) (bool, error)
+	ListByOrder(ctx context.Context, orderID int64) ([]e.FeeLine, error)
+	ListStatementLines(ctx context.Context, sellerID string, from, to time.Time) ([]e.StatementLine, error)
+	SummarizeBySeller(ctx context.Context, from, to time.Time) ([]e.SellerStatement, error)
+}
+
+type FXRates interface {
+	List(ctx context.Context) ([]e.FXRate, error)
+	Upsert(ctx context.Context, rates []e.FXRate) error
 }
 
 type Repositories struct {
 	Auctions
 	Bids
+	Invites
+	BidderGroups
+	Registrations
+	Fraud
+	Watchlist
+	Notifications
+	Webhooks
+	Bans
+	APIKeys
+	Wallets
+	Orders
+	Ratings
+	Questions
+	Media
+	Templates
+	Fees
+	FXRates
 }
 
 func NewRepositories(pg *postgres.Postgres) *Repositories {
 	return &Repositories{
-		Auctions: pgdb.NewAuctionRepo(pg),
-		Bids:     pgdb.NewBidRepo(pg),
+		Auctions:      pgdb.NewAuctionRepo(pg),
+		Bids:          pgdb.NewBidRepo(pg),
+		Invites:       pgdb.NewInviteRepo(pg),
+		BidderGroups:  pgdb.NewGroupRepo(pg),
+		Registrations: pgdb.NewRegistrationRepo(pg),
+		Fraud:         pgdb.NewFraudRepo(pg),
+		Watchlist:     pgdb.NewWatchlistRepo(pg),
+		Notifications: pgdb.NewNotificationRepo(pg),
+		Webhooks:      pgdb.NewWebhookRepo(pg),
+		Bans:          pgdb.NewBanRepo(pg),
+		APIKeys:       pgdb.NewAPIKeyRepo(pg),
+		Wallets:       pgdb.NewWalletRepo(pg),
+		Orders:        pgdb.NewOrderRepo(pg),
+		Ratings:       pgdb.NewRatingRepo(pg),
+		Questions:     pgdb.NewQuestionRepo(pg),
+		Media:         pgdb.NewMediaRepo(pg),
+		Templates:     pgdb.NewTemplateRepo(pg),
+		Fees:          pgdb.NewFeeRepo(pg),
+		FXRates:       pgdb.NewFXRepo(pg),
 	}
 }
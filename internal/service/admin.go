@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/metrics"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+type AdminService struct {
+	auctionRepo repo.Auctions
+	banRepo     repo.Bans
+	walletRepo  repo.Wallets
+	txManager   trm.Manager
+	breaker     *circuitbreaker.CircuitBreaker
+	retryer     *retry.Retryer
+	metrics     *metrics.Metrics
+}
+
+func NewAdminService(
+	aRepo repo.Auctions,
+	banRepo repo.Bans,
+	walletRepo repo.Wallets,
+	txManager trm.Manager,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+	m *metrics.Metrics,
+) *AdminService {
+	return &AdminService{
+		auctionRepo: aRepo,
+		banRepo:     banRepo,
+		walletRepo:  walletRepo,
+		txManager:   txManager,
+		breaker:     breaker,
+		retryer:     retryer,
+		metrics:     m,
+	}
+}
+
+func (s *AdminService) ForceFinishAuction(ctx context.Context, auctionID string) error {
+	if err := s.auctionRepo.ForceEnd(ctx, auctionID); err != nil {
+		return s.activeAuctionErr(ctx, auctionID, err)
+	}
+	log.Infof("Auction force finished [%s]", auctionID)
+	return nil
+}
+
+// CancelAuction cancels the auction and releases its bid holds and
+// registration deposits in the same transaction, so a cancelled auction never
+// keeps funds held.
+func (s *AdminService) CancelAuction(ctx context.Context, auctionID string) error {
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.auctionRepo.Cancel(ctx, auctionID); err != nil {
+			return err
+		}
+		if err := releaseHolds(ctx, s.walletRepo, auctionID); err != nil {
+			return err
+		}
+		return releaseHolds(ctx, s.walletRepo, e.RegistrationReference(auctionID))
+	})
+	if err != nil {
+		return s.activeAuctionErr(ctx, auctionID, err)
+	}
+
+	s.metrics.ActiveAuctions.Dec()
+	log.Infof("Auction cancelled [%s]", auctionID)
+	return nil
+}
+
+func (s *AdminService) activeAuctionErr(ctx context.Context, auctionID string, err error) error {
+	if !errors.Is(err, re.ErrNotFound) {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotUpdateAuction
+	}
+	if _, err := s.auctionRepo.GetByID(ctx, auctionID); err != nil {
+		return se.HandleRepoNotFound(err, se.ErrNotFoundAuction, se.ErrCannotUpdateAuction)
+	}
+	return se.ErrAuctionNotActive
+}
+
+func (s *AdminService) BanUser(ctx context.Context, in sd.BanUserInput) (e.Ban, error) {
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(time.Now()) {
+		return e.Ban{}, se.ErrInvalidBanExpiry
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var ban e.Ban
+		err := s.retryer.Do(ctx, "ban_user", func() error {
+			var e error
+			ban, e = s.banRepo.Create(ctx, rd.CreateBanInput{
+				UserID:    in.UserID,
+				Reason:    in.Reason,
+				BannedBy:  in.BannedBy,
+				ExpiresAt: in.ExpiresAt,
+			})
+			return e
+		})
+		return ban, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Ban{}, se.ErrCannotBanUser
+	}
+
+	ban := result.(e.Ban)
+	log.Infof("User banned [%s] by %s: %s", ban.UserID, ban.BannedBy, ban.Reason)
+	return ban, nil
+}
+
+func (s *AdminService) UnbanUser(ctx context.Context, userID string) error {
+	if err := s.banRepo.Delete(ctx, userID); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.HandleRepoNotFound(err, se.ErrNotFoundBan, se.ErrCannotUnbanUser)
+	}
+	return nil
+}
+
+func (s *AdminService) ListBans(ctx context.Context) ([]e.Ban, error) {
+	bans, err := s.banRepo.List(ctx)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return nil, se.ErrCannotGetBans
+	}
+	return bans, nil
+}
+
+func (s *AdminService) CircuitBreakerStates() []circuitbreaker.State {
+	return s.breaker.States()
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+type AllowlistService struct {
+	auctionRepo repo.Auctions
+	inviteRepo  repo.Invites
+	groupRepo   repo.BidderGroups
+	txManager   trm.Manager
+	breaker     *circuitbreaker.CircuitBreaker
+	retryer     *retry.Retryer
+}
+
+func NewAllowlistService(
+	aRepo repo.Auctions,
+	inviteRepo repo.Invites,
+	groupRepo repo.BidderGroups,
+	txManager trm.Manager,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *AllowlistService {
+	return &AllowlistService{
+		auctionRepo: aRepo,
+		inviteRepo:  inviteRepo,
+		groupRepo:   groupRepo,
+		txManager:   txManager,
+		breaker:     breaker,
+		retryer:     retryer,
+	}
+}
+
+// ownedAuction loads the auction and hides it from everyone but its seller.
+func (s *AllowlistService) ownedAuction(ctx context.Context, sellerID, auctionID string) (e.Auction, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotGetAuction)
+	}
+
+	auction := result.(e.Auction)
+	if auction.SellerID != sellerID {
+		return e.Auction{}, se.ErrNotFoundAuction
+	}
+	return auction, nil
+}
+
+// ownedGroup loads the group and hides it from everyone but its seller.
+func (s *AllowlistService) ownedGroup(ctx context.Context, sellerID string, groupID int64) (e.BidderGroup, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var group e.BidderGroup
+		err := s.retryer.Do(ctx, "get_bidder_group", func() error {
+			var e error
+			group, e = s.groupRepo.GetByID(ctx, groupID)
+			return e
+		})
+		return group, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.BidderGroup{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundGroup, se.ErrCannotGetAllowlist)
+	}
+
+	group := result.(e.BidderGroup)
+	if group.SellerID != sellerID {
+		return e.BidderGroup{}, se.ErrNotFoundGroup
+	}
+	return group, nil
+}
+
+func (s *AllowlistService) SetVisibility(ctx context.Context, in sd.SetVisibilityInput) (e.Auction, error) {
+	if !in.Visibility.IsValid() {
+		return e.Auction{}, se.ErrInvalidVisibility
+	}
+	auction, err := s.ownedAuction(ctx, in.SellerID, in.AuctionID)
+	if err != nil {
+		return e.Auction{}, err
+	}
+
+	_, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "set_auction_visibility", func() error {
+			return s.auctionRepo.UpdateVisibility(ctx, in.AuctionID, in.Visibility)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotUpdateAuction)
+	}
+
+	auction.Visibility = in.Visibility
+	log.Infof("Auction %s visibility set to %s", in.AuctionID, in.Visibility)
+	return auction, nil
+}
+
+func (s *AllowlistService) GetAllowlist(ctx context.Context, sellerID, auctionID string) (e.Allowlist, error) {
+	if _, err := s.ownedAuction(ctx, sellerID, auctionID); err != nil {
+		return e.Allowlist{}, err
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		list := e.Allowlist{AuctionID: auctionID}
+		err := s.retryer.Do(ctx, "get_allowlist", func() error {
+			var e error
+			if list.BidderIDs, e = s.inviteRepo.ListByAuction(ctx, auctionID); e != nil {
+				return e
+			}
+			list.Groups, e = s.groupRepo.ListByAuction(ctx, auctionID)
+			return e
+		})
+		return list, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Allowlist{}, se.ErrCannotGetAllowlist
+	}
+	return result.(e.Allowlist), nil
+}
+
+func (s *AllowlistService) UpdateAllowlist(ctx context.Context, in sd.UpdateAllowlistInput) (e.Allowlist, error) {
+	if _, err := s.ownedAuction(ctx, in.SellerID, in.AuctionID); err != nil {
+		return e.Allowlist{}, err
+	}
+	for _, groupID := range in.AddGroups {
+		if _, err := s.ownedGroup(ctx, in.SellerID, groupID); err != nil {
+			return e.Allowlist{}, err
+		}
+	}
+
+	_, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "update_allowlist", func() error {
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				if err := s.inviteRepo.RemoveMany(ctx, in.AuctionID, in.RemoveBidders); err != nil {
+					return err
+				}
+				if err := s.inviteRepo.AddMany(ctx, in.AuctionID, in.AddBidders); err != nil {
+					return err
+				}
+				if err := s.inviteRepo.RemoveGroups(ctx, in.AuctionID, in.RemoveGroups); err != nil {
+					return err
+				}
+				return s.inviteRepo.AddGroups(ctx, in.AuctionID, in.AddGroups)
+			})
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Allowlist{}, se.ErrCannotUpdateAllowlist
+	}
+
+	return s.GetAllowlist(ctx, in.SellerID, in.AuctionID)
+}
+
+func (s *AllowlistService) CreateGroup(ctx context.Context, sellerID, name string, members []string) (e.BidderGroup, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var group e.BidderGroup
+		err := s.retryer.Do(ctx, "create_bidder_group", func() error {
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				var err error
+				if group, err = s.groupRepo.Create(ctx, sellerID, name); err != nil {
+					return err
+				}
+				return s.groupRepo.AddMembers(ctx, group.GroupID, members)
+			})
+		})
+		return group, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		if errors.Is(cbErr, re.ErrAlreadyExists) {
+			return e.BidderGroup{}, se.ErrGroupAlreadyExists
+		}
+		return e.BidderGroup{}, se.ErrCannotUpdateAllowlist
+	}
+
+	group := result.(e.BidderGroup)
+	log.Infof("Bidder group %d created by seller %s", group.GroupID, sellerID)
+	return s.ownedGroup(ctx, sellerID, group.GroupID)
+}
+
+func (s *AllowlistService) ListGroups(ctx context.Context, sellerID string) ([]e.BidderGroup, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var groups []e.BidderGroup
+		err := s.retryer.Do(ctx, "list_bidder_groups", func() error {
+			var e error
+			groups, e = s.groupRepo.ListBySeller(ctx, sellerID)
+			return e
+		})
+		return groups, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetAllowlist
+	}
+
+	groups, _ := result.([]e.BidderGroup)
+	return groups, nil
+}
+
+func (s *AllowlistService) DeleteGroup(ctx context.Context, sellerID string, groupID int64) error {
+	if _, err := s.ownedGroup(ctx, sellerID, groupID); err != nil {
+		return err
+	}
+
+	_, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "delete_bidder_group", func() error {
+			return s.groupRepo.Delete(ctx, groupID)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundGroup, se.ErrCannotUpdateAllowlist)
+	}
+	return nil
+}
+
+func (s *AllowlistService) UpdateGroupMembers(ctx context.Context, in sd.UpdateGroupMembersInput) (e.BidderGroup, error) {
+	if _, err := s.ownedGroup(ctx, in.SellerID, in.GroupID); err != nil {
+		return e.BidderGroup{}, err
+	}
+
+	_, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "update_group_members", func() error {
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				if err := s.groupRepo.RemoveMembers(ctx, in.GroupID, in.Remove); err != nil {
+					return err
+				}
+				return s.groupRepo.AddMembers(ctx, in.GroupID, in.Add)
+			})
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.BidderGroup{}, se.ErrCannotUpdateAllowlist
+	}
+
+	return s.ownedGroup(ctx, in.SellerID, in.GroupID)
+}
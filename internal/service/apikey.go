@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	apiKeyPrefix    = "ak_"
+	apiKeyPrefixLen = len(apiKeyPrefix) + 8
+)
+
+type APIKeyService struct {
+	apiKeyRepo   repo.APIKeys
+	breaker      *circuitbreaker.CircuitBreaker
+	retryer      *retry.Retryer
+	defaultRPS   float64
+	defaultBurst int
+}
+
+func NewAPIKeyService(
+	kRepo repo.APIKeys,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+	defaultRPS float64,
+	defaultBurst int,
+) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo:   kRepo,
+		breaker:      breaker,
+		retryer:      retryer,
+		defaultRPS:   defaultRPS,
+		defaultBurst: defaultBurst,
+	}
+}
+
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, in sd.CreateAPIKeyInput) (e.APIKey, string, error) {
+	plain, err := newAPIKey()
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.APIKey{}, "", se.ErrCannotCreateAPIKey
+	}
+
+	repoIn := rd.CreateAPIKeyInput{
+		OwnerID:        in.OwnerID,
+		Name:           in.Name,
+		Prefix:         plain[:apiKeyPrefixLen],
+		KeyHash:        hashAPIKey(plain),
+		Scopes:         in.Scopes,
+		RateLimitRPS:   s.defaultRPS,
+		RateLimitBurst: s.defaultBurst,
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var k e.APIKey
+		err := s.retryer.Do(ctx, "create_api_key", func() error {
+			var e error
+			k, e = s.apiKeyRepo.Create(ctx, repoIn)
+			return e
+		})
+		return k, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.APIKey{}, "", se.ErrCannotCreateAPIKey
+	}
+
+	return result.(e.APIKey), plain, nil
+}
+
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, ownerID string) ([]e.APIKey, error) {
+	keys, err := s.apiKeyRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return nil, se.ErrCannotGetAPIKeys
+	}
+	return keys, nil
+}
+
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, ownerID string, keyID int64) (e.APIKey, string, error) {
+	plain, err := newAPIKey()
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.APIKey{}, "", se.ErrCannotUpdateAPIKey
+	}
+
+	k, err := s.apiKeyRepo.Rotate(ctx, rd.RotateAPIKeyInput{
+		KeyID:   keyID,
+		OwnerID: ownerID,
+		Prefix:  plain[:apiKeyPrefixLen],
+		KeyHash: hashAPIKey(plain),
+	})
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.APIKey{}, "", se.HandleRepoNotFound(err, se.ErrNotFoundAPIKey, se.ErrCannotUpdateAPIKey)
+	}
+
+	log.Infof("API key rotated: key=%d owner=%s", keyID, ownerID)
+	return k, plain, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, ownerID string, keyID int64) error {
+	if err := s.apiKeyRepo.Revoke(ctx, keyID, ownerID); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.HandleRepoNotFound(err, se.ErrNotFoundAPIKey, se.ErrCannotUpdateAPIKey)
+	}
+
+	log.Infof("API key revoked: key=%d owner=%s", keyID, ownerID)
+	return nil
+}
+
+func (s *APIKeyService) SetQuota(ctx context.Context, in sd.SetAPIKeyQuotaInput) (e.APIKey, error) {
+	k, err := s.apiKeyRepo.SetQuota(ctx, in.KeyID, in.RPS, in.Burst)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.APIKey{}, se.HandleRepoNotFound(err, se.ErrNotFoundAPIKey, se.ErrCannotUpdateAPIKey)
+	}
+	return k, nil
+}
+
+func (s *APIKeyService) VerifyAPIKey(ctx context.Context, plain string) (e.APIKey, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		k, err := s.apiKeyRepo.GetActiveByHash(ctx, hashAPIKey(plain))
+		if errors.Is(err, re.ErrNotFound) {
+			return e.APIKey{}, nil
+		}
+		return k, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.APIKey{}, se.ErrCannotVerifyAPIKey
+	}
+
+	k := result.(e.APIKey)
+	if k.KeyID == 0 {
+		return e.APIKey{}, se.ErrInvalidAPIKey
+	}
+	return k, nil
+}
+
+func newAPIKey() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashAPIKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,52 +2,100 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
+	"fmt"
 
 	e "auction-platform/internal/entity"
 	"auction-platform/internal/infrastruct/circuitbreaker"
 	"auction-platform/internal/infrastruct/retry"
 	"auction-platform/internal/metrics"
 	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
 	re "auction-platform/internal/repo/errors"
 	sd "auction-platform/internal/service/dto"
 	se "auction-platform/internal/service/errors"
 	smap "auction-platform/internal/service/mappers"
 	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
 
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	log "github.com/sirupsen/logrus"
 )
 
 type AuctionService struct {
-	auctionRepo repo.Auctions
-	breaker     *circuitbreaker.CircuitBreaker
-	retryer     *retry.Retryer
-	metrics     *metrics.Metrics
+	auctionRepo  repo.Auctions
+	inviteRepo   repo.Invites
+	groupRepo    repo.BidderGroups
+	banRepo      repo.Bans
+	fxRepo       repo.FXRates
+	fraudRepo    repo.Fraud
+	ratingRepo   repo.Ratings
+	questionRepo repo.Questions
+	mediaRepo    repo.Media
+	storage      Storage
+	txManager    trm.Manager
+	breaker      *circuitbreaker.CircuitBreaker
+	retryer      *retry.Retryer
+	metrics      *metrics.Metrics
+	increments   e.IncrementPolicy
 }
 
 func NewAuctionService(
 	aRepo repo.Auctions,
+	inviteRepo repo.Invites,
+	groupRepo repo.BidderGroups,
+	banRepo repo.Bans,
+	fxRepo repo.FXRates,
+	fraudRepo repo.Fraud,
+	ratingRepo repo.Ratings,
+	questionRepo repo.Questions,
+	mediaRepo repo.Media,
+	storage Storage,
+	txManager trm.Manager,
 	breaker *circuitbreaker.CircuitBreaker,
 	retryer *retry.Retryer,
 	m *metrics.Metrics,
+	increments e.IncrementPolicy,
 ) *AuctionService {
 	return &AuctionService{
-		auctionRepo: aRepo,
-		breaker:     breaker,
-		retryer:     retryer,
-		metrics:     m,
+		auctionRepo:  aRepo,
+		inviteRepo:   inviteRepo,
+		groupRepo:    groupRepo,
+		banRepo:      banRepo,
+		fxRepo:       fxRepo,
+		fraudRepo:    fraudRepo,
+		ratingRepo:   ratingRepo,
+		questionRepo: questionRepo,
+		mediaRepo:    mediaRepo,
+		storage:      storage,
+		txManager:    txManager,
+		breaker:      breaker,
+		retryer:      retryer,
+		metrics:      m,
+		increments:   increments,
 	}
 }
 
 func (s *AuctionService) CreateAuction(ctx context.Context, in sd.CreateAuctionInput) (e.Auction, error) {
-	repoIn := smap.ToCreateAuctionRepoInput(in)
+	if err := s.normalizeAuction(&in); err != nil {
+		return e.Auction{}, err
+	}
+	if err := checkNotBanned(ctx, s.banRepo, in.SellerID); err != nil {
+		return e.Auction{}, err
+	}
+	if err := s.checkFXRate(ctx, in.Currency); err != nil {
+		return e.Auction{}, err
+	}
 
 	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
 		var auction e.Auction
 		err := s.retryer.Do(ctx, "create_auction", func() error {
-			var e error
-			auction, e = s.auctionRepo.Create(ctx, repoIn)
-			return e
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				var err error
+				auction, err = s.insertAuction(ctx, in)
+				return err
+			})
 		})
 		return auction, err
 	})
@@ -62,16 +110,96 @@ func (s *AuctionService) CreateAuction(ctx context.Context, in sd.CreateAuctionI
 	auction := result.(e.Auction)
 	s.metrics.AuctionsCreated.Inc()
 	s.metrics.ActiveAuctions.Inc()
+	s.recordSellerDevice(ctx, in.SellerID, in.ClientIP, in.DeviceID)
 
 	return auction, nil
 }
 
-func (s *AuctionService) GetAuction(ctx context.Context, auctionID string) (e.Auction, error) {
+// normalizeAuction fills in the defaults of a new auction and checks
+// everything that does not need the database.
+func (s *AuctionService) normalizeAuction(in *sd.CreateAuctionInput) error {
+	if in.Currency == "" {
+		in.Currency = money.DefaultCurrency
+	}
+	if !in.Currency.IsSupported() {
+		return se.ErrUnsupportedCurrency
+	}
+	in.StartPrice.Currency, in.MinStep.Currency, in.ReservePrice.Currency = in.Currency, in.Currency, in.Currency
+	if in.StartPrice.Validate() != nil || in.MinStep.Validate() != nil ||
+		in.ReservePrice.Validate() != nil || in.ReservePrice.IsNegative() {
+		return se.ErrInvalidAmount
+	}
+	if err := s.resolveIncrements(in); err != nil {
+		return err
+	}
+	if in.Quantity == 0 {
+		in.Quantity = 1
+	}
+	if in.Quantity < 0 {
+		return se.ErrInvalidQuantity
+	}
+	if in.Pricing == "" {
+		in.Pricing = e.LotPricingPayAsBid
+	}
+	if !in.Pricing.IsValid() {
+		return se.ErrInvalidPricing
+	}
+	if in.Type == "" {
+		in.Type = e.AuctionTypeForward
+	}
+	if !in.Type.IsValid() {
+		return se.ErrInvalidAuctionType
+	}
+	if in.Visibility == "" {
+		in.Visibility = e.VisibilityPublic
+		if len(in.Invited) > 0 {
+			in.Visibility = e.VisibilityPrivate
+		}
+	}
+	if !in.Visibility.IsValid() {
+		return se.ErrInvalidVisibility
+	}
+	in.Eligibility.Deposit.Currency = in.Currency
+	if !in.Eligibility.IsValid() {
+		return se.ErrInvalidEligibility
+	}
+	if !in.Relist.IsValid() {
+		return se.ErrInvalidRelistPolicy
+	}
+	return nil
+}
+
+// insertAuction stores a normalized auction with its invitations; callers run
+// it inside a transaction.
+func (s *AuctionService) insertAuction(ctx context.Context, in sd.CreateAuctionInput) (e.Auction, error) {
+	auction, err := s.auctionRepo.Create(ctx, smap.ToCreateAuctionRepoInput(in))
+	if err != nil {
+		return e.Auction{}, err
+	}
+	return auction, s.inviteRepo.AddMany(ctx, auction.AuctionID, in.Invited)
+}
+
+// recordSellerDevice keeps the seller's devices, which fraud detection
+// compares bidders against.
+func (s *AuctionService) recordSellerDevice(ctx context.Context, sellerID, clientIP, deviceID string) {
+	if clientIP == "" && deviceID == "" {
+		return
+	}
+	if err := s.fraudRepo.RecordDevice(ctx, sellerID, clientIP, deviceID); err != nil {
+		log.Warnf("Cannot record device for seller %s: %v", sellerID, err)
+	}
+}
+
+func (s *AuctionService) GetAuction(ctx context.Context, in sd.GetAuctionInput) (e.Auction, error) {
+	if in.Currency != "" && !in.Currency.IsSupported() {
+		return e.Auction{}, se.ErrUnsupportedCurrency
+	}
+
 	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
 		var auction e.Auction
 		err := s.retryer.Do(ctx, "get_auction", func() error {
 			var e error
-			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			auction, e = s.auctionRepo.GetByID(ctx, in.AuctionID)
 			return e
 		})
 		return auction, err
@@ -82,10 +210,180 @@ func (s *AuctionService) GetAuction(ctx context.Context, auctionID string) (e.Au
 	}
 
 	auction := result.(e.Auction)
-	return auction, nil
+	if err := checkCanView(ctx, s.inviteRepo, auction, in.ViewerID); err != nil {
+		return e.Auction{}, err
+	}
+
+	auctions := []e.Auction{auction}
+	if err := s.convertPrices(ctx, auctions, in.Currency); err != nil {
+		return e.Auction{}, err
+	}
+	attachSellerRatings(ctx, s.ratingRepo, auctions)
+	attachMedia(ctx, s.mediaRepo, s.storage, auctions)
+	attachAnswers(ctx, s.questionRepo, &auctions[0], in.ViewerID)
+	return auctions[0], nil
+}
+
+// checkCanView hides private auctions from everyone but the seller and the
+// allowlisted bidders.
+func checkCanView(ctx context.Context, inviteRepo repo.Invites, auction e.Auction, viewerID string) error {
+	if !auction.IsPrivate() || auction.SellerID == viewerID {
+		return nil
+	}
+	if viewerID == "" {
+		return se.ErrNotFoundAuction
+	}
+
+	invited, err := inviteRepo.IsInvited(ctx, auction.AuctionID, viewerID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotGetAuction
+	}
+	if !invited {
+		return se.ErrNotFoundAuction
+	}
+	return nil
+}
+
+// ListActive takes the price filters in the viewer's currency, or in
+// money.DefaultCurrency when none is given, and converts the listed prices into
+// the viewer's currency.
+func (s *AuctionService) ListActive(ctx context.Context, in sd.ListAuctionsInput) ([]e.Auction, int64, error) {
+	if in.Page < 1 {
+		in.Page = 1
+	}
+	if in.PageSize < 1 || in.PageSize > 100 {
+		in.PageSize = 20
+	}
+	if in.Currency != "" && !in.Currency.IsSupported() {
+		return nil, 0, se.ErrUnsupportedCurrency
+	}
+
+	input := rd.ListActiveAuctionsInput{
+		Limit:  in.PageSize,
+		Offset: (in.Page - 1) * in.PageSize,
+	}
+	var err error
+	if input.MinPrice, err = s.toBaseCurrency(ctx, in.MinPrice); err != nil {
+		return nil, 0, err
+	}
+	if input.MaxPrice, err = s.toBaseCurrency(ctx, in.MaxPrice); err != nil {
+		return nil, 0, err
+	}
+
+	type activePage struct {
+		auctions []e.Auction
+		total    int64
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var r activePage
+		err := s.retryer.Do(ctx, "list_auctions", func() error {
+			var e error
+			r.auctions, r.total, e = s.auctionRepo.ListActive(ctx, input)
+			return e
+		})
+		return r, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, 0, se.ErrCannotListAuctions
+	}
+
+	r := result.(activePage)
+	if err := s.convertPrices(ctx, r.auctions, in.Currency); err != nil {
+		return nil, 0, err
+	}
+	attachSellerRatings(ctx, s.ratingRepo, r.auctions)
+	attachMedia(ctx, s.mediaRepo, s.storage, r.auctions)
+	return r.auctions, r.total, nil
+}
+
+// convertPrices fills in the prices of each auction in currency to. Auctions in
+// a currency without a rate are left unconverted.
+func (s *AuctionService) convertPrices(ctx context.Context, auctions []e.Auction, to money.Currency) error {
+	if to == "" || len(auctions) == 0 {
+		return nil
+	}
+	table, err := loadFXTable(ctx, s.fxRepo)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotGetFXRates
+	}
+
+	for i := range auctions {
+		a := &auctions[i]
+		rate, ok := table.Rate(a.Currency, to)
+		if !ok {
+			continue
+		}
+		a.Converted = &e.ConvertedPrices{
+			Currency:   to,
+			Rate:       rate,
+			StartPrice: a.StartPrice.Convert(to, rate),
+			CurrentBid: a.CurrentBid.Convert(to, rate),
+			MinStep:    a.MinStep.Convert(to, rate),
+			NextBid:    a.NextBid().Convert(to, rate),
+		}
+	}
+	return nil
+}
+
+func (s *AuctionService) toBaseCurrency(ctx context.Context, m *money.Money) (*money.Money, error) {
+	if m == nil {
+		return nil, nil
+	}
+	base, _, err := convertMoney(ctx, s.fxRepo, *m, money.DefaultCurrency)
+	if err != nil {
+		if errors.Is(err, se.ErrNoFXRate) {
+			return nil, err
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return nil, se.ErrCannotGetFXRates
+	}
+	return &base, nil
 }
 
-func (s *AuctionService) ListActive(ctx context.Context, page, pageSize int) ([]e.Auction, int64, error) {
+// resolveIncrements picks the auction's own increment table, then a flat
+// MinStep, then the category's default table. With a table, MinStep is set to
+// the step at the start price.
+func (s *AuctionService) resolveIncrements(in *sd.CreateAuctionInput) error {
+	in.Increments = in.Increments.WithCurrency(in.Currency)
+	if len(in.Increments) == 0 && in.MinStep.IsZero() {
+		in.Increments = s.increments.TableFor(in.Category, in.Currency)
+	}
+
+	if len(in.Increments) == 0 {
+		if !in.MinStep.IsPositive() {
+			return se.ErrNoBidIncrement
+		}
+		return nil
+	}
+	if !in.Increments.IsValid() {
+		return se.ErrInvalidIncrements
+	}
+	in.MinStep = in.Increments.Step(in.StartPrice)
+	return nil
+}
+
+// checkFXRate makes sure amounts in c can be converted into the currency
+// wallets are kept in.
+func (s *AuctionService) checkFXRate(ctx context.Context, c money.Currency) error {
+	if c == money.DefaultCurrency {
+		return nil
+	}
+	table, err := loadFXTable(ctx, s.fxRepo)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotGetFXRates
+	}
+	if _, ok := table.Rate(c, money.DefaultCurrency); !ok {
+		return se.ErrNoFXRate
+	}
+	return nil
+}
+
+func (s *AuctionService) ListBySeller(ctx context.Context, sellerID string, page, pageSize int) ([]e.SellerAuction, int64, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -94,15 +392,16 @@ func (s *AuctionService) ListActive(ctx context.Context, page, pageSize int) ([]
 	}
 	offset := (page - 1) * pageSize
 
+	type sellerPage struct {
+		auctions []e.SellerAuction
+		total    int64
+	}
+
 	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
-		type la struct {
-			auctions []e.Auction
-			total    int64
-		}
-		var r la
-		err := s.retryer.Do(ctx, "list_auctions", func() error {
+		var r sellerPage
+		err := s.retryer.Do(ctx, "list_seller_auctions", func() error {
 			var e error
-			r.auctions, r.total, e = s.auctionRepo.ListActive(ctx, pageSize, offset)
+			r.auctions, r.total, e = s.auctionRepo.ListBySeller(ctx, sellerID, pageSize, offset)
 			return e
 		})
 		return r, err
@@ -112,10 +411,139 @@ func (s *AuctionService) ListActive(ctx context.Context, page, pageSize int) ([]
 		return nil, 0, se.ErrCannotListAuctions
 	}
 
-	type la struct {
-		auctions []e.Auction
-		total    int64
-	}
-	r := result.(la)
+	r := result.(sellerPage)
 	return r.auctions, r.total, nil
 }
+
+// DeleteAuction lets the seller remove an auction nobody has bid on or
+// registered for, along with its stored media.
+func (s *AuctionService) DeleteAuction(ctx context.Context, sellerID, auctionID string) error {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotDeleteAuction)
+	}
+	if result.(e.Auction).SellerID != sellerID {
+		return se.ErrNotFoundAuction
+	}
+
+	result, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		var media []e.Media
+		err := s.retryer.Do(ctx, "delete_auction", func() error {
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				var err error
+				if media, err = s.mediaRepo.ListByAuction(ctx, auctionID); err != nil {
+					return err
+				}
+				return s.auctionRepo.Delete(ctx, auctionID)
+			})
+		})
+		return media, err
+	})
+	if cbErr != nil {
+		if errors.Is(cbErr, re.ErrNotFound) {
+			return se.ErrAuctionHasActivity
+		}
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.ErrCannotDeleteAuction
+	}
+
+	media, _ := result.([]e.Media)
+	removeMediaFiles(ctx, s.storage, media...)
+	log.Infof("Auction [%s] deleted by seller %s, %d media files removed", auctionID, sellerID, len(media))
+	return nil
+}
+
+// RelistAuction puts an auction that finished unsold up again under its
+// relist policy, with the same invitations, and links the new auction back
+// to it. It fails with ErrNotRelistable when the auction sold, is still
+// running, was relisted already or has used up its policy.
+func (s *AuctionService) RelistAuction(ctx context.Context, auctionID string) (e.Auction, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotRelistAuction)
+	}
+	prev := result.(e.Auction)
+	if prev.Status != e.AuctionStatusFinished || prev.WinnerID != "" || !prev.CanRelist() {
+		return e.Auction{}, se.ErrNotRelistable
+	}
+	if err := checkNotBanned(ctx, s.banRepo, prev.SellerID); err != nil {
+		return e.Auction{}, err
+	}
+
+	newID, err := newAuctionID()
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Auction{}, se.ErrCannotRelistAuction
+	}
+
+	result, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "relist_auction", func() error {
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				invited, err := s.inviteRepo.ListByAuction(ctx, prev.AuctionID)
+				if err != nil {
+					return err
+				}
+				groups, err := s.groupRepo.ListByAuction(ctx, prev.AuctionID)
+				if err != nil {
+					return err
+				}
+				groupIDs := make([]int64, 0, len(groups))
+				for _, g := range groups {
+					groupIDs = append(groupIDs, g.GroupID)
+				}
+
+				if auction, err = s.auctionRepo.Create(ctx, smap.ToRelistAuctionRepoInput(prev, newID)); err != nil {
+					return err
+				}
+				if err := s.inviteRepo.AddMany(ctx, auction.AuctionID, invited); err != nil {
+					return err
+				}
+				return s.inviteRepo.AddGroups(ctx, auction.AuctionID, groupIDs)
+			})
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		// relisted_from is unique, so a second relisting of prev conflicts.
+		if errors.Is(cbErr, re.ErrAlreadyExists) {
+			return e.Auction{}, se.ErrNotRelistable
+		}
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.ErrCannotRelistAuction
+	}
+
+	s.metrics.AuctionsCreated.Inc()
+	s.metrics.ActiveAuctions.Inc()
+	return result.(e.Auction), nil
+}
+
+// newAuctionID makes a random (version 4) UUID for auctions the platform
+// creates on the seller's behalf.
+func newAuctionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("auction id: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+func checkNotBanned(ctx context.Context, banRepo repo.Bans, userID string) error {
+	banned, err := banRepo.IsBanned(ctx, userID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotCheckBan
+	}
+	if banned {
+		return se.ErrUserBanned
+	}
+	return nil
+}
+
+func checkNotBlocked(ctx context.Context, banRepo repo.Bans, sellerID, bidderID string) error {
+	blocked, err := banRepo.IsBlocked(ctx, sellerID, bidderID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotCheckBan
+	}
+	if blocked {
+		return se.ErrBlockedBySeller
+	}
+	return nil
+}
+
+// cachedBans keeps ban and block lookups in Redis for ttl. Changes made
+// through it drop the cached answer straight away. Only "not banned" is
+// cached for bans, so a ban that runs out on its own stops applying at
+// expires_at; blocks never expire and are cached either way.
+type cachedBans struct {
+	repo.Bans
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+func newCachedBans(bans repo.Bans, rdb *redis.Client, ttl time.Duration) repo.Bans {
+	if rdb == nil || ttl <= 0 {
+		return bans
+	}
+	return &cachedBans{Bans: bans, redis: rdb, ttl: ttl}
+}
+
+func banCacheKey(userID string) string {
+	return fmt.Sprintf("ban:user:%s", userID)
+}
+
+func blockCacheKey(sellerID, bidderID string) string {
+	return fmt.Sprintf("ban:blocked:%s:%s", sellerID, bidderID)
+}
+
+func (c *cachedBans) IsBanned(ctx context.Context, userID string) (bool, error) {
+	return c.lookup(ctx, banCacheKey(userID), false, func() (bool, error) {
+		return c.Bans.IsBanned(ctx, userID)
+	})
+}
+
+func (c *cachedBans) IsBlocked(ctx context.Context, sellerID, bidderID string) (bool, error) {
+	return c.lookup(ctx, blockCacheKey(sellerID, bidderID), true, func() (bool, error) {
+		return c.Bans.IsBlocked(ctx, sellerID, bidderID)
+	})
+}
+
+func (c *cachedBans) Create(ctx context.Context, in rd.CreateBanInput) (e.Ban, error) {
+	ban, err := c.Bans.Create(ctx, in)
+	if err == nil {
+		c.redis.Del(ctx, banCacheKey(in.UserID))
+	}
+	return ban, err
+}
+
+func (c *cachedBans) Delete(ctx context.Context, userID string) error {
+	err := c.Bans.Delete(ctx, userID)
+	if err == nil {
+		c.redis.Del(ctx, banCacheKey(userID))
+	}
+	return err
+}
+
+func (c *cachedBans) Block(ctx context.Context, in rd.BlockBidderInput) (e.BlockedBidder, error) {
+	blocked, err := c.Bans.Block(ctx, in)
+	if err == nil {
+		c.redis.Del(ctx, blockCacheKey(in.SellerID, in.BidderID))
+	}
+	return blocked, err
+}
+
+func (c *cachedBans) Unblock(ctx context.Context, sellerID, bidderID string) error {
+	err := c.Bans.Unblock(ctx, sellerID, bidderID)
+	if err == nil {
+		c.redis.Del(ctx, blockCacheKey(sellerID, bidderID))
+	}
+	return err
+}
+
+// lookup falls back to load whenever Redis cannot answer. Found results are
+// only cached when cacheFound is set.
+func (c *cachedBans) lookup(ctx context.Context, key string, cacheFound bool, load func() (bool, error)) (bool, error) {
+	if val, err := c.redis.Get(ctx, key).Result(); err == nil {
+		return val == "1", nil
+	}
+
+	found, err := load()
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case !found:
+		c.redis.Set(ctx, key, "0", c.ttl)
+	case cacheFound:
+		c.redis.Set(ctx, key, "1", c.ttl)
+	}
+	return found, nil
+}
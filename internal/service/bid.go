@@ -18,7 +18,9 @@ import (
 	se "auction-platform/internal/service/errors"
 	smap "auction-platform/internal/service/mappers"
 	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
 
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/redis/go-redis/v9"
 	log "github.com/sirupsen/logrus"
 )
@@ -26,6 +28,11 @@ import (
 type BidService struct {
 	auctionRepo repo.Auctions
 	bidRepo     repo.Bids
+	inviteRepo  repo.Invites
+	banRepo     repo.Bans
+	walletRepo  repo.Wallets
+	fxRepo      repo.FXRates
+	txManager   trm.Manager
 	producer    *kafkaclient.Producer
 	redis       *redis.Client
 	breaker     *circuitbreaker.CircuitBreaker
@@ -33,11 +40,18 @@ type BidService struct {
 	metrics     *metrics.Metrics
 	bidTopic    string
 	resultTopic string
+	holdPercent float64
+	eligibility []EligibilityRule
 }
 
 func NewBidService(
 	aRepo repo.Auctions,
 	bRepo repo.Bids,
+	inviteRepo repo.Invites,
+	banRepo repo.Bans,
+	walletRepo repo.Wallets,
+	fxRepo repo.FXRates,
+	txManager trm.Manager,
 	producer *kafkaclient.Producer,
 	rdb *redis.Client,
 	breaker *circuitbreaker.CircuitBreaker,
@@ -45,10 +59,17 @@ func NewBidService(
 	m *metrics.Metrics,
 	bidTopic string,
 	resultTopic string,
+	holdPercent float64,
+	eligibility []EligibilityRule,
 ) *BidService {
 	return &BidService{
 		auctionRepo: aRepo,
 		bidRepo:     bRepo,
+		inviteRepo:  inviteRepo,
+		banRepo:     banRepo,
+		walletRepo:  walletRepo,
+		fxRepo:      fxRepo,
+		txManager:   txManager,
 		producer:    producer,
 		redis:       rdb,
 		breaker:     breaker,
@@ -56,10 +77,32 @@ func NewBidService(
 		metrics:     m,
 		bidTopic:    bidTopic,
 		resultTopic: resultTopic,
+		holdPercent: holdPercent,
+		eligibility: eligibility,
 	}
 }
 
+// holdAmount is the deposit for a bid in the currency wallets are kept in,
+// converted at the current rate.
+func (s *BidService) holdAmount(ctx context.Context, bidAmount money.Money) (money.Money, error) {
+	hold, _, err := convertMoney(ctx, s.fxRepo, bidAmount.Percent(s.holdPercent), money.DefaultCurrency)
+	return hold, err
+}
+
 func (s *BidService) PlaceBid(ctx context.Context, in sd.PlaceBidInput) (e.Bid, error) {
+	if err := in.Amount.Validate(); err != nil {
+		return e.Bid{}, se.ErrInvalidAmount
+	}
+	if in.Quantity == 0 {
+		in.Quantity = 1
+	}
+	if in.Quantity < 0 {
+		return e.Bid{}, se.ErrInvalidQuantity
+	}
+	if err := checkNotBanned(ctx, s.banRepo, in.BidderID); err != nil {
+		return e.Bid{}, err
+	}
+
 	repoIn := smap.ToCreateBidRepoInput(in)
 
 	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
@@ -73,21 +116,19 @@ func (s *BidService) PlaceBid(ctx context.Context, in sd.PlaceBidInput) (e.Bid,
 	})
 	if cbErr != nil {
 		log.Error(errutils.WrapPathErr(cbErr))
+		if errors.Is(cbErr, re.ErrAlreadyExists) {
+			return e.Bid{}, se.ErrBidAlreadyExists
+		}
 		return e.Bid{}, se.ErrCannotCreateBid
 	}
 
 	bid := result.(e.Bid)
 
-	event := kd.BidPlacedEvent{
-		BidID:     bid.BidID,
-		AuctionID: bid.AuctionID,
-		BidderID:  bid.BidderID,
-		Amount:    bid.Amount,
-		Timestamp: bid.CreatedAt,
-	}
+	event := bidPlacedEvent(bid)
+	event.ClientIP, event.DeviceID = in.ClientIP, in.DeviceID
 
 	s.metrics.BidsPlaced.Inc()
-	s.metrics.BidAmountHistogram.Observe(bid.Amount)
+	s.metrics.BidAmountHistogram.Observe(bid.Amount.Float64())
 
 	if err := s.producer.Publish(ctx, s.bidTopic, bid.AuctionID, event); err != nil {
 		log.Error(errutils.WrapPathErr(err))
@@ -108,33 +149,97 @@ func (s *BidService) ProcessBidEvent(ctx context.Context, event kd.BidPlacedEven
 	}
 	defer s.releaseLock(ctx, lockKey, lockVal)
 
+	if bid, err := s.bidRepo.GetByID(ctx, event.BidID); err == nil && bid.Status == e.BidStatusUnderReview {
+		log.Infof("Bid [%s] is under review, skipping", event.BidID)
+		return nil
+	}
+
 	auction, err := s.auctionRepo.GetByID(ctx, event.AuctionID)
 	if err != nil {
-		s.rejectBid(ctx, event, se.ErrNotFoundAuction.Error())
+		s.rejectBid(ctx, event, e.BidRejectAuctionNotFound, se.ErrNotFoundAuction.Error())
 		return se.ErrNotFoundAuction
 	}
 
 	if auction.Status != e.AuctionStatusActive {
-		s.rejectBid(ctx, event, se.ErrAuctionEnded.Error())
+		s.rejectBid(ctx, event, e.BidRejectAuctionEnded, se.ErrAuctionEnded.Error())
 		return se.ErrAuctionEnded
 	}
 
 	if event.BidderID == auction.SellerID {
-		s.rejectBid(ctx, event, se.ErrSellerCannotBid.Error())
+		s.rejectBid(ctx, event, e.BidRejectSellerCannotBid, se.ErrSellerCannotBid.Error())
 		return se.ErrSellerCannotBid
 	}
 
-	if event.Amount < auction.CurrentBid+auction.MinStep {
-		s.rejectBid(ctx, event, fmt.Sprintf("bid must be >= %.2f", auction.CurrentBid+auction.MinStep))
-		return se.ErrBidTooLow
+	if auction.IsPrivate() {
+		invited, err := s.inviteRepo.IsInvited(ctx, auction.AuctionID, event.BidderID)
+		if err != nil {
+			log.Error(errutils.WrapPathErr(err))
+			return se.ErrCannotUpdateBid
+		}
+		if !invited {
+			s.rejectBid(ctx, event, e.BidRejectNotInvited, se.ErrNotInvited.Error())
+			return se.ErrNotInvited
+		}
 	}
 
-	if err := s.auctionRepo.UpdateCurrentBid(ctx, event.AuctionID, event.Amount); err != nil {
-		log.Error(errutils.WrapPathErr(err))
-		return se.ErrCannotUpdateBid
+	for _, rule := range s.eligibility {
+		eligible, err := rule.Check(ctx, auction, event.BidderID)
+		if err != nil {
+			log.Error(errutils.WrapPathErr(err))
+			return se.ErrCannotUpdateBid
+		}
+		if !eligible {
+			s.rejectBid(ctx, event, rule.Code, rule.Err.Error())
+			return rule.Err
+		}
+	}
+
+	event.Amount.Currency, event.Currency = auction.Currency, string(auction.Currency)
+	if err := event.Amount.Validate(); err != nil {
+		s.rejectBid(ctx, event, e.BidRejectInvalidAmount, se.ErrInvalidAmount.Error())
+		return se.ErrInvalidAmount
+	}
+
+	if event.Quantity == 0 {
+		event.Quantity = 1
+	}
+	if event.Quantity > auction.Quantity {
+		s.rejectBid(ctx, event, e.BidRejectInvalidQuantity, se.ErrInvalidQuantity.Error())
+		return se.ErrInvalidQuantity
+	}
+
+	if !auction.AcceptsBid(event.Amount) {
+		if auction.IsReverse() {
+			s.rejectBid(ctx, event, e.BidRejectTooHigh, fmt.Sprintf("bid must be <= %s", auction.NextMaxBid()))
+			return se.ErrBidTooHigh
+		}
+		s.rejectBid(ctx, event, e.BidRejectTooLow, fmt.Sprintf("bid must be >= %s", auction.NextMinBid()))
+		return se.ErrBidTooLow
 	}
 
-	if err := s.bidRepo.UpdateStatus(ctx, event.BidID, e.BidStatusAccepted); err != nil {
+	var outbid []string
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		before, err := s.WinningBids(ctx, auction)
+		if err != nil {
+			return err
+		}
+		if err := s.bidRepo.UpdateStatus(ctx, event.BidID, e.BidStatusAccepted); err != nil {
+			return err
+		}
+		after, err := s.WinningBids(ctx, auction)
+		if err != nil {
+			return err
+		}
+		if outbid, err = s.rebalanceHolds(ctx, auction, before, after, event.BidderID); err != nil {
+			return err
+		}
+		return s.auctionRepo.UpdateCurrentBid(ctx, event.AuctionID, auction.ClearingPrice(after))
+	})
+	if err != nil {
+		if errors.Is(err, re.ErrInsufficientFunds) {
+			s.rejectBid(ctx, event, e.BidRejectInsufficientFunds, se.ErrInsufficientFunds.Error())
+			return se.ErrInsufficientFunds
+		}
 		log.Error(errutils.WrapPathErr(err))
 		return se.ErrCannotUpdateBid
 	}
@@ -142,36 +247,245 @@ func (s *BidService) ProcessBidEvent(ctx context.Context, event kd.BidPlacedEven
 	cacheKey := fmt.Sprintf("auction:%s", event.AuctionID)
 	s.redis.Del(ctx, cacheKey)
 
-	s.publishResult(ctx, event, string(e.BidStatusAccepted), "")
+	s.publishResult(ctx, event, string(e.BidStatusAccepted), "", "", outbid)
 	s.metrics.BidsAccepted.Inc()
 
 	return nil
 }
 
-func (s *BidService) rejectBid(ctx context.Context, event kd.BidPlacedEvent, reason string) {
+func (s *BidService) RejectBid(ctx context.Context, bidID, reason string) error {
+	bid, withdrawn, err := s.withdrawBid(ctx, bidID, e.BidStatusRejected)
+	if err != nil || !withdrawn {
+		return err
+	}
+
+	s.publishResult(ctx, bidPlacedEvent(bid), string(e.BidStatusRejected), e.BidRejectByAdmin, reason, nil)
+	s.metrics.BidsRejected.Inc()
+	log.Infof("Bid rejected by admin [%s]: %s", bid.BidID, reason)
+
+	return nil
+}
+
+// HoldBid takes a bid out of its auction while it is reviewed. ProcessBidEvent
+// skips held bids until ReleaseHeldBid puts them back.
+func (s *BidService) HoldBid(ctx context.Context, bidID, reason string) error {
+	bid, withdrawn, err := s.withdrawBid(ctx, bidID, e.BidStatusUnderReview)
+	if err != nil || !withdrawn {
+		return err
+	}
+
+	s.publishResult(ctx, bidPlacedEvent(bid), string(e.BidStatusUnderReview), "", reason, nil)
+	log.Infof("Bid held for review [%s]: %s", bid.BidID, reason)
+
+	return nil
+}
+
+// ReleaseHeldBid returns a held bid to pending and places it again, so it is
+// checked against the auction as it stands now.
+func (s *BidService) ReleaseHeldBid(ctx context.Context, bidID string) error {
+	bid, err := s.bidRepo.GetByID(ctx, bidID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.HandleRepoNotFound(err, se.ErrNotFoundBid, se.ErrCannotUpdateBid)
+	}
+	if bid.Status != e.BidStatusUnderReview {
+		return nil
+	}
+	if err := s.bidRepo.UpdateStatus(ctx, bid.BidID, e.BidStatusPending); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotUpdateBid
+	}
+
+	event := bidPlacedEvent(bid)
+	if err := s.producer.Publish(ctx, s.bidTopic, bid.AuctionID, event); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		if processErr := s.ProcessBidEvent(ctx, event); processErr != nil {
+			log.Error(errutils.WrapPathErr(processErr))
+		}
+	}
+	log.Infof("Bid released from review [%s]", bid.BidID)
+
+	return nil
+}
+
+// withdrawBid moves a bid that is still in play to status, recomputing the
+// auction's winners and holds if the bid was accepted. It reports false when
+// the bid was already rejected or in status.
+func (s *BidService) withdrawBid(ctx context.Context, bidID string, status e.BidStatus) (e.Bid, bool, error) {
+	bid, err := s.bidRepo.GetByID(ctx, bidID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Bid{}, false, se.HandleRepoNotFound(err, se.ErrNotFoundBid, se.ErrCannotUpdateBid)
+	}
+
+	lockKey := fmt.Sprintf("lock:auction:%s", bid.AuctionID)
+	var lockVal string
+	err = s.retryer.Do(ctx, "acquire_auction_lock", func() error {
+		var e error
+		lockVal, e = s.acquireLock(ctx, lockKey, 5*time.Second)
+		return e
+	})
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Bid{}, false, se.ErrCannotUpdateBid
+	}
+	defer s.releaseLock(ctx, lockKey, lockVal)
+
+	// The bid may have been processed while we waited for the lock.
+	if bid, err = s.bidRepo.GetByID(ctx, bidID); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Bid{}, false, se.ErrCannotUpdateBid
+	}
+	if bid.Status == e.BidStatusRejected || bid.Status == status {
+		return bid, false, nil
+	}
+
+	auction, err := s.auctionRepo.GetByID(ctx, bid.AuctionID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Bid{}, false, se.HandleRepoNotFound(err, se.ErrNotFoundAuction, se.ErrCannotUpdateBid)
+	}
+	if auction.Status != e.AuctionStatusActive {
+		return e.Bid{}, false, se.ErrAuctionNotActive
+	}
+
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		before, err := s.WinningBids(ctx, auction)
+		if err != nil {
+			return err
+		}
+		if err := s.bidRepo.UpdateStatus(ctx, bid.BidID, status); err != nil {
+			return err
+		}
+		if bid.Status != e.BidStatusAccepted {
+			return nil
+		}
+
+		after, err := s.WinningBids(ctx, auction)
+		if err != nil {
+			return err
+		}
+		if err := s.auctionRepo.UpdateCurrentBid(ctx, bid.AuctionID, auction.ClearingPrice(after)); err != nil {
+			return err
+		}
+		_, err = s.rebalanceHolds(ctx, auction, before, after, "")
+		return err
+	})
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Bid{}, false, se.ErrCannotUpdateBid
+	}
+
+	cacheKey := fmt.Sprintf("auction:%s", bid.AuctionID)
+	s.redis.Del(ctx, cacheKey)
+
+	return bid, true, nil
+}
+
+func bidPlacedEvent(bid e.Bid) kd.BidPlacedEvent {
+	return kd.BidPlacedEvent{
+		BidID:     bid.BidID,
+		AuctionID: bid.AuctionID,
+		BidderID:  bid.BidderID,
+		Amount:    bid.Amount,
+		Currency:  string(bid.Amount.Currency),
+		Quantity:  bid.Quantity,
+		Timestamp: bid.CreatedAt,
+	}
+}
+
+// rebalanceHolds sets each winner's hold to the deposit for the units they win
+// in after and releases, and returns, the bidders who only won in before. A
+// shortfall is an error for strictBidder alone; any other winner who can no
+// longer cover their deposit is logged and keeps their units without it.
+// Suppliers in a reverse auction are paid rather than charged, so nothing is
+// held for them.
+func (s *BidService) rebalanceHolds(ctx context.Context, auction e.Auction, before, after []e.LotAllocation, strictBidder string) ([]string, error) {
+	winning := make(map[string]bool, len(after))
+	for _, a := range after {
+		winning[a.Bid.BidderID] = true
+	}
+
+	var released []string
+	for _, a := range before {
+		if !winning[a.Bid.BidderID] {
+			released = append(released, a.Bid.BidderID)
+		}
+	}
+	if auction.IsReverse() {
+		return released, nil
+	}
+
+	auctionID := auction.AuctionID
+	for _, bidderID := range released {
+		if err := setHold(ctx, s.walletRepo, bidderID, auctionID, money.Zero(money.DefaultCurrency)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range after {
+		hold, err := s.holdAmount(ctx, a.Bid.Amount.Mul(int64(a.Units)))
+		if err != nil {
+			return nil, err
+		}
+		err = setHold(ctx, s.walletRepo, a.Bid.BidderID, auctionID, hold)
+		if errors.Is(err, re.ErrInsufficientFunds) && a.Bid.BidderID != strictBidder {
+			log.Warnf("Cannot hold funds for bidder %s on auction %s", a.Bid.BidderID, auctionID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return released, nil
+}
+
+func (s *BidService) rejectBid(ctx context.Context, event kd.BidPlacedEvent, code e.BidRejectCode, reason string) {
 	s.bidRepo.UpdateStatus(ctx, event.BidID, e.BidStatusRejected)
-	s.publishResult(ctx, event, string(e.BidStatusRejected), reason)
+	s.publishResult(ctx, event, string(e.BidStatusRejected), code, reason, nil)
 	s.metrics.BidsRejected.Inc()
-	log.Infof("Bid rejected [%s]: %s", event.BidID, reason)
+	log.Infof("Bid rejected [%s] %s: %s", event.BidID, code, reason)
 }
 
-func (s *BidService) publishResult(ctx context.Context, event kd.BidPlacedEvent, status, reason string) {
+func (s *BidService) publishResult(ctx context.Context, event kd.BidPlacedEvent, status string, code e.BidRejectCode, reason string, outbid []string) {
 	result := kd.BidResultEvent{
 		BidID:     event.BidID,
 		AuctionID: event.AuctionID,
 		BidderID:  event.BidderID,
 		Amount:    event.Amount,
+		Currency:  string(event.Amount.Currency),
+		Quantity:  event.Quantity,
 		Status:    status,
+		Code:      string(code),
 		Reason:    reason,
+		Outbid:    outbid,
 	}
 	s.producer.Publish(ctx, s.resultTopic, event.AuctionID, result)
 }
 
-func (s *BidService) GetBidsByAuction(ctx context.Context, auctionID string, limit int) ([]e.Bid, error) {
+// GetBidsByAuction lists the bids of an auction the viewer can see.
+func (s *BidService) GetBidsByAuction(ctx context.Context, auctionID, viewerID string, limit int) ([]e.Bid, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
 
+	auction, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotGetBids)
+	}
+	if err := checkCanView(ctx, s.inviteRepo, auction.(e.Auction), viewerID); err != nil {
+		return nil, err
+	}
+
 	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
 		var bids []e.Bid
 		err := s.retryer.Do(ctx, "create_bid", func() error {
@@ -214,17 +528,64 @@ func (s *BidService) releaseLock(ctx context.Context, key, value string) {
 	unlockScript.Run(ctx, s.redis, []string{key}, value)
 }
 
-func (s *BidService) GetHighestBid(ctx context.Context, auctionID string) (e.Bid, error) {
-	bid, err := s.bidRepo.GetHighestByAuction(ctx, auctionID)
+// WinningBids allocates the auction's lot to the latest accepted bid of each
+// bidder, lowest bids first in a reverse auction.
+func (s *BidService) WinningBids(ctx context.Context, auction e.Auction) ([]e.LotAllocation, error) {
+	bids, err := s.bidRepo.ListActive(ctx, auction.AuctionID, auction.IsReverse())
 	if err != nil {
-		if errors.Is(err, re.ErrNotFound) {
-			return e.Bid{}, se.ErrNotFoundBid
-		}
-		return e.Bid{}, err
+		return nil, err
 	}
-	return bid, nil
+	return auction.Allocate(bids), nil
 }
 
 func (s *BidService) CountByAuction(ctx context.Context, auctionID string) (int, error) {
 	return s.bidRepo.CountByAuction(ctx, auctionID)
 }
+
+func (s *BidService) ListByBidder(ctx context.Context, bidderID string, page, pageSize int) ([]e.BidderAuction, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	type bidderPage struct {
+		auctions []e.BidderAuction
+		total    int64
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var r bidderPage
+		err := s.retryer.Do(ctx, "list_bidder_auctions", func() error {
+			var e error
+			r.auctions, r.total, e = s.bidRepo.ListAuctionsByBidder(ctx, bidderID, pageSize, offset)
+			return e
+		})
+		return r, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, 0, se.ErrCannotGetBids
+	}
+
+	r := result.(bidderPage)
+	for i := range r.auctions {
+		r.auctions[i].Outcome = bidOutcome(r.auctions[i], bidderID)
+		if r.auctions[i].Outcome != e.BidOutcomeInProgress {
+			r.auctions[i].IsLeading = r.auctions[i].Outcome == e.BidOutcomeWon
+		}
+	}
+	return r.auctions, r.total, nil
+}
+
+func bidOutcome(a e.BidderAuction, bidderID string) e.BidOutcome {
+	if a.AuctionStatus != e.AuctionStatusFinished {
+		return e.BidOutcomeInProgress
+	}
+	if a.WinnerID == bidderID || a.HasWon {
+		return e.BidOutcomeWon
+	}
+	return e.BidOutcomeLost
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type BlocklistService struct {
+	banRepo repo.Bans
+	breaker *circuitbreaker.CircuitBreaker
+	retryer *retry.Retryer
+}
+
+func NewBlocklistService(
+	banRepo repo.Bans,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *BlocklistService {
+	return &BlocklistService{
+		banRepo: banRepo,
+		breaker: breaker,
+		retryer: retryer,
+	}
+}
+
+// BlockBidder keeps a bidder off all of the seller's auctions. Blocking the
+// same bidder again only updates the reason.
+func (s *BlocklistService) BlockBidder(ctx context.Context, in sd.BlockBidderInput) (e.BlockedBidder, error) {
+	if in.SellerID == in.BidderID {
+		return e.BlockedBidder{}, se.ErrCannotBlockSelf
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var blocked e.BlockedBidder
+		err := s.retryer.Do(ctx, "block_bidder", func() error {
+			var e error
+			blocked, e = s.banRepo.Block(ctx, rd.BlockBidderInput{
+				SellerID: in.SellerID,
+				BidderID: in.BidderID,
+				Reason:   in.Reason,
+			})
+			return e
+		})
+		return blocked, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.BlockedBidder{}, se.ErrCannotBlockBidder
+	}
+
+	blocked := result.(e.BlockedBidder)
+	log.Infof("Bidder [%s] blocked by seller %s: %s", blocked.BidderID, blocked.SellerID, blocked.Reason)
+	return blocked, nil
+}
+
+func (s *BlocklistService) UnblockBidder(ctx context.Context, sellerID, bidderID string) error {
+	_, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "unblock_bidder", func() error {
+			return s.banRepo.Unblock(ctx, sellerID, bidderID)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundBlockedBidder, se.ErrCannotUnblockBidder)
+	}
+	return nil
+}
+
+func (s *BlocklistService) ListBlocked(ctx context.Context, sellerID string) ([]e.BlockedBidder, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var blocked []e.BlockedBidder
+		err := s.retryer.Do(ctx, "list_blocked_bidders", func() error {
+			var e error
+			blocked, e = s.banRepo.ListBlocked(ctx, sellerID)
+			return e
+		})
+		return blocked, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetBlockedBidders
+	}
+
+	blocked, _ := result.([]e.BlockedBidder)
+	return blocked, nil
+}
@@ -0,0 +1,25 @@
+package servdto
+
+import e "auction-platform/internal/entity"
+
+type SetVisibilityInput struct {
+	SellerID   string
+	AuctionID  string
+	Visibility e.AuctionVisibility
+}
+
+type UpdateAllowlistInput struct {
+	SellerID      string
+	AuctionID     string
+	AddBidders    []string
+	RemoveBidders []string
+	AddGroups     []int64
+	RemoveGroups  []int64
+}
+
+type UpdateGroupMembersInput struct {
+	SellerID string
+	GroupID  int64
+	Add      []string
+	Remove   []string
+}
@@ -0,0 +1,13 @@
+package servdto
+
+type CreateAPIKeyInput struct {
+	OwnerID string
+	Name    string
+	Scopes  []string
+}
+
+type SetAPIKeyQuotaInput struct {
+	KeyID int64
+	RPS   float64
+	Burst int
+}
@@ -1,11 +1,45 @@
 package servdto
 
+import (
+	e "auction-platform/internal/entity"
+	"auction-platform/pkg/money"
+)
+
 type CreateAuctionInput struct {
 	AuctionID   string
 	Title       string
 	Description string
+	Category    string
 	SellerID    string
-	StartPrice  float64
-	MinStep     float64
+	StartPrice  money.Money
+	MinStep     money.Money
+	Currency    money.Currency
+	Increments  e.IncrementTable
+	Quantity    int
+	Pricing     e.LotPricing
+	Type        e.AuctionType
+	Visibility  e.AuctionVisibility
+	Eligibility e.EligibilityRules
+	Invited     []string
 	DurationMin int
+	// ReservePrice is zero for an auction without a reserve.
+	ReservePrice money.Money
+	Relist       e.RelistPolicy
+	ClientIP     string
+	DeviceID     string
+}
+
+// GetAuctionInput.ViewerID is empty for anonymous viewers.
+type GetAuctionInput struct {
+	AuctionID string
+	ViewerID  string
+	Currency  money.Currency
+}
+
+type ListAuctionsInput struct {
+	Page     int
+	PageSize int
+	Currency money.Currency
+	MinPrice *money.Money
+	MaxPrice *money.Money
 }
@@ -1,8 +1,13 @@
 package servdto
 
+import "auction-platform/pkg/money"
+
 type PlaceBidInput struct {
 	BidID     string
 	AuctionID string
 	BidderID  string
-	Amount    float64
+	Amount    money.Money
+	Quantity  int
+	ClientIP  string
+	DeviceID  string
 }
@@ -0,0 +1,7 @@
+package servdto
+
+type BlockBidderInput struct {
+	SellerID string
+	BidderID string
+	Reason   string
+}
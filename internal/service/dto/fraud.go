@@ -0,0 +1,10 @@
+package servdto
+
+// ReviewFraudFlagInput confirms the flag, rejecting the bid, or dismisses it,
+// releasing the bid if it was held.
+type ReviewFraudFlagInput struct {
+	FlagID     int64
+	ReviewerID string
+	Confirm    bool
+	Note       string
+}
@@ -0,0 +1,17 @@
+package servdto
+
+// ImportRow.Error carries a parse or validation failure found before the row
+// reached the service; such rows are reported as invalid.
+type ImportRow struct {
+	Line    int
+	Auction CreateAuctionInput
+	Error   string
+}
+
+type ImportAuctionsInput struct {
+	SellerID string
+	Rows     []ImportRow
+	DryRun   bool
+	ClientIP string
+	DeviceID string
+}
@@ -0,0 +1,18 @@
+package servdto
+
+import "io"
+
+type UploadMediaInput struct {
+	AuctionID string
+	SellerID  string
+	FileName  string
+	File      io.Reader
+}
+
+// ReorderMediaInput.MediaIDs must list every media item of the auction, in
+// the new display order.
+type ReorderMediaInput struct {
+	AuctionID string
+	SellerID  string
+	MediaIDs  []int64
+}
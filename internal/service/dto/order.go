@@ -0,0 +1,18 @@
+package servdto
+
+import "auction-platform/pkg/money"
+
+type CreateWinOrderInput struct {
+	AuctionID string
+	BuyerID   string
+	SellerID  string
+	Amount    money.Money
+	Quantity  int
+}
+
+type ListOrdersInput struct {
+	UserID   string
+	AsSeller bool
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,14 @@
+package servdto
+
+type AskQuestionInput struct {
+	AuctionID string
+	AskerID   string
+	Question  string
+}
+
+type AnswerQuestionInput struct {
+	QuestionID int64
+	SellerID   string
+	Answer     string
+	Public     bool
+}
@@ -0,0 +1,11 @@
+package servdto
+
+// RateInput.RateeID may be left empty by buyers, who always rate the seller,
+// and by a seller whose auction has a single buyer.
+type RateInput struct {
+	AuctionID string
+	RaterID   string
+	RateeID   string
+	Score     int
+	Comment   string
+}
@@ -0,0 +1,21 @@
+package servdto
+
+import e "auction-platform/internal/entity"
+
+// ListRegistrationsInput.Status is optional. Reviewers other than admins only
+// see registrations for their own auctions.
+type ListRegistrationsInput struct {
+	ReviewerID string
+	IsAdmin    bool
+	AuctionID  string
+	Status     e.RegistrationStatus
+}
+
+type ReviewRegistrationInput struct {
+	ReviewerID string
+	IsAdmin    bool
+	AuctionID  string
+	BidderID   string
+	Approve    bool
+	Reason     string
+}
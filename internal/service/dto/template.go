@@ -0,0 +1,19 @@
+package servdto
+
+// SaveTemplateInput.Auction carries the settings; its AuctionID and SellerID
+// are ignored.
+type SaveTemplateInput struct {
+	SellerID string
+	Name     string
+	Auction  CreateAuctionInput
+}
+
+// LaunchTemplateInput.DurationMin overrides the template's duration when set.
+type LaunchTemplateInput struct {
+	SellerID    string
+	TemplateID  int64
+	AuctionID   string
+	DurationMin int
+	ClientIP    string
+	DeviceID    string
+}
@@ -0,0 +1,11 @@
+package servdto
+
+import "time"
+
+// BanUserInput.ExpiresAt is nil for a permanent ban.
+type BanUserInput struct {
+	UserID    string
+	Reason    string
+	BannedBy  string
+	ExpiresAt *time.Time
+}
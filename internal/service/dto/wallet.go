@@ -0,0 +1,16 @@
+package servdto
+
+import "auction-platform/pkg/money"
+
+type SettleAuctionInput struct {
+	AuctionID string
+	Winners   []SettleWinner
+	SellerID  string
+}
+
+// SettleWinner.Amount is what the winner owes for their allocation, in the
+// auction's currency.
+type SettleWinner struct {
+	BidderID string
+	Amount   money.Money
+}
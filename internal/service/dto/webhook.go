@@ -0,0 +1,7 @@
+package servdto
+
+type CreateWebhookInput struct {
+	OwnerID    string
+	URL        string
+	EventTypes []string
+}
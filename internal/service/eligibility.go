@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/repo"
+	re "auction-platform/internal/repo/errors"
+	se "auction-platform/internal/service/errors"
+)
+
+// EligibilityRule is one condition a bidder must meet before their bid is
+// considered. Check reports false when the bidder fails it, and the bid is
+// rejected with Code and Err; a returned error means the rule could not be
+// evaluated.
+type EligibilityRule struct {
+	Code  e.BidRejectCode
+	Err   error
+	Check func(ctx context.Context, auction e.Auction, bidderID string) (bool, error)
+}
+
+// DefaultEligibilityRules checks, in order, that the bidder is not banned or
+// blocked by the seller, is registered where the auction requires it, has had
+// an account for long enough and is not already winning too many other
+// auctions.
+func DefaultEligibilityRules(
+	banRepo repo.Bans,
+	registrationRepo repo.Registrations,
+	walletRepo repo.Wallets,
+	bidRepo repo.Bids,
+) []EligibilityRule {
+	return []EligibilityRule{
+		NotBannedRule(banRepo),
+		NotBlockedRule(banRepo),
+		RegisteredRule(registrationRepo),
+		MinAccountAgeRule(walletRepo),
+		MaxWinningAuctionsRule(bidRepo),
+	}
+}
+
+func NotBannedRule(banRepo repo.Bans) EligibilityRule {
+	return EligibilityRule{
+		Code: e.BidRejectBanned,
+		Err:  se.ErrUserBanned,
+		Check: func(ctx context.Context, _ e.Auction, bidderID string) (bool, error) {
+			banned, err := banRepo.IsBanned(ctx, bidderID)
+			return !banned, err
+		},
+	}
+}
+
+func NotBlockedRule(banRepo repo.Bans) EligibilityRule {
+	return EligibilityRule{
+		Code: e.BidRejectBlockedBySeller,
+		Err:  se.ErrBlockedBySeller,
+		Check: func(ctx context.Context, auction e.Auction, bidderID string) (bool, error) {
+			blocked, err := banRepo.IsBlocked(ctx, auction.SellerID, bidderID)
+			return !blocked, err
+		},
+	}
+}
+
+func RegisteredRule(registrationRepo repo.Registrations) EligibilityRule {
+	return EligibilityRule{
+		Code: e.BidRejectNotRegistered,
+		Err:  se.ErrNotRegistered,
+		Check: func(ctx context.Context, auction e.Auction, bidderID string) (bool, error) {
+			if !auction.Eligibility.RequireRegistration {
+				return true, nil
+			}
+			return registrationRepo.IsApproved(ctx, auction.AuctionID, bidderID)
+		},
+	}
+}
+
+// MinAccountAgeRule dates a bidder's account from their first wallet account,
+// the earliest record the platform keeps of a user, so it measures wallet age
+// rather than the age of the login. Bidders without a wallet fail it.
+func MinAccountAgeRule(walletRepo repo.Wallets) EligibilityRule {
+	return EligibilityRule{
+		Code: e.BidRejectAccountTooNew,
+		Err:  se.ErrAccountTooNew,
+		Check: func(ctx context.Context, auction e.Auction, bidderID string) (bool, error) {
+			minAge := auction.Eligibility.MinAccountAge()
+			if minAge <= 0 {
+				return true, nil
+			}
+			openedAt, err := walletRepo.FirstAccountAt(ctx, bidderID)
+			if errors.Is(err, re.ErrNotFound) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			return time.Since(openedAt) >= minAge, nil
+		},
+	}
+}
+
+func MaxWinningAuctionsRule(bidRepo repo.Bids) EligibilityRule {
+	return EligibilityRule{
+		Code: e.BidRejectTooManyWinning,
+		Err:  se.ErrTooManyWinning,
+		Check: func(ctx context.Context, auction e.Auction, bidderID string) (bool, error) {
+			limit := auction.Eligibility.MaxWinningAuctions
+			if limit <= 0 {
+				return true, nil
+			}
+			leading, err := bidRepo.CountLeading(ctx, bidderID, auction.AuctionID)
+			return leading < limit, err
+		},
+	}
+}
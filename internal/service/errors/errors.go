@@ -5,6 +5,10 @@ import "errors"
 var (
 	ErrNotFoundAuction = errors.New("auction not found")
 	ErrNotFoundBid     = errors.New("bid not found")
+	ErrNotFoundWatch   = errors.New("auction is not in watchlist")
+	ErrNotFoundWebhook = errors.New("webhook not found")
+	ErrNotFoundBan     = errors.New("ban not found")
+	ErrNotFoundAPIKey  = errors.New("api key not found")
 
 	ErrCannotCreateAuction = errors.New("cannot create auction")
 	ErrCannotGetAuction    = errors.New("cannot get auction")
@@ -14,9 +18,139 @@ var (
 	ErrCannotGetBids       = errors.New("cannot get bids")
 	ErrCannotPublishEvent  = errors.New("cannot publish event")
 
+	ErrCannotWatchAuction        = errors.New("cannot add auction to watchlist")
+	ErrCannotUnwatchAuction      = errors.New("cannot remove auction from watchlist")
+	ErrCannotGetWatchlist        = errors.New("cannot get watchlist")
+	ErrCannotGetNotifications    = errors.New("cannot get notifications")
+	ErrCannotUpdateNotifications = errors.New("cannot update notifications")
+	ErrCannotCreateWebhook       = errors.New("cannot create webhook")
+	ErrCannotDeleteWebhook       = errors.New("cannot delete webhook")
+	ErrCannotGetWebhooks         = errors.New("cannot get webhooks")
+	ErrCannotUpdateAuction       = errors.New("cannot update auction")
+	ErrCannotBanUser             = errors.New("cannot ban user")
+	ErrCannotUnbanUser           = errors.New("cannot unban user")
+	ErrCannotGetBans             = errors.New("cannot get bans")
+	ErrCannotCheckBan            = errors.New("cannot check user ban")
+	ErrCannotCreateAPIKey        = errors.New("cannot create api key")
+	ErrCannotGetAPIKeys          = errors.New("cannot get api keys")
+	ErrCannotUpdateAPIKey        = errors.New("cannot update api key")
+	ErrCannotVerifyAPIKey        = errors.New("cannot verify api key")
+	ErrCannotGetWallet           = errors.New("cannot get wallet")
+	ErrCannotUpdateWallet        = errors.New("cannot update wallet")
+	ErrCannotSettleAuction       = errors.New("cannot settle auction")
+	ErrCannotCreateOrder         = errors.New("cannot create order")
+	ErrCannotGetOrders           = errors.New("cannot get orders")
+	ErrCannotUpdateOrder         = errors.New("cannot update order")
+	ErrCannotGetStatement        = errors.New("cannot get statement")
+	ErrCannotGetFXRates          = errors.New("cannot get fx rates")
+	ErrCannotUpdateFXRates       = errors.New("cannot update fx rates")
+
 	ErrAuctionAlreadyExists = errors.New("auction already exists")
+	ErrBidAlreadyExists     = errors.New("bid already exists")
 	ErrAuctionNotActive     = errors.New("auction is not active")
 	ErrAuctionEnded         = errors.New("auction has ended")
 	ErrBidTooLow            = errors.New("bid is too low")
 	ErrSellerCannotBid      = errors.New("seller cannot bid on own auction")
+	ErrUserBanned           = errors.New("user is banned")
+	ErrInvalidAPIKey        = errors.New("invalid api key")
+	ErrInsufficientFunds    = errors.New("insufficient funds")
+	ErrInvalidAmount        = errors.New("amount is not valid for the currency")
+
+	ErrNotFoundOrder      = errors.New("order not found")
+	ErrOrderAlreadyExists = errors.New("order already exists")
+	ErrOrderNotPayable    = errors.New("order is not awaiting payment")
+	ErrOfferNotOpen       = errors.New("second-chance offer is not open")
+	ErrInvalidPeriod      = errors.New("period start must be before its end")
+
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	ErrNoFXRate            = errors.New("no fx rate for currency")
+	ErrInvalidFXRate       = errors.New("fx rate must be positive")
+
+	ErrNoBidIncrement    = errors.New("min_step or an increment table is required")
+	ErrInvalidIncrements = errors.New("increment tiers must have ascending bounds, positive steps and end with an open tier")
+
+	ErrInvalidQuantity = errors.New("quantity must be between 1 and the lot size")
+	ErrInvalidPricing  = errors.New("pricing must be PAY_AS_BID or UNIFORM")
+
+	ErrInvalidAuctionType = errors.New("type must be FORWARD or REVERSE")
+	ErrBidTooHigh         = errors.New("bid is too high")
+	ErrNotInvited         = errors.New("bidder is not invited to this auction")
+
+	ErrNotFoundGroup         = errors.New("bidder group not found")
+	ErrGroupAlreadyExists    = errors.New("bidder group already exists")
+	ErrInvalidVisibility     = errors.New("visibility must be PUBLIC, UNLISTED or PRIVATE")
+	ErrCannotGetAllowlist    = errors.New("cannot get allowlist")
+	ErrCannotUpdateAllowlist = errors.New("cannot update allowlist")
+
+	ErrNotRegistered             = errors.New("bidder is not approved for this auction")
+	ErrAccountTooNew             = errors.New("bidder wallet was opened too recently for this auction")
+	ErrTooManyWinning            = errors.New("bidder is already winning the maximum number of auctions")
+	ErrInvalidEligibility        = errors.New("eligibility rules must not be negative and a deposit requires registration")
+	ErrRegistrationNotRequired   = errors.New("auction does not require registration")
+	ErrRegistrationAlreadyExists = errors.New("bidder has already registered for this auction")
+	ErrNotFoundRegistration      = errors.New("registration not found")
+	ErrRegistrationNotPending    = errors.New("registration has already been reviewed")
+	ErrCannotRegister            = errors.New("cannot register for auction")
+	ErrCannotGetRegistrations    = errors.New("cannot get registrations")
+	ErrCannotReviewRegistration  = errors.New("cannot review registration")
+
+	ErrNotFoundFraudFlag     = errors.New("fraud flag not found")
+	ErrFraudFlagNotOpen      = errors.New("fraud flag has already been reviewed")
+	ErrInvalidFraudStatus    = errors.New("status must be OPEN, CONFIRMED or DISMISSED")
+	ErrCannotGetFraudFlags   = errors.New("cannot get fraud flags")
+	ErrCannotReviewFraudFlag = errors.New("cannot review fraud flag")
+
+	ErrBlockedBySeller         = errors.New("bidder is blocked by this seller")
+	ErrCannotBlockSelf         = errors.New("sellers cannot block themselves")
+	ErrInvalidBanExpiry        = errors.New("ban expiry must be in the future")
+	ErrNotFoundBlockedBidder   = errors.New("blocked bidder not found")
+	ErrCannotBlockBidder       = errors.New("cannot block bidder")
+	ErrCannotUnblockBidder     = errors.New("cannot unblock bidder")
+	ErrCannotGetBlockedBidders = errors.New("cannot get blocked bidders")
+
+	ErrInvalidRatingScore = errors.New("rating score must be between 1 and 5")
+	ErrAuctionNotSold     = errors.New("auction has not finished with a winner")
+	ErrNotTradeParty      = errors.New("only the seller and buyers of an auction can rate each other")
+	ErrRateeRequired      = errors.New("user_id is required when the rater traded with several users on the auction")
+	ErrAlreadyRated       = errors.New("user has already been rated for this auction")
+	ErrCannotRate         = errors.New("cannot save rating")
+	ErrCannotGetProfile   = errors.New("cannot get user profile")
+
+	ErrSelfRating = errors.New("users cannot rate themselves")
+
+	ErrNotFoundQuestion        = errors.New("question not found")
+	ErrSellerCannotAsk         = errors.New("sellers cannot ask questions on their own auction")
+	ErrQuestionAlreadyAnswered = errors.New("question has already been answered")
+	ErrCannotAskQuestion       = errors.New("cannot save question")
+	ErrCannotAnswerQuestion    = errors.New("cannot answer question")
+	ErrCannotGetQuestions      = errors.New("cannot get questions")
+
+	ErrNotFoundMedia        = errors.New("media not found")
+	ErrEmptyMedia           = errors.New("uploaded file is empty")
+	ErrMediaTooLarge        = errors.New("uploaded file is too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrInvalidMedia         = errors.New("uploaded image cannot be decoded")
+	ErrTooManyMedia         = errors.New("auction has reached its media limit")
+	ErrInvalidMediaOrder    = errors.New("media order must list every media item of the auction exactly once")
+	ErrAuctionHasActivity   = errors.New("auctions with bids or registrations cannot be deleted")
+	ErrCannotUploadMedia    = errors.New("cannot upload media")
+	ErrCannotDeleteMedia    = errors.New("cannot delete media")
+	ErrCannotUpdateMedia    = errors.New("cannot update media")
+	ErrCannotDeleteAuction  = errors.New("cannot delete auction")
+
+	ErrEmptyImport          = errors.New("import contains no rows")
+	ErrTooManyImportRows    = errors.New("import has more rows than allowed")
+	ErrDuplicateImportID    = errors.New("auction_id appears more than once in the import")
+	ErrImportBatchFailed    = errors.New("batch was rolled back")
+	ErrCannotImportAuctions = errors.New("cannot import auctions")
+
+	ErrInvalidRelistPolicy  = errors.New("relist policy allows at most 52 relistings and a 90% price cut")
+	ErrNotRelistable        = errors.New("auction cannot be relisted")
+	ErrCannotRelistAuction  = errors.New("cannot relist auction")
+	ErrNotFoundTemplate     = errors.New("template not found")
+	ErrCannotSaveTemplate   = errors.New("cannot save template")
+	ErrCannotGetTemplates   = errors.New("cannot get templates")
+	ErrCannotDeleteTemplate = errors.New("cannot delete template")
+
+	ErrInvalidWebhookURL = errors.New("webhook URL must use https and resolve only to public addresses")
 )
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	kd "auction-platform/internal/infrastruct/kafka/dto"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultFraudRejectReason = "rejected after fraud review"
+
+type FraudService struct {
+	auctionRepo repo.Auctions
+	fraudRepo   repo.Fraud
+	bids        Bids
+	breaker     *circuitbreaker.CircuitBreaker
+	retryer     *retry.Retryer
+	policy      e.FraudPolicy
+}
+
+func NewFraudService(
+	aRepo repo.Auctions,
+	fraudRepo repo.Fraud,
+	bids Bids,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+	policy e.FraudPolicy,
+) *FraudService {
+	return &FraudService{
+		auctionRepo: aRepo,
+		fraudRepo:   fraudRepo,
+		bids:        bids,
+		breaker:     breaker,
+		retryer:     retryer,
+		policy:      policy,
+	}
+}
+
+// HandleBidPlaced scores a placed bid against its seller and flags it for
+// review when the score is high enough, holding it back from the auction if
+// the policy says so. A bid is only ever flagged once.
+func (s *FraudService) HandleBidPlaced(ctx context.Context, event kd.BidPlacedEvent) error {
+	auction, err := s.auctionRepo.GetByID(ctx, event.AuctionID)
+	if err != nil {
+		if errors.Is(err, re.ErrNotFound) {
+			return nil
+		}
+		return errutils.WrapPathErr(err)
+	}
+	if event.BidderID == auction.SellerID {
+		return nil
+	}
+
+	var shared bool
+	if event.ClientIP != "" || event.DeviceID != "" {
+		if err := s.fraudRepo.RecordDevice(ctx, event.BidderID, event.ClientIP, event.DeviceID); err != nil {
+			return errutils.WrapPathErr(err)
+		}
+		if shared, err = s.fraudRepo.SharesDevice(ctx, auction.SellerID, event.ClientIP, event.DeviceID); err != nil {
+			return errutils.WrapPathErr(err)
+		}
+	}
+
+	stats, err := s.fraudRepo.BidderSellerStats(ctx, event.BidderID, auction.SellerID)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+
+	signals := s.policy.Signals(stats, shared)
+	score := s.policy.Score(signals)
+	if !s.policy.ShouldFlag(score) {
+		return nil
+	}
+
+	hold := s.policy.ShouldHold(score)
+	_, err = s.fraudRepo.CreateFlag(ctx, rd.CreateFraudFlagInput{
+		BidID:     event.BidID,
+		AuctionID: event.AuctionID,
+		BidderID:  event.BidderID,
+		SellerID:  auction.SellerID,
+		Score:     score,
+		Signals:   signals,
+		Held:      hold,
+	})
+	if errors.Is(err, re.ErrAlreadyExists) {
+		return nil
+	}
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	log.Warnf("Bid [%s] flagged for review: score %d, signals %v", event.BidID, score, signals)
+
+	if !hold {
+		return nil
+	}
+	reason := fmt.Sprintf("bid is under review (score %d)", score)
+	if err := s.bids.HoldBid(ctx, event.BidID, reason); err != nil && !errors.Is(err, se.ErrAuctionNotActive) {
+		log.Errorf("Cannot hold flagged bid %s: %v", event.BidID, err)
+	}
+	return nil
+}
+
+// HandleBidResult records retractions. Bidders cannot withdraw their own bids,
+// so the bids an administrator rejected after acceptance are what count.
+func (s *FraudService) HandleBidResult(ctx context.Context, event kd.BidResultEvent) error {
+	if event.Status != string(e.BidStatusRejected) || event.Code != string(e.BidRejectByAdmin) {
+		return nil
+	}
+	if err := s.fraudRepo.RecordRetraction(ctx, event.BidID, event.AuctionID, event.BidderID); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+// ListFlags returns the review queue, all flags when status is empty.
+func (s *FraudService) ListFlags(ctx context.Context, status e.FraudFlagStatus, page, pageSize int) ([]e.FraudFlag, int64, error) {
+	if status != "" && !status.IsValid() {
+		return nil, 0, se.ErrInvalidFraudStatus
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	type flagPage struct {
+		flags []e.FraudFlag
+		total int64
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var p flagPage
+		err := s.retryer.Do(ctx, "list_fraud_flags", func() error {
+			var e error
+			p.flags, p.total, e = s.fraudRepo.ListFlags(ctx, status, pageSize, offset)
+			return e
+		})
+		return p, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, 0, se.ErrCannotGetFraudFlags
+	}
+
+	p := result.(flagPage)
+	return p.flags, p.total, nil
+}
+
+// ReviewFlag settles the bid before closing the flag, so a failed review can
+// simply be retried.
+func (s *FraudService) ReviewFlag(ctx context.Context, in sd.ReviewFraudFlagInput) (e.FraudFlag, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var flag e.FraudFlag
+		err := s.retryer.Do(ctx, "get_fraud_flag", func() error {
+			var e error
+			flag, e = s.fraudRepo.GetFlag(ctx, in.FlagID)
+			return e
+		})
+		return flag, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.FraudFlag{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundFraudFlag, se.ErrCannotReviewFraudFlag)
+	}
+
+	flag := result.(e.FraudFlag)
+	if flag.Status != e.FraudFlagOpen {
+		return e.FraudFlag{}, se.ErrFraudFlagNotOpen
+	}
+
+	status := e.FraudFlagDismissed
+	if in.Confirm {
+		status = e.FraudFlagConfirmed
+		reason := in.Note
+		if reason == "" {
+			reason = defaultFraudRejectReason
+		}
+		if err := s.bids.RejectBid(ctx, flag.BidID, reason); err != nil && !errors.Is(err, se.ErrAuctionNotActive) {
+			return e.FraudFlag{}, err
+		}
+	} else if flag.Held {
+		if err := s.bids.ReleaseHeldBid(ctx, flag.BidID); err != nil {
+			return e.FraudFlag{}, err
+		}
+	}
+
+	result, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		var flag e.FraudFlag
+		err := s.retryer.Do(ctx, "review_fraud_flag", func() error {
+			var e error
+			flag, e = s.fraudRepo.ReviewFlag(ctx, rd.ReviewFraudFlagInput{
+				FlagID:     in.FlagID,
+				Status:     status,
+				ReviewedBy: in.ReviewerID,
+				Note:       in.Note,
+			})
+			return e
+		})
+		return flag, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.FraudFlag{}, se.HandleRepoNotFound(cbErr, se.ErrFraudFlagNotOpen, se.ErrCannotReviewFraudFlag)
+	}
+
+	flag = result.(e.FraudFlag)
+	log.Infof("Fraud flag %d on bid [%s] %s by %s", flag.FlagID, flag.BidID, flag.Status, in.ReviewerID)
+	return flag, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type FXService struct {
+	fxRepo  repo.FXRates
+	breaker *circuitbreaker.CircuitBreaker
+	retryer *retry.Retryer
+}
+
+func NewFXService(
+	fxRepo repo.FXRates,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *FXService {
+	return &FXService{
+		fxRepo:  fxRepo,
+		breaker: breaker,
+		retryer: retryer,
+	}
+}
+
+func (s *FXService) ListRates(ctx context.Context) ([]e.FXRate, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var rates []e.FXRate
+		err := s.retryer.Do(ctx, "list_fx_rates", func() error {
+			var e error
+			rates, e = s.fxRepo.List(ctx)
+			return e
+		})
+		return rates, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetFXRates
+	}
+
+	rates, _ := result.([]e.FXRate)
+	return rates, nil
+}
+
+// SetRates stores rates against money.DefaultCurrency, whose own rate is
+// always 1.
+func (s *FXService) SetRates(ctx context.Context, rates map[money.Currency]float64) ([]e.FXRate, error) {
+	input := make([]e.FXRate, 0, len(rates))
+	for c, rate := range rates {
+		if !c.IsSupported() {
+			return nil, se.ErrUnsupportedCurrency
+		}
+		if rate <= 0 || (c == money.DefaultCurrency && rate != 1) {
+			return nil, se.ErrInvalidFXRate
+		}
+		input = append(input, e.FXRate{Currency: c, Rate: rate})
+	}
+
+	_, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "set_fx_rates", func() error {
+			return s.fxRepo.Upsert(ctx, input)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotUpdateFXRates
+	}
+
+	log.Infof("FX rates updated: %d currencies", len(input))
+	return s.ListRates(ctx)
+}
+
+type fxRatesFile struct {
+	Base  money.Currency             `json:"base"`
+	Rates map[money.Currency]float64 `json:"rates"`
+}
+
+// LoadRatesFile stores the rates from a JSON file of the form
+// {"base": "USD", "rates": {"EUR": 1.08}}.
+func (s *FXService) LoadRatesFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+
+	var file fxRatesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if file.Base != "" && file.Base != money.DefaultCurrency {
+		return fmt.Errorf("fx rates in %s must be based on %s, got %s", path, money.DefaultCurrency, file.Base)
+	}
+
+	if _, err := s.SetRates(ctx, file.Rates); err != nil {
+		return fmt.Errorf("fx rates in %s: %w", path, err)
+	}
+	return nil
+}
+
+func loadFXTable(ctx context.Context, fxRepo repo.FXRates) (e.FXTable, error) {
+	rates, err := fxRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return e.NewFXTable(rates), nil
+}
+
+// convertMoney converts m into currency to at the current rate.
+func convertMoney(ctx context.Context, fxRepo repo.FXRates, m money.Money, to money.Currency) (money.Money, float64, error) {
+	if m.Currency == to {
+		return m, 1, nil
+	}
+	table, err := loadFXTable(ctx, fxRepo)
+	if err != nil {
+		return money.Money{}, 0, err
+	}
+	rate, ok := table.Rate(m.Currency, to)
+	if !ok {
+		return money.Money{}, 0, se.ErrNoFXRate
+	}
+	return m.Convert(to, rate), rate, nil
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	e "auction-platform/internal/entity"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type ImportService struct {
+	auctions  *AuctionService
+	batchSize int
+	maxRows   int
+}
+
+func NewImportService(auctions *AuctionService, batchSize, maxRows int) *ImportService {
+	return &ImportService{
+		auctions:  auctions,
+		batchSize: max(batchSize, 1),
+		maxRows:   maxRows,
+	}
+}
+
+// ImportAuctions checks every row the way CreateAuction would and, unless
+// it is a dry run, creates the valid ones in transactional batches. A row
+// that fails to insert rolls back the rest of its batch, but other batches
+// go ahead.
+func (s *ImportService) ImportAuctions(ctx context.Context, in sd.ImportAuctionsInput) (e.ImportReport, error) {
+	if len(in.Rows) == 0 {
+		return e.ImportReport{}, se.ErrEmptyImport
+	}
+	if len(in.Rows) > s.maxRows {
+		return e.ImportReport{}, se.ErrTooManyImportRows
+	}
+	if err := checkNotBanned(ctx, s.auctions.banRepo, in.SellerID); err != nil {
+		return e.ImportReport{}, err
+	}
+
+	report := e.ImportReport{DryRun: in.DryRun, Rows: make([]e.ImportRowResult, len(in.Rows))}
+	inputs := make([]sd.CreateAuctionInput, len(in.Rows))
+	firstLine := make(map[string]int, len(in.Rows))
+	fxChecked := make(map[money.Currency]error)
+	var valid []int
+
+	for i, row := range in.Rows {
+		res := &report.Rows[i]
+		res.Line, res.AuctionID, res.Status = row.Line, row.Auction.AuctionID, e.ImportRowInvalid
+
+		if row.Error != "" {
+			res.Error = row.Error
+			continue
+		}
+		if line, ok := firstLine[row.Auction.AuctionID]; ok {
+			res.Error = fmt.Sprintf("%s (first on line %d)", se.ErrDuplicateImportID, line)
+			continue
+		}
+		firstLine[row.Auction.AuctionID] = row.Line
+
+		auction := row.Auction
+		auction.SellerID = in.SellerID
+		if err := s.auctions.normalizeAuction(&auction); err != nil {
+			res.Error = err.Error()
+			continue
+		}
+		fxErr, ok := fxChecked[auction.Currency]
+		if !ok {
+			fxErr = s.auctions.checkFXRate(ctx, auction.Currency)
+			fxChecked[auction.Currency] = fxErr
+		}
+		if fxErr != nil {
+			res.Error = fxErr.Error()
+			continue
+		}
+
+		inputs[i] = auction
+		valid = append(valid, i)
+	}
+
+	valid, err := s.dropExisting(ctx, report.Rows, valid)
+	if err != nil {
+		return e.ImportReport{}, err
+	}
+
+	for _, i := range valid {
+		report.Rows[i].Status = e.ImportRowValid
+	}
+	if in.DryRun || len(valid) == 0 {
+		return report, nil
+	}
+
+	for start := 0; start < len(valid); start += s.batchSize {
+		s.createBatch(ctx, report.Rows, inputs, valid[start:min(start+s.batchSize, len(valid))])
+	}
+
+	created := report.Count(e.ImportRowCreated)
+	if created > 0 {
+		s.auctions.metrics.AuctionsCreated.Add(float64(created))
+		s.auctions.metrics.ActiveAuctions.Add(float64(created))
+		s.auctions.recordSellerDevice(ctx, in.SellerID, in.ClientIP, in.DeviceID)
+	}
+	log.Infof("Seller %s imported %d of %d auctions", in.SellerID, created, len(in.Rows))
+	return report, nil
+}
+
+// dropExisting marks rows whose auction ID is already taken as invalid and
+// returns the rest.
+func (s *ImportService) dropExisting(ctx context.Context, rows []e.ImportRowResult, valid []int) ([]int, error) {
+	if len(valid) == 0 {
+		return valid, nil
+	}
+	ids := make([]string, 0, len(valid))
+	for _, i := range valid {
+		ids = append(ids, rows[i].AuctionID)
+	}
+
+	result, cbErr := s.auctions.breaker.Execute("postgres", func() (any, error) {
+		var existing []string
+		err := s.auctions.retryer.Do(ctx, "check_auction_ids", func() error {
+			var e error
+			existing, e = s.auctions.auctionRepo.ExistingIDs(ctx, ids)
+			return e
+		})
+		return existing, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotImportAuctions
+	}
+
+	taken := make(map[string]struct{})
+	for _, id := range result.([]string) {
+		taken[id] = struct{}{}
+	}
+	kept := valid[:0]
+	for _, i := range valid {
+		if _, ok := taken[rows[i].AuctionID]; ok {
+			rows[i].Status, rows[i].Error = e.ImportRowInvalid, se.ErrAuctionAlreadyExists.Error()
+			continue
+		}
+		kept = append(kept, i)
+	}
+	return kept, nil
+}
+
+func (s *ImportService) createBatch(ctx context.Context, rows []e.ImportRowResult, inputs []sd.CreateAuctionInput, batch []int) {
+	failedAt := -1
+	_, cbErr := s.auctions.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.auctions.retryer.Do(ctx, "import_auctions", func() error {
+			return s.auctions.txManager.Do(ctx, func(ctx context.Context) error {
+				for n, i := range batch {
+					if _, err := s.auctions.insertAuction(ctx, inputs[i]); err != nil {
+						failedAt = n
+						return err
+					}
+				}
+				return nil
+			})
+		})
+	})
+	if cbErr == nil {
+		for _, i := range batch {
+			rows[i].Status = e.ImportRowCreated
+		}
+		return
+	}
+
+	log.Error(errutils.WrapPathErr(cbErr))
+	cause := se.ErrCannotCreateAuction
+	if errors.Is(cbErr, re.ErrAlreadyExists) {
+		cause = se.ErrAuctionAlreadyExists
+	}
+	for n, i := range batch {
+		rows[i].Status, rows[i].Error = e.ImportRowFailed, se.ErrImportBatchFailed.Error()
+		if n == failedAt {
+			rows[i].Error = cause.Error()
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	"auction-platform/pkg/money"
+)
+
+func transferFunds(
+	ctx context.Context,
+	walletRepo repo.Wallets,
+	fromOwner string, fromType e.AccountType,
+	toOwner string, toType e.AccountType,
+	amount money.Money, kind e.LedgerEntryKind, reference string,
+) error {
+	from, err := walletRepo.GetOrCreateAccount(ctx, fromOwner, fromType)
+	if err != nil {
+		return err
+	}
+	to, err := walletRepo.GetOrCreateAccount(ctx, toOwner, toType)
+	if err != nil {
+		return err
+	}
+
+	_, err = walletRepo.Transfer(ctx, rd.TransferInput{
+		FromAccountID: from.AccountID,
+		ToAccountID:   to.AccountID,
+		Amount:        amount,
+		Kind:          kind,
+		Reference:     reference,
+	})
+	return err
+}
+
+// releaseHolds returns every hold on reference to its owner, except those
+// belonging to keepOwners. Must run inside a transaction.
+func releaseHolds(ctx context.Context, walletRepo repo.Wallets, reference string, keepOwners ...string) error {
+	holds, err := walletRepo.ListHolds(ctx, reference)
+	if err != nil {
+		return err
+	}
+	for _, h := range holds {
+		if slices.Contains(keepOwners, h.OwnerID) {
+			continue
+		}
+		if err := setHold(ctx, walletRepo, h.OwnerID, reference, money.Zero(h.Amount.Currency)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setHold moves funds between the owner's available and held accounts so that
+// exactly target is held against reference. Must run inside a transaction.
+func setHold(ctx context.Context, walletRepo repo.Wallets, ownerID, reference string, target money.Money) error {
+	held, err := walletRepo.HeldAmount(ctx, ownerID, reference)
+	if err != nil {
+		return err
+	}
+
+	diff := target.Sub(held)
+	switch {
+	case diff.IsPositive():
+		// Checked up front so a shortfall does not abort the surrounding
+		// transaction; the balance constraint still guards concurrent holds.
+		available, err := walletRepo.GetOrCreateAccount(ctx, ownerID, e.AccountAvailable)
+		if err != nil {
+			return err
+		}
+		if available.Balance.Cmp(diff) < 0 {
+			return re.ErrInsufficientFunds
+		}
+		return transferFunds(ctx, walletRepo,
+			ownerID, e.AccountAvailable, ownerID, e.AccountHeld, diff, e.LedgerHold, reference)
+	case diff.IsNegative():
+		return transferFunds(ctx, walletRepo,
+			ownerID, e.AccountHeld, ownerID, e.AccountAvailable, diff.Neg(), e.LedgerRelease, reference)
+	}
+	return nil
+}
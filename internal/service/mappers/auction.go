@@ -11,13 +11,54 @@ import (
 func ToCreateAuctionRepoInput(in sd.CreateAuctionInput) rd.CreateAuctionInput {
 	endsAt := time.Now().UTC().Add(time.Duration(in.DurationMin) * time.Minute)
 	return rd.CreateAuctionInput{
-		AuctionID:   in.AuctionID,
-		Title:       in.Title,
-		Description: in.Description,
-		SellerID:    in.SellerID,
-		StartPrice:  in.StartPrice,
-		MinStep:     in.MinStep,
-		Status:      e.AuctionStatusActive,
-		EndsAt:      fmt.Sprintf("%s", endsAt.Format(time.RFC3339)),
+		AuctionID:    in.AuctionID,
+		Title:        in.Title,
+		Description:  in.Description,
+		Category:     in.Category,
+		SellerID:     in.SellerID,
+		StartPrice:   in.StartPrice,
+		MinStep:      in.MinStep,
+		Currency:     in.Currency,
+		Increments:   in.Increments,
+		Quantity:     in.Quantity,
+		Pricing:      in.Pricing,
+		Type:         in.Type,
+		Visibility:   in.Visibility,
+		Eligibility:  in.Eligibility,
+		Status:       e.AuctionStatusActive,
+		EndsAt:       fmt.Sprintf("%s", endsAt.Format(time.RFC3339)),
+		ReservePrice: in.ReservePrice,
+		DurationMin:  in.DurationMin,
+		Relist:       in.Relist,
+	}
+}
+
+// ToRelistAuctionRepoInput copies an unsold auction into a new one under
+// auctionID that runs as long as the original and starts at its relist
+// price.
+func ToRelistAuctionRepoInput(prev e.Auction, auctionID string) rd.CreateAuctionInput {
+	endsAt := time.Now().UTC().Add(time.Duration(prev.DurationMin) * time.Minute)
+	return rd.CreateAuctionInput{
+		AuctionID:    auctionID,
+		Title:        prev.Title,
+		Description:  prev.Description,
+		Category:     prev.Category,
+		SellerID:     prev.SellerID,
+		StartPrice:   prev.RelistStartPrice(),
+		MinStep:      prev.MinStep,
+		Currency:     prev.Currency,
+		Increments:   prev.Increments,
+		Quantity:     prev.Quantity,
+		Pricing:      prev.Pricing,
+		Type:         prev.Type,
+		Visibility:   prev.Visibility,
+		Eligibility:  prev.Eligibility,
+		Status:       e.AuctionStatusActive,
+		EndsAt:       endsAt.Format(time.RFC3339),
+		ReservePrice: prev.ReservePrice,
+		DurationMin:  prev.DurationMin,
+		Relist:       prev.Relist,
+		RelistCount:  prev.RelistCount + 1,
+		RelistedFrom: prev.AuctionID,
 	}
 }
@@ -12,6 +12,7 @@ func ToCreateBidRepoInput(in sd.PlaceBidInput) rd.CreateBidInput {
 		AuctionID: in.AuctionID,
 		BidderID:  in.BidderID,
 		Amount:    in.Amount,
+		Quantity:  in.Quantity,
 		Status:    e.BidStatusPending,
 	}
 }
@@ -0,0 +1,51 @@
+package servmappers
+
+import (
+	e "auction-platform/internal/entity"
+	sd "auction-platform/internal/service/dto"
+)
+
+func ToTemplateSettings(in sd.CreateAuctionInput) e.TemplateSettings {
+	return e.TemplateSettings{
+		Title:        in.Title,
+		Description:  in.Description,
+		Category:     in.Category,
+		StartPrice:   in.StartPrice,
+		MinStep:      in.MinStep,
+		ReservePrice: in.ReservePrice,
+		Currency:     in.Currency,
+		Increments:   in.Increments,
+		Quantity:     in.Quantity,
+		Pricing:      in.Pricing,
+		Type:         in.Type,
+		Visibility:   in.Visibility,
+		Eligibility:  in.Eligibility,
+		Invited:      in.Invited,
+		DurationMin:  in.DurationMin,
+		Relist:       in.Relist,
+	}
+}
+
+func ToCreateAuctionFromTemplate(t e.AuctionTemplate, auctionID string) sd.CreateAuctionInput {
+	s := t.Settings
+	return sd.CreateAuctionInput{
+		AuctionID:    auctionID,
+		Title:        s.Title,
+		Description:  s.Description,
+		Category:     s.Category,
+		SellerID:     t.SellerID,
+		StartPrice:   s.StartPrice,
+		MinStep:      s.MinStep,
+		Currency:     s.Currency,
+		Increments:   s.Increments,
+		Quantity:     s.Quantity,
+		Pricing:      s.Pricing,
+		Type:         s.Type,
+		Visibility:   s.Visibility,
+		Eligibility:  s.Eligibility,
+		Invited:      s.Invited,
+		DurationMin:  s.DurationMin,
+		ReservePrice: s.ReservePrice,
+		Relist:       s.Relist,
+	}
+}
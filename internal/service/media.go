@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/thumbnail"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+var mediaExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+type MediaService struct {
+	auctionRepo repo.Auctions
+	mediaRepo   repo.Media
+	storage     Storage
+	txManager   trm.Manager
+	breaker     *circuitbreaker.CircuitBreaker
+	retryer     *retry.Retryer
+	policy      e.MediaPolicy
+}
+
+func NewMediaService(
+	aRepo repo.Auctions,
+	mediaRepo repo.Media,
+	storage Storage,
+	txManager trm.Manager,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+	policy e.MediaPolicy,
+) *MediaService {
+	return &MediaService{
+		auctionRepo: aRepo,
+		mediaRepo:   mediaRepo,
+		storage:     storage,
+		txManager:   txManager,
+		breaker:     breaker,
+		retryer:     retryer,
+		policy:      policy,
+	}
+}
+
+// ownedAuction loads the auction and hides it from everyone but its seller.
+func (s *MediaService) ownedAuction(ctx context.Context, sellerID, auctionID string) (e.Auction, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotGetAuction)
+	}
+
+	auction := result.(e.Auction)
+	if auction.SellerID != sellerID {
+		return e.Auction{}, se.ErrNotFoundAuction
+	}
+	return auction, nil
+}
+
+// Upload stores a file for an active auction. The content type is sniffed
+// from the data rather than trusted from the client, and images get a
+// thumbnail when their format can be decoded.
+func (s *MediaService) Upload(ctx context.Context, in sd.UploadMediaInput) (e.Media, error) {
+	data, err := io.ReadAll(io.LimitReader(in.File, s.policy.MaxSize+1))
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Media{}, se.ErrCannotUploadMedia
+	}
+	if len(data) == 0 {
+		return e.Media{}, se.ErrEmptyMedia
+	}
+	if int64(len(data)) > s.policy.MaxSize {
+		return e.Media{}, se.ErrMediaTooLarge
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	kind, ok := e.MediaKindOf(contentType)
+	if !ok {
+		return e.Media{}, se.ErrUnsupportedMediaType
+	}
+
+	auction, err := s.ownedAuction(ctx, in.SellerID, in.AuctionID)
+	if err != nil {
+		return e.Media{}, err
+	}
+	if auction.Status != e.AuctionStatusActive {
+		return e.Media{}, se.ErrAuctionNotActive
+	}
+
+	// A cheap check before storing anything; Create enforces the limit.
+	count, err := s.mediaRepo.CountByAuction(ctx, in.AuctionID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Media{}, se.ErrCannotUploadMedia
+	}
+	if count >= s.policy.MaxPerAuction {
+		return e.Media{}, se.ErrTooManyMedia
+	}
+
+	var thumb []byte
+	var thumbType string
+	if kind == e.MediaKindImage {
+		thumb, thumbType, err = thumbnail.Make(data, s.policy.ThumbnailSize)
+		switch {
+		case errors.Is(err, thumbnail.ErrUnsupported):
+			log.Debugf("No thumbnail for %s upload on auction [%s]", contentType, in.AuctionID)
+		case errors.Is(err, thumbnail.ErrTooLarge):
+			return e.Media{}, se.ErrMediaTooLarge
+		case err != nil:
+			return e.Media{}, se.ErrInvalidMedia
+		}
+	}
+
+	name, err := randomMediaName()
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Media{}, se.ErrCannotUploadMedia
+	}
+	prefix := "auctions/" + url.PathEscape(in.AuctionID) + "/" + name
+	repoIn := rd.CreateMediaInput{
+		AuctionID:     in.AuctionID,
+		Kind:          kind,
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		FileName:      in.FileName,
+		Key:           prefix + mediaExtensions[contentType],
+		MaxPerAuction: s.policy.MaxPerAuction,
+	}
+
+	if err := s.storage.Put(ctx, repoIn.Key, data, contentType); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Media{}, se.ErrCannotUploadMedia
+	}
+	if thumb != nil {
+		repoIn.ThumbnailKey = prefix + "_thumb" + mediaExtensions[thumbType]
+		if err := s.storage.Put(ctx, repoIn.ThumbnailKey, thumb, thumbType); err != nil {
+			log.Error(errutils.WrapPathErr(err))
+			removeMediaFiles(ctx, s.storage, e.Media{Key: repoIn.Key})
+			return e.Media{}, se.ErrCannotUploadMedia
+		}
+	}
+
+	var media e.Media
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		media, err = s.mediaRepo.Create(ctx, repoIn)
+		return err
+	})
+	if err != nil {
+		removeMediaFiles(ctx, s.storage, e.Media{Key: repoIn.Key, ThumbnailKey: repoIn.ThumbnailKey})
+		if errors.Is(err, re.ErrLimitReached) {
+			return e.Media{}, se.ErrTooManyMedia
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return e.Media{}, se.ErrCannotUploadMedia
+	}
+
+	log.Infof("Media %d (%s, %d bytes) uploaded to auction [%s]", media.MediaID, media.ContentType, media.Size, media.AuctionID)
+	return withMediaURLs(s.storage, []e.Media{media})[0], nil
+}
+
+func (s *MediaService) DeleteMedia(ctx context.Context, sellerID string, mediaID int64) error {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var media e.Media
+		err := s.retryer.Do(ctx, "get_media", func() error {
+			var e error
+			media, e = s.mediaRepo.GetByID(ctx, mediaID)
+			return e
+		})
+		return media, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundMedia, se.ErrCannotDeleteMedia)
+	}
+
+	media := result.(e.Media)
+	if _, err := s.ownedAuction(ctx, sellerID, media.AuctionID); err != nil {
+		if errors.Is(err, se.ErrNotFoundAuction) {
+			return se.ErrNotFoundMedia
+		}
+		return err
+	}
+
+	_, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "delete_media", func() error {
+			return s.mediaRepo.Delete(ctx, mediaID)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundMedia, se.ErrCannotDeleteMedia)
+	}
+
+	removeMediaFiles(ctx, s.storage, media)
+	log.Infof("Media %d deleted from auction [%s]", media.MediaID, media.AuctionID)
+	return nil
+}
+
+func (s *MediaService) ReorderMedia(ctx context.Context, in sd.ReorderMediaInput) ([]e.Media, error) {
+	if _, err := s.ownedAuction(ctx, in.SellerID, in.AuctionID); err != nil {
+		return nil, err
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var media []e.Media
+		err := s.retryer.Do(ctx, "list_media", func() error {
+			var e error
+			media, e = s.mediaRepo.ListByAuction(ctx, in.AuctionID)
+			return e
+		})
+		return media, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotUpdateMedia
+	}
+
+	current, _ := result.([]e.Media)
+	if len(in.MediaIDs) != len(current) {
+		return nil, se.ErrInvalidMediaOrder
+	}
+	for _, m := range current {
+		if !slices.Contains(in.MediaIDs, m.MediaID) {
+			return nil, se.ErrInvalidMediaOrder
+		}
+	}
+
+	_, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "reorder_media", func() error {
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				return s.mediaRepo.Reorder(ctx, in.AuctionID, in.MediaIDs)
+			})
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		if errors.Is(cbErr, re.ErrNotFound) {
+			return nil, se.ErrInvalidMediaOrder
+		}
+		return nil, se.ErrCannotUpdateMedia
+	}
+
+	media, err := s.mediaRepo.ListByAuction(ctx, in.AuctionID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return nil, se.ErrCannotUpdateMedia
+	}
+	return withMediaURLs(s.storage, media), nil
+}
+
+func randomMediaName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("media name: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func withMediaURLs(storage Storage, media []e.Media) []e.Media {
+	for i := range media {
+		media[i].URL = storage.URL(media[i].Key)
+		if media[i].ThumbnailKey != "" {
+			media[i].ThumbnailURL = storage.URL(media[i].ThumbnailKey)
+		}
+	}
+	return media
+}
+
+// removeMediaFiles deletes stored files once their rows are gone. A file
+// left behind only costs space, so failures are logged and skipped.
+func removeMediaFiles(ctx context.Context, storage Storage, media ...e.Media) {
+	for _, m := range media {
+		for _, key := range m.Keys() {
+			if err := storage.Delete(ctx, key); err != nil {
+				log.Warnf("Cannot delete stored media %s: %v", key, err)
+			}
+		}
+	}
+}
+
+// attachMedia fills in the ordered media of each auction. Media only
+// decorates a listing, so a failure is logged and the auctions go out
+// without it.
+func attachMedia(ctx context.Context, mediaRepo repo.Media, storage Storage, auctions []e.Auction) {
+	if len(auctions) == 0 {
+		return
+	}
+	auctionIDs := make([]string, 0, len(auctions))
+	for _, a := range auctions {
+		auctionIDs = append(auctionIDs, a.AuctionID)
+	}
+
+	byAuction, err := mediaRepo.ListByAuctions(ctx, auctionIDs)
+	if err != nil {
+		log.Warnf("Cannot get auction media: %v", err)
+		return
+	}
+	for i := range auctions {
+		auctions[i].Media = withMediaURLs(storage, byAuction[auctions[i].AuctionID])
+	}
+}
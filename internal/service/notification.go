@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	kd "auction-platform/internal/infrastruct/kafka/dto"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/metrics"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type NotificationService struct {
+	notificationRepo repo.Notifications
+	watchlistRepo    repo.Watchlist
+	auctionRepo      repo.Auctions
+	bidRepo          repo.Bids
+	deliverer        Deliverer
+	breaker          *circuitbreaker.CircuitBreaker
+	retryer          *retry.Retryer
+	metrics          *metrics.Metrics
+}
+
+func NewNotificationService(
+	nRepo repo.Notifications,
+	wRepo repo.Watchlist,
+	aRepo repo.Auctions,
+	bRepo repo.Bids,
+	deliverer Deliverer,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+	m *metrics.Metrics,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: nRepo,
+		watchlistRepo:    wRepo,
+		auctionRepo:      aRepo,
+		bidRepo:          bRepo,
+		deliverer:        deliverer,
+		breaker:          breaker,
+		retryer:          retryer,
+		metrics:          m,
+	}
+}
+
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) ([]e.Notification, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	type notificationPage struct {
+		notifications []e.Notification
+		total         int64
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var r notificationPage
+		err := s.retryer.Do(ctx, "list_notifications", func() error {
+			var e error
+			r.notifications, r.total, e = s.notificationRepo.ListByUser(ctx, userID, unreadOnly, pageSize, offset)
+			return e
+		})
+		return r, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, 0, se.ErrCannotGetNotifications
+	}
+
+	r := result.(notificationPage)
+	return r.notifications, r.total, nil
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID string) (int64, error) {
+	count, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return 0, se.ErrCannotGetNotifications
+	}
+	return count, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID string, notificationIDs []int64) (int64, error) {
+	updated, err := s.notificationRepo.MarkRead(ctx, userID, notificationIDs)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return 0, se.ErrCannotUpdateNotifications
+	}
+	return updated, nil
+}
+
+func (s *NotificationService) HandleBidResult(ctx context.Context, event kd.BidResultEvent) error {
+	if event.Status != string(e.BidStatusAccepted) {
+		return nil
+	}
+
+	var errs []error
+	for _, bidderID := range event.Outbid {
+		errs = append(errs, s.notify(ctx, bidderID, event.AuctionID, e.NotificationTypeOutbid,
+			fmt.Sprintf("You have been outbid on auction %s: new bid is %s", event.AuctionID, event.Amount)))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *NotificationService) HandleAuctionEnded(ctx context.Context, event kd.AuctionEndedEvent) error {
+	bidders, err := s.bidRepo.ListBidderIDs(ctx, event.AuctionID)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+
+	won := make(map[string]kd.AuctionWinner, len(event.Winners))
+	for _, w := range event.Winners {
+		won[w.BidderID] = w
+	}
+
+	var errs []error
+	for _, bidderID := range bidders {
+		if w, ok := won[bidderID]; ok {
+			msg := fmt.Sprintf("You won auction %s for %s", event.AuctionID, w.Amount)
+			if event.Quantity > 1 {
+				msg = fmt.Sprintf("You won %d of %d units in auction %s for %s", w.Units, event.Quantity, event.AuctionID, w.Amount)
+			}
+			errs = append(errs, s.notify(ctx, bidderID, event.AuctionID, e.NotificationTypeWon, msg))
+			continue
+		}
+		errs = append(errs, s.notify(ctx, bidderID, event.AuctionID, e.NotificationTypeLost,
+			fmt.Sprintf("Auction %s has ended, final price %s", event.AuctionID, event.FinalPrice)))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *NotificationService) NotifyEndingSoon(ctx context.Context, window time.Duration) error {
+	auctions, err := s.auctionRepo.GetEndingWithin(ctx, window)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+
+	var errs []error
+	for _, auction := range auctions {
+		recipients, err := s.participants(ctx, auction.AuctionID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		msg := fmt.Sprintf("Auction %q ends at %s, current bid %s",
+			auction.Title, auction.EndsAt.UTC().Format(time.RFC3339), auction.CurrentBid)
+		for _, userID := range recipients {
+			errs = append(errs, s.notify(ctx, userID, auction.AuctionID, e.NotificationTypeEndingSoon, msg))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotifyQuestionAnswered tells the asker their question was answered and,
+// when the answer is public, everyone watching the auction.
+func (s *NotificationService) NotifyQuestionAnswered(ctx context.Context, q e.Question) error {
+	errs := []error{s.notify(ctx, q.AskerID, q.AuctionID, e.NotificationTypeQuestionAnswered,
+		fmt.Sprintf("The seller answered your question on auction %s", q.AuctionID))}
+	if !q.Public {
+		return errors.Join(errs...)
+	}
+
+	watchers, err := s.watchlistRepo.ListWatchers(ctx, q.AuctionID)
+	if err != nil {
+		return errors.Join(append(errs, errutils.WrapPathErr(err))...)
+	}
+	for _, userID := range watchers {
+		if userID == q.AskerID {
+			continue
+		}
+		errs = append(errs, s.notify(ctx, userID, q.AuctionID, e.NotificationTypeQuestionAnswered,
+			fmt.Sprintf("A question on auction %s you are watching was answered", q.AuctionID)))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *NotificationService) participants(ctx context.Context, auctionID string) ([]string, error) {
+	watchers, err := s.watchlistRepo.ListWatchers(ctx, auctionID)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+	bidders, err := s.bidRepo.ListBidderIDs(ctx, auctionID)
+	if err != nil {
+		return nil, errutils.WrapPathErr(err)
+	}
+
+	seen := make(map[string]struct{}, len(watchers)+len(bidders))
+	users := make([]string, 0, len(watchers)+len(bidders))
+	for _, userID := range append(watchers, bidders...) {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+		users = append(users, userID)
+	}
+	return users, nil
+}
+
+func (s *NotificationService) notify(ctx context.Context, userID, auctionID string, t e.NotificationType, msg string) error {
+	n, err := s.notificationRepo.Create(ctx, rd.CreateNotificationInput{
+		UserID:    userID,
+		AuctionID: auctionID,
+		Type:      t,
+		Message:   msg,
+	})
+	if err != nil {
+		if errors.Is(err, re.ErrAlreadyExists) {
+			return nil
+		}
+		return errutils.WrapPathErr(err)
+	}
+
+	s.metrics.NotificationsCreated.WithLabelValues(string(t)).Inc()
+
+	if err := s.deliverer.Deliver(ctx, n); err != nil {
+		log.Errorf("Failed to deliver notification %d to %s: %v", n.NotificationID, userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,492 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	kafkaclient "auction-platform/internal/infrastruct/kafka"
+	kd "auction-platform/internal/infrastruct/kafka/dto"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+type OrderService struct {
+	orderRepo     repo.Orders
+	auctionRepo   repo.Auctions
+	bidRepo       repo.Bids
+	feeRepo       repo.Fees
+	walletRepo    repo.Wallets
+	fxRepo        repo.FXRates
+	txManager     trm.Manager
+	producer      *kafkaclient.Producer
+	breaker       *circuitbreaker.CircuitBreaker
+	retryer       *retry.Retryer
+	orderTopic    string
+	paymentWindow time.Duration
+	offerWindow   time.Duration
+	feePolicy     e.FeePolicy
+}
+
+func NewOrderService(
+	oRepo repo.Orders,
+	aRepo repo.Auctions,
+	bRepo repo.Bids,
+	fRepo repo.Fees,
+	wRepo repo.Wallets,
+	fxRepo repo.FXRates,
+	txManager trm.Manager,
+	producer *kafkaclient.Producer,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+	orderTopic string,
+	paymentWindow time.Duration,
+	offerWindow time.Duration,
+	feePolicy e.FeePolicy,
+) *OrderService {
+	return &OrderService{
+		orderRepo:     oRepo,
+		auctionRepo:   aRepo,
+		bidRepo:       bRepo,
+		feeRepo:       fRepo,
+		walletRepo:    wRepo,
+		fxRepo:        fxRepo,
+		txManager:     txManager,
+		producer:      producer,
+		breaker:       breaker,
+		retryer:       retryer,
+		orderTopic:    orderTopic,
+		paymentWindow: paymentWindow,
+		offerWindow:   offerWindow,
+		feePolicy:     feePolicy,
+	}
+}
+
+// CreateWinOrder opens the order for a won auction and records its fees. The bid
+// deposit charged at settlement counts towards the amount; the rest is due within
+// the payment window. The rate into the wallet currency is fixed at this point
+// for the deposit, the payment and the seller statement.
+func (s *OrderService) CreateWinOrder(ctx context.Context, in sd.CreateWinOrderInput) (e.Order, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var order e.Order
+		err := s.retryer.Do(ctx, "create_win_order", func() error {
+			return s.txManager.Do(ctx, func(ctx context.Context) error {
+				rate, err := s.settlementRate(ctx, in.Amount.Currency)
+				if err != nil {
+					return err
+				}
+				charged, err := s.walletRepo.ChargedAmount(ctx, in.BuyerID, in.AuctionID)
+				if err != nil {
+					return err
+				}
+				paid := money.Min(charged.Convert(in.Amount.Currency, 1/rate), in.Amount)
+
+				input := rd.CreateOrderInput{
+					AuctionID:  in.AuctionID,
+					BuyerID:    in.BuyerID,
+					SellerID:   in.SellerID,
+					Kind:       e.OrderKindWin,
+					Status:     e.OrderStatusPaid,
+					Amount:     in.Amount,
+					AmountPaid: paid,
+					Quantity:   in.Quantity,
+					FXRate:     rate,
+				}
+				if input.AmountPaid.Cmp(input.Amount) < 0 {
+					due := time.Now().Add(s.paymentWindow)
+					input.Status = e.OrderStatusPending
+					input.PaymentDueAt = &due
+				}
+
+				order, err = s.createOrder(ctx, input)
+				return err
+			})
+		})
+		return order, err
+	})
+	if cbErr != nil {
+		if errors.Is(cbErr, re.ErrAlreadyExists) {
+			return e.Order{}, se.ErrOrderAlreadyExists
+		}
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Order{}, se.ErrCannotCreateOrder
+	}
+
+	order := result.(e.Order)
+	s.publish(ctx, e.OrderEventCreated, order)
+	if order.Status == e.OrderStatusPaid {
+		s.publish(ctx, e.OrderEventPaid, order)
+	}
+
+	log.Infof("Order created: id=%d auction=%s buyer=%s status=%s", order.OrderID, order.AuctionID, order.BuyerID, order.Status)
+	return order, nil
+}
+
+func (s *OrderService) GetOrder(ctx context.Context, userID string, orderID int64) (e.Order, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var order e.Order
+		err := s.retryer.Do(ctx, "get_order", func() error {
+			var e error
+			order, e = s.orderRepo.GetByID(ctx, orderID)
+			return e
+		})
+		return order, err
+	})
+	if cbErr != nil {
+		if !errors.Is(cbErr, re.ErrNotFound) {
+			log.Error(errutils.WrapPathErr(cbErr))
+		}
+		return e.Order{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundOrder, se.ErrCannotGetOrders)
+	}
+
+	order := result.(e.Order)
+	if order.BuyerID != userID && order.SellerID != userID {
+		return e.Order{}, se.ErrNotFoundOrder
+	}
+	return order, nil
+}
+
+func (s *OrderService) ListOrders(ctx context.Context, in sd.ListOrdersInput) ([]e.Order, int64, error) {
+	if in.Page < 1 {
+		in.Page = 1
+	}
+	if in.PageSize < 1 || in.PageSize > 100 {
+		in.PageSize = 20
+	}
+
+	input := rd.ListOrdersInput{
+		BuyerID: in.UserID,
+		Limit:   in.PageSize,
+		Offset:  (in.Page - 1) * in.PageSize,
+	}
+	if in.AsSeller {
+		input.BuyerID, input.SellerID = "", in.UserID
+	}
+
+	type orderPage struct {
+		orders []e.Order
+		total  int64
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var r orderPage
+		err := s.retryer.Do(ctx, "list_orders", func() error {
+			var e error
+			r.orders, r.total, e = s.orderRepo.List(ctx, input)
+			return e
+		})
+		return r, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, 0, se.ErrCannotGetOrders
+	}
+
+	r := result.(orderPage)
+	return r.orders, r.total, nil
+}
+
+// PayOrder pays the outstanding amount from the buyer's wallet to the seller.
+func (s *OrderService) PayOrder(ctx context.Context, buyerID string, orderID int64) (e.Order, error) {
+	var order e.Order
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		current, err := s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if current.BuyerID != buyerID {
+			return re.ErrNotFound
+		}
+		if current.Status != e.OrderStatusPending {
+			return se.ErrOrderNotPayable
+		}
+
+		if due := current.SettlementAmount(current.AmountDue()); due.IsPositive() {
+			available, err := s.walletRepo.GetOrCreateAccount(ctx, buyerID, e.AccountAvailable)
+			if err != nil {
+				return err
+			}
+			if available.Balance.Cmp(due) < 0 {
+				return re.ErrInsufficientFunds
+			}
+			err = transferFunds(ctx, s.walletRepo,
+				buyerID, e.AccountAvailable, current.SellerID, e.AccountAvailable,
+				due, e.LedgerCharge, current.AuctionID)
+			if err != nil {
+				return err
+			}
+		}
+
+		order, err = s.orderRepo.Transition(ctx, rd.TransitionOrderInput{
+			OrderID:    orderID,
+			From:       e.OrderStatusPending,
+			To:         e.OrderStatusPaid,
+			AmountPaid: &current.Amount,
+		})
+		if errors.Is(err, re.ErrNotFound) {
+			return se.ErrOrderNotPayable
+		}
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, re.ErrNotFound):
+			return e.Order{}, se.ErrNotFoundOrder
+		case errors.Is(err, se.ErrOrderNotPayable):
+			return e.Order{}, se.ErrOrderNotPayable
+		case errors.Is(err, re.ErrInsufficientFunds):
+			return e.Order{}, se.ErrInsufficientFunds
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return e.Order{}, se.ErrCannotUpdateOrder
+	}
+
+	s.publish(ctx, e.OrderEventPaid, order)
+
+	log.Infof("Order paid: id=%d auction=%s buyer=%s", order.OrderID, order.AuctionID, order.BuyerID)
+	return order, nil
+}
+
+func (s *OrderService) AcceptOffer(ctx context.Context, buyerID string, orderID int64) (e.Order, error) {
+	due := time.Now().Add(s.paymentWindow)
+	order, err := s.respondToOffer(ctx, buyerID, orderID, rd.TransitionOrderInput{
+		OrderID:      orderID,
+		From:         e.OrderStatusOffered,
+		To:           e.OrderStatusPending,
+		PaymentDueAt: &due,
+	})
+	if err != nil {
+		return e.Order{}, err
+	}
+
+	s.publish(ctx, e.OrderEventOfferAccepted, order)
+	return order, nil
+}
+
+func (s *OrderService) DeclineOffer(ctx context.Context, buyerID string, orderID int64) (e.Order, error) {
+	order, err := s.respondToOffer(ctx, buyerID, orderID, rd.TransitionOrderInput{
+		OrderID: orderID,
+		From:    e.OrderStatusOffered,
+		To:      e.OrderStatusCancelled,
+	})
+	if err != nil {
+		return e.Order{}, err
+	}
+
+	s.publish(ctx, e.OrderEventOfferDeclined, order)
+	return order, nil
+}
+
+func (s *OrderService) respondToOffer(ctx context.Context, buyerID string, orderID int64, in rd.TransitionOrderInput) (e.Order, error) {
+	current, err := s.GetOrder(ctx, buyerID, orderID)
+	if err != nil {
+		return e.Order{}, err
+	}
+	if current.BuyerID != buyerID || current.Status != e.OrderStatusOffered {
+		return e.Order{}, se.ErrOfferNotOpen
+	}
+	if current.OfferExpiresAt != nil && current.OfferExpiresAt.Before(time.Now()) {
+		return e.Order{}, se.ErrOfferNotOpen
+	}
+
+	order, err := s.orderRepo.Transition(ctx, in)
+	if err != nil {
+		if errors.Is(err, re.ErrNotFound) {
+			return e.Order{}, se.ErrOfferNotOpen
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return e.Order{}, se.ErrCannotUpdateOrder
+	}
+	return order, nil
+}
+
+// ProcessDeadlines marks overdue orders as unpaid, offers an unpaid win to the
+// runner-up at their last bid and cancels second-chance offers nobody accepted.
+// The winner's deposit stays with the seller.
+func (s *OrderService) ProcessDeadlines(ctx context.Context) error {
+	overdue, err := s.orderRepo.ListOverdue(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range overdue {
+		var err error
+		switch order.Status {
+		case e.OrderStatusPending:
+			err = s.expirePayment(ctx, order)
+		case e.OrderStatusOffered:
+			err = s.expireOffer(ctx, order)
+		}
+		if err != nil {
+			log.Errorf("Failed to process deadline for order %d: %v", order.OrderID, err)
+		}
+	}
+	return nil
+}
+
+func (s *OrderService) expirePayment(ctx context.Context, order e.Order) error {
+	var unpaid, offer e.Order
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		unpaid, err = s.orderRepo.Transition(ctx, rd.TransitionOrderInput{
+			OrderID: order.OrderID,
+			From:    e.OrderStatusPending,
+			To:      e.OrderStatusUnpaid,
+		})
+		if err != nil || order.Kind != e.OrderKindWin {
+			return err
+		}
+
+		// The buyer of a reverse auction is its owner, so there is no
+		// runner-up to offer the lot to.
+		auction, err := s.auctionRepo.GetByID(ctx, order.AuctionID)
+		if err != nil || auction.IsReverse() {
+			return err
+		}
+
+		runnerUp, err := s.bidRepo.GetRunnerUp(ctx, order.AuctionID)
+		if err != nil {
+			if errors.Is(err, re.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		// The runner-up is the best bid left, so when it is short of the
+		// reserve every other bid is too.
+		if !auction.MeetsReserve(runnerUp.Amount) {
+			return nil
+		}
+
+		units := min(runnerUp.Quantity, order.Quantity)
+		expires := time.Now().Add(s.offerWindow)
+		offer, err = s.createOrder(ctx, rd.CreateOrderInput{
+			AuctionID:      order.AuctionID,
+			BuyerID:        runnerUp.BidderID,
+			SellerID:       order.SellerID,
+			Kind:           e.OrderKindSecondChance,
+			Status:         e.OrderStatusOffered,
+			Amount:         runnerUp.Amount.Mul(int64(units)),
+			Quantity:       units,
+			OfferExpiresAt: &expires,
+		})
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, re.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	s.publish(ctx, e.OrderEventUnpaid, unpaid)
+	log.Infof("Order unpaid: id=%d auction=%s buyer=%s", unpaid.OrderID, unpaid.AuctionID, unpaid.BuyerID)
+
+	if offer.OrderID != 0 {
+		s.publish(ctx, e.OrderEventOfferCreated, offer)
+		log.Infof("Second-chance offer: id=%d auction=%s buyer=%s amount=%s",
+			offer.OrderID, offer.AuctionID, offer.BuyerID, offer.Amount)
+	}
+	return nil
+}
+
+func (s *OrderService) expireOffer(ctx context.Context, order e.Order) error {
+	expired, err := s.orderRepo.Transition(ctx, rd.TransitionOrderInput{
+		OrderID: order.OrderID,
+		From:    e.OrderStatusOffered,
+		To:      e.OrderStatusCancelled,
+	})
+	if err != nil {
+		if errors.Is(err, re.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	s.publish(ctx, e.OrderEventOfferExpired, expired)
+	return nil
+}
+
+// createOrder stores the order together with the fee lines from the schedule of
+// the auction's category. Final-value fees are charged on every order and the
+// listing fee only on the first order of the auction. Fees are charged in
+// money.DefaultCurrency. Must run inside a transaction.
+func (s *OrderService) createOrder(ctx context.Context, in rd.CreateOrderInput) (e.Order, error) {
+	auction, err := s.auctionRepo.GetByID(ctx, in.AuctionID)
+	if err != nil {
+		return e.Order{}, err
+	}
+	if in.FXRate == 0 {
+		if in.FXRate, err = s.settlementRate(ctx, in.Amount.Currency); err != nil {
+			return e.Order{}, err
+		}
+	}
+
+	order, err := s.orderRepo.Create(ctx, in)
+	if err != nil {
+		return e.Order{}, err
+	}
+
+	listed, err := s.feeRepo.HasListingFee(ctx, in.AuctionID)
+	if err != nil {
+		return e.Order{}, err
+	}
+
+	schedule := s.feePolicy.ScheduleFor(auction.Category)
+	settled := order.SettlementAmount(order.Amount)
+	lines := schedule.Lines(settled)
+	if listing, ok := schedule.ListingLine(settled); ok && !listed {
+		lines = append([]e.FeeLine{listing}, lines...)
+	}
+	for i := range lines {
+		lines[i].OrderID = order.OrderID
+		lines[i].SellerID = order.SellerID
+	}
+	return order, s.feeRepo.CreateLines(ctx, lines)
+}
+
+// settlementRate is the current rate from c into money.DefaultCurrency.
+func (s *OrderService) settlementRate(ctx context.Context, c money.Currency) (float64, error) {
+	table, err := loadFXTable(ctx, s.fxRepo)
+	if err != nil {
+		return 0, err
+	}
+	rate, ok := table.Rate(c, money.DefaultCurrency)
+	if !ok {
+		return 0, se.ErrNoFXRate
+	}
+	return rate, nil
+}
+
+func (s *OrderService) publish(ctx context.Context, event e.OrderEventType, o e.Order) {
+	msg := kd.OrderEvent{
+		Event:          string(event),
+		OrderID:        o.OrderID,
+		AuctionID:      o.AuctionID,
+		BuyerID:        o.BuyerID,
+		SellerID:       o.SellerID,
+		Kind:           string(o.Kind),
+		Status:         string(o.Status),
+		Amount:         o.Amount,
+		AmountDue:      o.AmountDue(),
+		Quantity:       o.Quantity,
+		Currency:       string(o.Amount.Currency),
+		FXRate:         o.FXRate,
+		PaymentDueAt:   o.PaymentDueAt,
+		OfferExpiresAt: o.OfferExpiresAt,
+		Timestamp:      time.Now(),
+	}
+	if err := s.producer.Publish(ctx, s.orderTopic, o.AuctionID, msg); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+	}
+}
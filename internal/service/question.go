@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type QuestionService struct {
+	auctionRepo   repo.Auctions
+	inviteRepo    repo.Invites
+	banRepo       repo.Bans
+	questionRepo  repo.Questions
+	notifications Notifications
+	breaker       *circuitbreaker.CircuitBreaker
+	retryer       *retry.Retryer
+}
+
+func NewQuestionService(
+	aRepo repo.Auctions,
+	inviteRepo repo.Invites,
+	banRepo repo.Bans,
+	questionRepo repo.Questions,
+	notifications Notifications,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *QuestionService {
+	return &QuestionService{
+		auctionRepo:   aRepo,
+		inviteRepo:    inviteRepo,
+		banRepo:       banRepo,
+		questionRepo:  questionRepo,
+		notifications: notifications,
+		breaker:       breaker,
+		retryer:       retryer,
+	}
+}
+
+func (s *QuestionService) getAuction(ctx context.Context, auctionID string) (e.Auction, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotGetAuction)
+	}
+	return result.(e.Auction), nil
+}
+
+// Ask posts a question on an active auction the asker can see.
+func (s *QuestionService) Ask(ctx context.Context, in sd.AskQuestionInput) (e.Question, error) {
+	auction, err := s.getAuction(ctx, in.AuctionID)
+	if err != nil {
+		return e.Question{}, err
+	}
+	if err := checkCanView(ctx, s.inviteRepo, auction, in.AskerID); err != nil {
+		return e.Question{}, err
+	}
+	if auction.SellerID == in.AskerID {
+		return e.Question{}, se.ErrSellerCannotAsk
+	}
+	if auction.Status != e.AuctionStatusActive {
+		return e.Question{}, se.ErrAuctionNotActive
+	}
+	if err := checkNotBanned(ctx, s.banRepo, in.AskerID); err != nil {
+		return e.Question{}, err
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var question e.Question
+		err := s.retryer.Do(ctx, "create_question", func() error {
+			var e error
+			question, e = s.questionRepo.Create(ctx, rd.CreateQuestionInput{
+				AuctionID: in.AuctionID,
+				AskerID:   in.AskerID,
+				Question:  in.Question,
+			})
+			return e
+		})
+		return question, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Question{}, se.ErrCannotAskQuestion
+	}
+
+	question := result.(e.Question)
+	log.Infof("Question %d asked on auction [%s] by %s", question.QuestionID, question.AuctionID, question.AskerID)
+	return question, nil
+}
+
+// Answer lets the seller answer a question once, either publicly or for the
+// asker only. Failing to notify does not undo the answer.
+func (s *QuestionService) Answer(ctx context.Context, in sd.AnswerQuestionInput) (e.Question, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var question e.Question
+		err := s.retryer.Do(ctx, "get_question", func() error {
+			var e error
+			question, e = s.questionRepo.GetByID(ctx, in.QuestionID)
+			return e
+		})
+		return question, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Question{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundQuestion, se.ErrCannotAnswerQuestion)
+	}
+
+	question := result.(e.Question)
+	auction, err := s.getAuction(ctx, question.AuctionID)
+	if err != nil {
+		return e.Question{}, err
+	}
+	if auction.SellerID != in.SellerID {
+		return e.Question{}, se.ErrNotFoundQuestion
+	}
+	if question.IsAnswered() {
+		return e.Question{}, se.ErrQuestionAlreadyAnswered
+	}
+
+	result, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		var answered e.Question
+		err := s.retryer.Do(ctx, "answer_question", func() error {
+			var e error
+			answered, e = s.questionRepo.Answer(ctx, rd.AnswerQuestionInput{
+				QuestionID: in.QuestionID,
+				Answer:     in.Answer,
+				Public:     in.Public,
+			})
+			return e
+		})
+		return answered, err
+	})
+	if cbErr != nil {
+		if errors.Is(cbErr, re.ErrNotFound) {
+			return e.Question{}, se.ErrQuestionAlreadyAnswered
+		}
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Question{}, se.ErrCannotAnswerQuestion
+	}
+
+	answered := result.(e.Question)
+	log.Infof("Question %d on auction [%s] answered (public=%t)", answered.QuestionID, answered.AuctionID, answered.Public)
+
+	if err := s.notifications.NotifyQuestionAnswered(ctx, answered); err != nil {
+		log.Errorf("Failed to notify about answer to question %d: %v", answered.QuestionID, err)
+	}
+	return answered, nil
+}
+
+// ListQuestions shows the seller every question on the auction, including
+// unanswered ones; other viewers see public answers and their own questions.
+func (s *QuestionService) ListQuestions(ctx context.Context, auctionID, viewerID string) ([]e.Question, error) {
+	auction, err := s.getAuction(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCanView(ctx, s.inviteRepo, auction, viewerID); err != nil {
+		return nil, err
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var questions []e.Question
+		err := s.retryer.Do(ctx, "list_questions", func() error {
+			var e error
+			questions, e = s.questionRepo.ListByAuction(ctx, auctionID, viewerID, auction.SellerID == viewerID)
+			return e
+		})
+		return questions, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetQuestions
+	}
+
+	questions, _ := result.([]e.Question)
+	return questions, nil
+}
+
+// attachAnswers fills in the answered questions the viewer may see. Answers
+// only decorate the listing, so a failure is logged and the auction goes out
+// without them.
+func attachAnswers(ctx context.Context, questionRepo repo.Questions, auction *e.Auction, viewerID string) {
+	questions, err := questionRepo.ListByAuction(ctx, auction.AuctionID, viewerID, auction.SellerID == viewerID)
+	if err != nil {
+		log.Warnf("Cannot get answers for auction [%s]: %v", auction.AuctionID, err)
+		return
+	}
+	for _, q := range questions {
+		if q.IsAnswered() {
+			auction.Answers = append(auction.Answers, q)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const profileRatingsLimit = 20
+
+type RatingService struct {
+	auctionRepo repo.Auctions
+	orderRepo   repo.Orders
+	ratingRepo  repo.Ratings
+	breaker     *circuitbreaker.CircuitBreaker
+	retryer     *retry.Retryer
+}
+
+func NewRatingService(
+	aRepo repo.Auctions,
+	orderRepo repo.Orders,
+	ratingRepo repo.Ratings,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *RatingService {
+	return &RatingService{
+		auctionRepo: aRepo,
+		orderRepo:   orderRepo,
+		ratingRepo:  ratingRepo,
+		breaker:     breaker,
+		retryer:     retryer,
+	}
+}
+
+// Rate lets the two sides of each order on a finished auction rate each other
+// once. The sides come from the orders, so in a reverse auction the owner
+// rates the winning suppliers as sellers and they rate the owner as buyer.
+func (s *RatingService) Rate(ctx context.Context, in sd.RateInput) (e.Rating, error) {
+	if in.Score < e.MinRatingScore || in.Score > e.MaxRatingScore {
+		return e.Rating{}, se.ErrInvalidRatingScore
+	}
+
+	if in.RateeID != "" && in.RateeID == in.RaterID {
+		return e.Rating{}, se.ErrSelfRating
+	}
+
+	type trade struct {
+		auction e.Auction
+		parties []e.TradeParties
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var t trade
+		err := s.retryer.Do(ctx, "get_trade", func() error {
+			var err error
+			if t.auction, err = s.auctionRepo.GetByID(ctx, in.AuctionID); err != nil {
+				return err
+			}
+			t.parties, err = s.orderRepo.ListTradeParties(ctx, in.AuctionID)
+			return err
+		})
+		return t, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Rating{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotRate)
+	}
+
+	t := result.(trade)
+	if t.auction.Status != e.AuctionStatusFinished || len(t.parties) == 0 {
+		return e.Rating{}, se.ErrAuctionNotSold
+	}
+
+	roles := make(map[string]e.RatingRole)
+	var counterparties []string
+	for _, p := range t.parties {
+		other, role, ok := p.Counterparty(in.RaterID)
+		if !ok || other == in.RaterID {
+			continue
+		}
+		if _, seen := roles[other]; !seen {
+			counterparties = append(counterparties, other)
+		}
+		roles[other] = role
+	}
+	if len(counterparties) == 0 {
+		return e.Rating{}, se.ErrNotTradeParty
+	}
+
+	repoIn := rd.CreateRatingInput{
+		AuctionID: in.AuctionID,
+		RaterID:   in.RaterID,
+		RateeID:   in.RateeID,
+		Score:     in.Score,
+		Comment:   in.Comment,
+	}
+	if repoIn.RateeID == "" {
+		if len(counterparties) > 1 {
+			return e.Rating{}, se.ErrRateeRequired
+		}
+		repoIn.RateeID = counterparties[0]
+	}
+	role, ok := roles[repoIn.RateeID]
+	if !ok {
+		return e.Rating{}, se.ErrNotTradeParty
+	}
+	repoIn.Role = role
+
+	result, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		var rating e.Rating
+		err := s.retryer.Do(ctx, "create_rating", func() error {
+			var e error
+			rating, e = s.ratingRepo.Create(ctx, repoIn)
+			return e
+		})
+		return rating, err
+	})
+	if cbErr != nil {
+		if errors.Is(cbErr, re.ErrAlreadyExists) {
+			return e.Rating{}, se.ErrAlreadyRated
+		}
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Rating{}, se.ErrCannotRate
+	}
+
+	rating := result.(e.Rating)
+	log.Infof("User %s rated %s %d/5 for auction [%s]", rating.RaterID, rating.RateeID, rating.Score, rating.AuctionID)
+	return rating, nil
+}
+
+// GetProfile returns the user's reputation and the latest ratings they
+// received.
+func (s *RatingService) GetProfile(ctx context.Context, userID string) (e.UserProfile, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		profile := e.UserProfile{UserID: userID}
+		err := s.retryer.Do(ctx, "get_profile", func() error {
+			var err error
+			if profile.Reputation, err = s.ratingRepo.Reputation(ctx, userID); err != nil {
+				return err
+			}
+			profile.Ratings, err = s.ratingRepo.ListByRatee(ctx, userID, profileRatingsLimit)
+			return err
+		})
+		return profile, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.UserProfile{}, se.ErrCannotGetProfile
+	}
+
+	return result.(e.UserProfile), nil
+}
+
+// attachSellerRatings fills in SellerRating on each auction. Ratings only
+// decorate a listing, so a failure is logged and the auctions go out without
+// them.
+func attachSellerRatings(ctx context.Context, ratingRepo repo.Ratings, auctions []e.Auction) {
+	if len(auctions) == 0 {
+		return
+	}
+	sellerIDs := make([]string, 0, len(auctions))
+	for _, a := range auctions {
+		if !slices.Contains(sellerIDs, a.SellerID) {
+			sellerIDs = append(sellerIDs, a.SellerID)
+		}
+	}
+
+	summaries, err := ratingRepo.SellerSummaries(ctx, sellerIDs)
+	if err != nil {
+		log.Warnf("Cannot get seller ratings: %v", err)
+		return
+	}
+	for i := range auctions {
+		if s, ok := summaries[auctions[i].SellerID]; ok {
+			auctions[i].SellerRating = &s
+		}
+	}
+}
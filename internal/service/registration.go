@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+type RegistrationService struct {
+	auctionRepo      repo.Auctions
+	registrationRepo repo.Registrations
+	inviteRepo       repo.Invites
+	banRepo          repo.Bans
+	walletRepo       repo.Wallets
+	fxRepo           repo.FXRates
+	txManager        trm.Manager
+	breaker          *circuitbreaker.CircuitBreaker
+	retryer          *retry.Retryer
+}
+
+func NewRegistrationService(
+	aRepo repo.Auctions,
+	registrationRepo repo.Registrations,
+	inviteRepo repo.Invites,
+	banRepo repo.Bans,
+	walletRepo repo.Wallets,
+	fxRepo repo.FXRates,
+	txManager trm.Manager,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *RegistrationService {
+	return &RegistrationService{
+		auctionRepo:      aRepo,
+		registrationRepo: registrationRepo,
+		inviteRepo:       inviteRepo,
+		banRepo:          banRepo,
+		walletRepo:       walletRepo,
+		fxRepo:           fxRepo,
+		txManager:        txManager,
+		breaker:          breaker,
+		retryer:          retryer,
+	}
+}
+
+func (s *RegistrationService) getAuction(ctx context.Context, auctionID string) (e.Auction, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotGetAuction)
+	}
+	return result.(e.Auction), nil
+}
+
+// reviewableAuction loads an auction the reviewer may decide registrations
+// for: any auction for admins, their own for sellers.
+func (s *RegistrationService) reviewableAuction(ctx context.Context, reviewerID string, isAdmin bool, auctionID string) (e.Auction, error) {
+	auction, err := s.getAuction(ctx, auctionID)
+	if err != nil {
+		return e.Auction{}, err
+	}
+	if !isAdmin && auction.SellerID != reviewerID {
+		return e.Auction{}, se.ErrNotFoundAuction
+	}
+	return auction, nil
+}
+
+// Register asks to take part in an auction that requires approval and holds
+// the auction's deposit, converted into money.DefaultCurrency, until the
+// auction settles or the request is denied.
+func (s *RegistrationService) Register(ctx context.Context, auctionID, bidderID string) (e.Registration, error) {
+	auction, err := s.getAuction(ctx, auctionID)
+	if err != nil {
+		return e.Registration{}, err
+	}
+	if !auction.Eligibility.RequireRegistration {
+		return e.Registration{}, se.ErrRegistrationNotRequired
+	}
+	if auction.Status != e.AuctionStatusActive {
+		return e.Registration{}, se.ErrAuctionNotActive
+	}
+	if auction.SellerID == bidderID {
+		return e.Registration{}, se.ErrSellerCannotBid
+	}
+	if err := checkNotBanned(ctx, s.banRepo, bidderID); err != nil {
+		return e.Registration{}, err
+	}
+	if err := checkNotBlocked(ctx, s.banRepo, auction.SellerID, bidderID); err != nil {
+		return e.Registration{}, err
+	}
+	if auction.IsPrivate() {
+		invited, err := s.inviteRepo.IsInvited(ctx, auctionID, bidderID)
+		if err != nil {
+			log.Error(errutils.WrapPathErr(err))
+			return e.Registration{}, se.ErrCannotRegister
+		}
+		if !invited {
+			return e.Registration{}, se.ErrNotInvited
+		}
+	}
+
+	deposit, _, err := convertMoney(ctx, s.fxRepo, auction.Eligibility.Deposit, money.DefaultCurrency)
+	if err != nil {
+		if errors.Is(err, se.ErrNoFXRate) {
+			return e.Registration{}, err
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return e.Registration{}, se.ErrCannotGetFXRates
+	}
+
+	var reg e.Registration
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		reg, err = s.registrationRepo.Create(ctx, rd.CreateRegistrationInput{
+			AuctionID: auctionID,
+			BidderID:  bidderID,
+			Deposit:   deposit,
+		})
+		if err != nil || !deposit.IsPositive() {
+			return err
+		}
+		return setHold(ctx, s.walletRepo, bidderID, e.RegistrationReference(auctionID), deposit)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, re.ErrAlreadyExists):
+			return e.Registration{}, se.ErrRegistrationAlreadyExists
+		case errors.Is(err, re.ErrInsufficientFunds):
+			return e.Registration{}, se.ErrInsufficientFunds
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return e.Registration{}, se.ErrCannotRegister
+	}
+
+	log.Infof("Bidder %s registered for auction %s", bidderID, auctionID)
+	return reg, nil
+}
+
+func (s *RegistrationService) GetRegistration(ctx context.Context, auctionID, bidderID string) (e.Registration, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var reg e.Registration
+		err := s.retryer.Do(ctx, "get_registration", func() error {
+			var e error
+			reg, e = s.registrationRepo.Get(ctx, auctionID, bidderID)
+			return e
+		})
+		return reg, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Registration{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundRegistration, se.ErrCannotGetRegistrations)
+	}
+	return result.(e.Registration), nil
+}
+
+func (s *RegistrationService) ListRegistrations(ctx context.Context, in sd.ListRegistrationsInput) ([]e.Registration, error) {
+	if _, err := s.reviewableAuction(ctx, in.ReviewerID, in.IsAdmin, in.AuctionID); err != nil {
+		return nil, err
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var regs []e.Registration
+		err := s.retryer.Do(ctx, "list_registrations", func() error {
+			var e error
+			regs, e = s.registrationRepo.ListByAuction(ctx, in.AuctionID, in.Status)
+			return e
+		})
+		return regs, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetRegistrations
+	}
+
+	regs, _ := result.([]e.Registration)
+	return regs, nil
+}
+
+// ReviewRegistration approves or denies a pending registration. A denial
+// returns the deposit straight away.
+func (s *RegistrationService) ReviewRegistration(ctx context.Context, in sd.ReviewRegistrationInput) (e.Registration, error) {
+	if _, err := s.reviewableAuction(ctx, in.ReviewerID, in.IsAdmin, in.AuctionID); err != nil {
+		return e.Registration{}, err
+	}
+
+	status := e.RegistrationDenied
+	if in.Approve {
+		status = e.RegistrationApproved
+	}
+
+	var reg e.Registration
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		reg, err = s.registrationRepo.Review(ctx, rd.ReviewRegistrationInput{
+			AuctionID:  in.AuctionID,
+			BidderID:   in.BidderID,
+			Status:     status,
+			ReviewedBy: in.ReviewerID,
+			Reason:     in.Reason,
+		})
+		if err != nil || status != e.RegistrationDenied {
+			return err
+		}
+		return setHold(ctx, s.walletRepo, in.BidderID, e.RegistrationReference(in.AuctionID), money.Zero(money.DefaultCurrency))
+	})
+	if err != nil {
+		if errors.Is(err, re.ErrNotFound) {
+			if _, getErr := s.GetRegistration(ctx, in.AuctionID, in.BidderID); getErr != nil {
+				return e.Registration{}, getErr
+			}
+			return e.Registration{}, se.ErrRegistrationNotPending
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return e.Registration{}, se.ErrCannotReviewRegistration
+	}
+
+	log.Infof("Registration of %s for auction %s %s by %s", in.BidderID, in.AuctionID, status, in.ReviewerID)
+	return reg, nil
+}
@@ -4,57 +4,325 @@ import (
 	"auction-platform/internal/infrastruct/circuitbreaker"
 	kafkaclient "auction-platform/internal/infrastruct/kafka"
 	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/infrastruct/webhook"
 	"auction-platform/internal/metrics"
 	"auction-platform/internal/repo"
+	"auction-platform/pkg/money"
 	"context"
+	"time"
 
 	e "auction-platform/internal/entity"
 	kd "auction-platform/internal/infrastruct/kafka/dto"
 	sd "auction-platform/internal/service/dto"
 
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/redis/go-redis/v9"
+	"github.com/sony/gobreaker"
 )
 
 type Auctions interface {
 	CreateAuction(ctx context.Context, in sd.CreateAuctionInput) (e.Auction, error)
-	GetAuction(ctx context.Context, auctionID string) (e.Auction, error)
-	ListActive(ctx context.Context, page, pageSize int) ([]e.Auction, int64, error)
+	GetAuction(ctx context.Context, in sd.GetAuctionInput) (e.Auction, error)
+	ListActive(ctx context.Context, in sd.ListAuctionsInput) ([]e.Auction, int64, error)
+	ListBySeller(ctx context.Context, sellerID string, page, pageSize int) ([]e.SellerAuction, int64, error)
+	DeleteAuction(ctx context.Context, sellerID, auctionID string) error
+	RelistAuction(ctx context.Context, auctionID string) (e.Auction, error)
+}
+
+type Imports interface {
+	ImportAuctions(ctx context.Context, in sd.ImportAuctionsInput) (e.ImportReport, error)
+}
+
+type Templates interface {
+	SaveTemplate(ctx context.Context, in sd.SaveTemplateInput) (e.AuctionTemplate, error)
+	ListTemplates(ctx context.Context, sellerID string) ([]e.AuctionTemplate, error)
+	DeleteTemplate(ctx context.Context, sellerID string, templateID int64) error
+	LaunchTemplate(ctx context.Context, in sd.LaunchTemplateInput) (e.Auction, error)
+}
+
+type Media interface {
+	Upload(ctx context.Context, in sd.UploadMediaInput) (e.Media, error)
+	DeleteMedia(ctx context.Context, sellerID string, mediaID int64) error
+	ReorderMedia(ctx context.Context, in sd.ReorderMediaInput) ([]e.Media, error)
 }
 
 type Bids interface {
 	PlaceBid(ctx context.Context, in sd.PlaceBidInput) (e.Bid, error)
 	ProcessBidEvent(ctx context.Context, event kd.BidPlacedEvent) error
-	GetBidsByAuction(ctx context.Context, auctionID string, limit int) ([]e.Bid, error)
-	GetHighestBid(ctx context.Context, auctionID string) (e.Bid, error)
+	GetBidsByAuction(ctx context.Context, auctionID, viewerID string, limit int) ([]e.Bid, error)
+	WinningBids(ctx context.Context, auction e.Auction) ([]e.LotAllocation, error)
 	CountByAuction(ctx context.Context, auctionID string) (int, error)
+	ListByBidder(ctx context.Context, bidderID string, page, pageSize int) ([]e.BidderAuction, int64, error)
+	RejectBid(ctx context.Context, bidID, reason string) error
+	HoldBid(ctx context.Context, bidID, reason string) error
+	ReleaseHeldBid(ctx context.Context, bidID string) error
+}
+
+type Allowlists interface {
+	SetVisibility(ctx context.Context, in sd.SetVisibilityInput) (e.Auction, error)
+	GetAllowlist(ctx context.Context, sellerID, auctionID string) (e.Allowlist, error)
+	UpdateAllowlist(ctx context.Context, in sd.UpdateAllowlistInput) (e.Allowlist, error)
+	CreateGroup(ctx context.Context, sellerID, name string, members []string) (e.BidderGroup, error)
+	ListGroups(ctx context.Context, sellerID string) ([]e.BidderGroup, error)
+	DeleteGroup(ctx context.Context, sellerID string, groupID int64) error
+	UpdateGroupMembers(ctx context.Context, in sd.UpdateGroupMembersInput) (e.BidderGroup, error)
+}
+
+type Registrations interface {
+	Register(ctx context.Context, auctionID, bidderID string) (e.Registration, error)
+	GetRegistration(ctx context.Context, auctionID, bidderID string) (e.Registration, error)
+	ListRegistrations(ctx context.Context, in sd.ListRegistrationsInput) ([]e.Registration, error)
+	ReviewRegistration(ctx context.Context, in sd.ReviewRegistrationInput) (e.Registration, error)
+}
+
+type Fraud interface {
+	HandleBidPlaced(ctx context.Context, event kd.BidPlacedEvent) error
+	HandleBidResult(ctx context.Context, event kd.BidResultEvent) error
+	ListFlags(ctx context.Context, status e.FraudFlagStatus, page, pageSize int) ([]e.FraudFlag, int64, error)
+	ReviewFlag(ctx context.Context, in sd.ReviewFraudFlagInput) (e.FraudFlag, error)
+}
+
+type Blocklists interface {
+	BlockBidder(ctx context.Context, in sd.BlockBidderInput) (e.BlockedBidder, error)
+	UnblockBidder(ctx context.Context, sellerID, bidderID string) error
+	ListBlocked(ctx context.Context, sellerID string) ([]e.BlockedBidder, error)
+}
+
+type Ratings interface {
+	Rate(ctx context.Context, in sd.RateInput) (e.Rating, error)
+	GetProfile(ctx context.Context, userID string) (e.UserProfile, error)
+}
+
+type Questions interface {
+	Ask(ctx context.Context, in sd.AskQuestionInput) (e.Question, error)
+	Answer(ctx context.Context, in sd.AnswerQuestionInput) (e.Question, error)
+	ListQuestions(ctx context.Context, auctionID, viewerID string) ([]e.Question, error)
+}
+
+type Watchlist interface {
+	Watch(ctx context.Context, userID, auctionID string) error
+	Unwatch(ctx context.Context, userID, auctionID string) error
+	GetWatchlist(ctx context.Context, userID string) ([]e.WatchlistItem, error)
+}
+
+type Notifications interface {
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) ([]e.Notification, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID string, notificationIDs []int64) (int64, error)
+	HandleBidResult(ctx context.Context, event kd.BidResultEvent) error
+	HandleAuctionEnded(ctx context.Context, event kd.AuctionEndedEvent) error
+	NotifyEndingSoon(ctx context.Context, window time.Duration) error
+	NotifyQuestionAnswered(ctx context.Context, q e.Question) error
+}
+
+type Webhooks interface {
+	CreateWebhook(ctx context.Context, in sd.CreateWebhookInput) (e.Webhook, error)
+	ListWebhooks(ctx context.Context, ownerID string) ([]e.Webhook, error)
+	DeleteWebhook(ctx context.Context, ownerID string, webhookID int64) error
+	ListDeliveries(ctx context.Context, ownerID string, webhookID int64, limit int) ([]e.WebhookDelivery, error)
+	Dispatch(ctx context.Context, eventType e.WebhookEventType, data any) error
+}
+
+type Admin interface {
+	ForceFinishAuction(ctx context.Context, auctionID string) error
+	CancelAuction(ctx context.Context, auctionID string) error
+	BanUser(ctx context.Context, in sd.BanUserInput) (e.Ban, error)
+	UnbanUser(ctx context.Context, userID string) error
+	ListBans(ctx context.Context) ([]e.Ban, error)
+	CircuitBreakerStates() []circuitbreaker.State
+}
+
+type APIKeys interface {
+	CreateAPIKey(ctx context.Context, in sd.CreateAPIKeyInput) (e.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, ownerID string) ([]e.APIKey, error)
+	RotateAPIKey(ctx context.Context, ownerID string, keyID int64) (e.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, ownerID string, keyID int64) error
+	SetQuota(ctx context.Context, in sd.SetAPIKeyQuotaInput) (e.APIKey, error)
+	VerifyAPIKey(ctx context.Context, plain string) (e.APIKey, error)
+}
+
+type Wallets interface {
+	GetWallet(ctx context.Context, ownerID string) (e.Wallet, error)
+	Deposit(ctx context.Context, ownerID string, amount money.Money) (e.Wallet, error)
+	Withdraw(ctx context.Context, ownerID string, amount money.Money) (e.Wallet, error)
+	ListEntries(ctx context.Context, ownerID string, page, pageSize int) ([]e.LedgerEntry, int64, error)
+	SettleAuction(ctx context.Context, in sd.SettleAuctionInput) error
+}
+
+type Orders interface {
+	CreateWinOrder(ctx context.Context, in sd.CreateWinOrderInput) (e.Order, error)
+	GetOrder(ctx context.Context, userID string, orderID int64) (e.Order, error)
+	ListOrders(ctx context.Context, in sd.ListOrdersInput) ([]e.Order, int64, error)
+	PayOrder(ctx context.Context, buyerID string, orderID int64) (e.Order, error)
+	AcceptOffer(ctx context.Context, buyerID string, orderID int64) (e.Order, error)
+	DeclineOffer(ctx context.Context, buyerID string, orderID int64) (e.Order, error)
+	ProcessDeadlines(ctx context.Context) error
+}
+
+type Statements interface {
+	SellerStatement(ctx context.Context, sellerID string, from, to time.Time) (e.SellerStatement, error)
+	SellerSummaries(ctx context.Context, from, to time.Time) ([]e.SellerStatement, error)
+}
+
+type FX interface {
+	ListRates(ctx context.Context) ([]e.FXRate, error)
+	SetRates(ctx context.Context, rates map[money.Currency]float64) ([]e.FXRate, error)
+	LoadRatesFile(ctx context.Context, path string) error
+}
+
+type Deliverer interface {
+	Deliver(ctx context.Context, n e.Notification) error
+}
+
+// Storage keeps uploaded files under slash separated keys; URL tells clients
+// where to fetch one.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
 }
 
 type Services struct {
 	Auctions
+	Imports
+	Templates
+	Media
 	Bids
+	Allowlists
+	Registrations
+	Fraud
+	Blocklists
+	Ratings
+	Questions
+	Watchlist
+	Notifications
+	Webhooks
+	Admin
+	APIKeys
+	Wallets
+	Orders
+	Statements
+	FX
 }
 
 type ServicesDependencies struct {
 	Repos       *repo.Repositories
+	TxManager   trm.Manager
 	Redis       *redis.Client
 	Breaker     *circuitbreaker.CircuitBreaker
 	Retryer     *retry.Retryer
 	Producer    *kafkaclient.Producer
 	Metrics     *metrics.Metrics
+	Deliverer   Deliverer
+	Storage     Storage
 	BidTopic    string
 	ResultTopic string
+
+	WebhookSender          *webhook.Sender
+	WebhookRetryer         *retry.Retryer
+	WebhookBreakerSettings gobreaker.Settings
+
+	APIKeyDefaultRPS   float64
+	APIKeyDefaultBurst int
+
+	HoldPercent float64
+
+	OrderTopic         string
+	OrderPaymentWindow time.Duration
+	OrderOfferWindow   time.Duration
+	FeePolicy          e.FeePolicy
+
+	IncrementPolicy e.IncrementPolicy
+
+	FraudPolicy e.FraudPolicy
+
+	BanCacheTTL time.Duration
+
+	MediaPolicy e.MediaPolicy
+
+	ImportBatchSize int
+	ImportMaxRows   int
 }
 
 func NewServices(deps ServicesDependencies) *Services {
+	bans := newCachedBans(deps.Repos.Bans, deps.Redis, deps.BanCacheTTL)
+	bids := NewBidService(
+		deps.Repos.Auctions, deps.Repos.Bids, deps.Repos.Invites, bans, deps.Repos.Wallets,
+		deps.Repos.FXRates, deps.TxManager, deps.Producer, deps.Redis, deps.Breaker, deps.Retryer,
+		deps.Metrics, deps.BidTopic, deps.ResultTopic, deps.HoldPercent,
+		DefaultEligibilityRules(bans, deps.Repos.Registrations, deps.Repos.Wallets, deps.Repos.Bids),
+	)
+	notifications := NewNotificationService(
+		deps.Repos.Notifications, deps.Repos.Watchlist,
+		deps.Repos.Auctions, deps.Repos.Bids, deps.Deliverer,
+		deps.Breaker, deps.Retryer, deps.Metrics,
+	)
+	auctions := NewAuctionService(
+		deps.Repos.Auctions, deps.Repos.Invites, deps.Repos.BidderGroups, bans, deps.Repos.FXRates, deps.Repos.Fraud,
+		deps.Repos.Ratings, deps.Repos.Questions, deps.Repos.Media, deps.Storage,
+		deps.TxManager, deps.Breaker, deps.Retryer, deps.Metrics, deps.IncrementPolicy,
+	)
+
 	return &Services{
-		Auctions: NewAuctionService(
-			deps.Repos.Auctions, deps.Breaker,
-			deps.Retryer, deps.Metrics,
-		),
-		Bids: NewBidService(
-			deps.Repos.Auctions, deps.Repos.Bids, deps.Producer,
-			deps.Redis, deps.Breaker, deps.Retryer, deps.Metrics,
-			deps.BidTopic, deps.ResultTopic,
+		Auctions:  auctions,
+		Imports:   NewImportService(auctions, deps.ImportBatchSize, deps.ImportMaxRows),
+		Templates: NewTemplateService(auctions, deps.Repos.Templates),
+		Media: NewMediaService(
+			deps.Repos.Auctions, deps.Repos.Media, deps.Storage,
+			deps.TxManager, deps.Breaker, deps.Retryer, deps.MediaPolicy,
+		),
+		Bids: bids,
+		Allowlists: NewAllowlistService(
+			deps.Repos.Auctions, deps.Repos.Invites, deps.Repos.BidderGroups,
+			deps.TxManager, deps.Breaker, deps.Retryer,
+		),
+		Registrations: NewRegistrationService(
+			deps.Repos.Auctions, deps.Repos.Registrations, deps.Repos.Invites, bans,
+			deps.Repos.Wallets, deps.Repos.FXRates, deps.TxManager, deps.Breaker, deps.Retryer,
+		),
+		Fraud: NewFraudService(
+			deps.Repos.Auctions, deps.Repos.Fraud, bids, deps.Breaker, deps.Retryer, deps.FraudPolicy,
+		),
+		Blocklists: NewBlocklistService(
+			bans, deps.Breaker, deps.Retryer,
+		),
+		Ratings: NewRatingService(
+			deps.Repos.Auctions, deps.Repos.Orders, deps.Repos.Ratings, deps.Breaker, deps.Retryer,
+		),
+		Questions: NewQuestionService(
+			deps.Repos.Auctions, deps.Repos.Invites, bans, deps.Repos.Questions,
+			notifications, deps.Breaker, deps.Retryer,
+		),
+		Watchlist: NewWatchlistService(
+			deps.Repos.Watchlist, deps.Repos.Auctions, deps.Repos.Invites, deps.Breaker, deps.Retryer,
+		),
+		Notifications: notifications,
+		Webhooks: NewWebhookService(
+			deps.Repos.Webhooks, deps.WebhookSender, deps.Breaker,
+			deps.WebhookBreakerSettings, deps.Retryer, deps.WebhookRetryer,
+			deps.Metrics,
+		),
+		Admin: NewAdminService(
+			deps.Repos.Auctions, bans, deps.Repos.Wallets,
+			deps.TxManager, deps.Breaker, deps.Retryer, deps.Metrics,
+		),
+		APIKeys: NewAPIKeyService(
+			deps.Repos.APIKeys, deps.Breaker, deps.Retryer,
+			deps.APIKeyDefaultRPS, deps.APIKeyDefaultBurst,
+		),
+		Wallets: NewWalletService(
+			deps.Repos.Wallets, deps.Repos.FXRates, deps.TxManager, deps.Breaker, deps.Retryer,
+		),
+		Orders: NewOrderService(
+			deps.Repos.Orders, deps.Repos.Auctions, deps.Repos.Bids, deps.Repos.Fees,
+			deps.Repos.Wallets, deps.Repos.FXRates, deps.TxManager, deps.Producer, deps.Breaker, deps.Retryer,
+			deps.OrderTopic, deps.OrderPaymentWindow, deps.OrderOfferWindow, deps.FeePolicy,
+		),
+		Statements: NewStatementService(
+			deps.Repos.Fees, deps.Breaker, deps.Retryer,
+		),
+		FX: NewFXService(
+			deps.Repos.FXRates, deps.Breaker, deps.Retryer,
 		),
 	}
 }
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type StatementService struct {
+	feeRepo repo.Fees
+	breaker *circuitbreaker.CircuitBreaker
+	retryer *retry.Retryer
+}
+
+func NewStatementService(
+	fRepo repo.Fees,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *StatementService {
+	return &StatementService{
+		feeRepo: fRepo,
+		breaker: breaker,
+		retryer: retryer,
+	}
+}
+
+// SellerStatement covers the seller's orders paid in [from, to).
+func (s *StatementService) SellerStatement(ctx context.Context, sellerID string, from, to time.Time) (e.SellerStatement, error) {
+	if !from.Before(to) {
+		return e.SellerStatement{}, se.ErrInvalidPeriod
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var lines []e.StatementLine
+		err := s.retryer.Do(ctx, "seller_statement", func() error {
+			var e error
+			lines, e = s.feeRepo.ListStatementLines(ctx, sellerID, from, to)
+			return e
+		})
+		return lines, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.SellerStatement{}, se.ErrCannotGetStatement
+	}
+
+	st := e.SellerStatement{
+		From:       from,
+		To:         to,
+		SellerID:   sellerID,
+		GrossSales: money.Zero(money.DefaultCurrency),
+		Fees:       money.Zero(money.DefaultCurrency),
+		Lines:      result.([]e.StatementLine),
+	}
+	for _, l := range st.Lines {
+		st.Orders++
+		st.GrossSales = st.GrossSales.Add(l.Gross)
+		st.Fees = st.Fees.Add(l.Fees)
+	}
+	st.NetPayout = st.GrossSales.Sub(st.Fees)
+	return st, nil
+}
+
+// SellerSummaries totals every seller's orders paid in [from, to).
+func (s *StatementService) SellerSummaries(ctx context.Context, from, to time.Time) ([]e.SellerStatement, error) {
+	if !from.Before(to) {
+		return nil, se.ErrInvalidPeriod
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var statements []e.SellerStatement
+		err := s.retryer.Do(ctx, "seller_summaries", func() error {
+			var e error
+			statements, e = s.feeRepo.SummarizeBySeller(ctx, from, to)
+			return e
+		})
+		return statements, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetStatement
+	}
+
+	statements := result.([]e.SellerStatement)
+	for i := range statements {
+		statements[i].NetPayout = statements[i].GrossSales.Sub(statements[i].Fees)
+	}
+	return statements, nil
+}
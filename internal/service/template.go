@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	smap "auction-platform/internal/service/mappers"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type TemplateService struct {
+	auctions     *AuctionService
+	templateRepo repo.Templates
+}
+
+func NewTemplateService(auctions *AuctionService, templateRepo repo.Templates) *TemplateService {
+	return &TemplateService{
+		auctions:     auctions,
+		templateRepo: templateRepo,
+	}
+}
+
+// SaveTemplate checks the settings the way CreateAuction would and stores
+// them under the name, replacing the seller's template of that name if any.
+func (s *TemplateService) SaveTemplate(ctx context.Context, in sd.SaveTemplateInput) (e.AuctionTemplate, error) {
+	if err := s.auctions.normalizeAuction(&in.Auction); err != nil {
+		return e.AuctionTemplate{}, err
+	}
+
+	result, cbErr := s.auctions.breaker.Execute("postgres", func() (any, error) {
+		var template e.AuctionTemplate
+		err := s.auctions.retryer.Do(ctx, "save_template", func() error {
+			var e error
+			template, e = s.templateRepo.Save(ctx, rd.SaveTemplateInput{
+				SellerID: in.SellerID,
+				Name:     in.Name,
+				Settings: smap.ToTemplateSettings(in.Auction),
+			})
+			return e
+		})
+		return template, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.AuctionTemplate{}, se.ErrCannotSaveTemplate
+	}
+	return result.(e.AuctionTemplate), nil
+}
+
+func (s *TemplateService) ListTemplates(ctx context.Context, sellerID string) ([]e.AuctionTemplate, error) {
+	result, cbErr := s.auctions.breaker.Execute("postgres", func() (any, error) {
+		var templates []e.AuctionTemplate
+		err := s.auctions.retryer.Do(ctx, "list_templates", func() error {
+			var e error
+			templates, e = s.templateRepo.ListBySeller(ctx, sellerID)
+			return e
+		})
+		return templates, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetTemplates
+	}
+	return result.([]e.AuctionTemplate), nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, sellerID string, templateID int64) error {
+	_, cbErr := s.auctions.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.auctions.retryer.Do(ctx, "delete_template", func() error {
+			return s.templateRepo.Delete(ctx, templateID, sellerID)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundTemplate, se.ErrCannotDeleteTemplate)
+	}
+	return nil
+}
+
+// LaunchTemplate creates an auction from one of the seller's templates.
+// Other sellers' templates are reported as not found.
+func (s *TemplateService) LaunchTemplate(ctx context.Context, in sd.LaunchTemplateInput) (e.Auction, error) {
+	result, cbErr := s.auctions.breaker.Execute("postgres", func() (any, error) {
+		var template e.AuctionTemplate
+		err := s.auctions.retryer.Do(ctx, "get_template", func() error {
+			var e error
+			template, e = s.templateRepo.GetByID(ctx, in.TemplateID)
+			return e
+		})
+		return template, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Auction{}, se.HandleRepoNotFound(cbErr, se.ErrNotFoundTemplate, se.ErrCannotGetTemplates)
+	}
+	template := result.(e.AuctionTemplate)
+	if template.SellerID != in.SellerID {
+		return e.Auction{}, se.ErrNotFoundTemplate
+	}
+
+	auction := smap.ToCreateAuctionFromTemplate(template, in.AuctionID)
+	if in.DurationMin > 0 {
+		auction.DurationMin = in.DurationMin
+	}
+	auction.ClientIP, auction.DeviceID = in.ClientIP, in.DeviceID
+	return s.auctions.CreateAuction(ctx, auction)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+	"auction-platform/pkg/money"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+type WalletService struct {
+	walletRepo repo.Wallets
+	fxRepo     repo.FXRates
+	txManager  trm.Manager
+	breaker    *circuitbreaker.CircuitBreaker
+	retryer    *retry.Retryer
+}
+
+func NewWalletService(
+	wRepo repo.Wallets,
+	fxRepo repo.FXRates,
+	txManager trm.Manager,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *WalletService {
+	return &WalletService{
+		walletRepo: wRepo,
+		fxRepo:     fxRepo,
+		txManager:  txManager,
+		breaker:    breaker,
+		retryer:    retryer,
+	}
+}
+
+func (s *WalletService) GetWallet(ctx context.Context, ownerID string) (e.Wallet, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var accounts []e.Account
+		err := s.retryer.Do(ctx, "get_wallet", func() error {
+			var e error
+			accounts, e = s.walletRepo.ListAccounts(ctx, ownerID)
+			return e
+		})
+		return accounts, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Wallet{}, se.ErrCannotGetWallet
+	}
+
+	w := e.Wallet{
+		OwnerID:   ownerID,
+		Available: money.Zero(money.DefaultCurrency),
+		Held:      money.Zero(money.DefaultCurrency),
+	}
+	for _, a := range result.([]e.Account) {
+		switch a.Type {
+		case e.AccountAvailable:
+			w.Available = a.Balance
+		case e.AccountHeld:
+			w.Held = a.Balance
+		}
+	}
+	return w, nil
+}
+
+func (s *WalletService) Deposit(ctx context.Context, ownerID string, amount money.Money) (e.Wallet, error) {
+	if err := amount.Validate(); err != nil {
+		return e.Wallet{}, se.ErrInvalidAmount
+	}
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		return transferFunds(ctx, s.walletRepo,
+			e.ExternalOwnerID, e.AccountExternal, ownerID, e.AccountAvailable,
+			amount, e.LedgerDeposit, "")
+	})
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Wallet{}, se.ErrCannotUpdateWallet
+	}
+
+	log.Infof("Wallet deposit: owner=%s amount=%s", ownerID, amount)
+	return s.GetWallet(ctx, ownerID)
+}
+
+func (s *WalletService) Withdraw(ctx context.Context, ownerID string, amount money.Money) (e.Wallet, error) {
+	if err := amount.Validate(); err != nil {
+		return e.Wallet{}, se.ErrInvalidAmount
+	}
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		return transferFunds(ctx, s.walletRepo,
+			ownerID, e.AccountAvailable, e.ExternalOwnerID, e.AccountExternal,
+			amount, e.LedgerWithdrawal, "")
+	})
+	if err != nil {
+		if errors.Is(err, re.ErrInsufficientFunds) {
+			return e.Wallet{}, se.ErrInsufficientFunds
+		}
+		log.Error(errutils.WrapPathErr(err))
+		return e.Wallet{}, se.ErrCannotUpdateWallet
+	}
+
+	log.Infof("Wallet withdrawal: owner=%s amount=%s", ownerID, amount)
+	return s.GetWallet(ctx, ownerID)
+}
+
+func (s *WalletService) ListEntries(ctx context.Context, ownerID string, page, pageSize int) ([]e.LedgerEntry, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	type entryPage struct {
+		entries []e.LedgerEntry
+		total   int64
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var r entryPage
+		err := s.retryer.Do(ctx, "list_ledger_entries", func() error {
+			var e error
+			r.entries, r.total, e = s.walletRepo.ListEntries(ctx, ownerID, pageSize, offset)
+			return e
+		})
+		return r, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, 0, se.ErrCannotGetWallet
+	}
+
+	r := result.(entryPage)
+	return r.entries, r.total, nil
+}
+
+// SettleAuction charges each winner what they owe, up to their hold, to the
+// seller and releases the rest of the hold. Under uniform pricing that rest is
+// the difference between the bid and the clearing price. Every other hold
+// left on the auction, registration deposits included, is released too.
+// Running it twice is a no-op.
+func (s *WalletService) SettleAuction(ctx context.Context, in sd.SettleAuctionInput) error {
+	var winnerIDs []string
+	owed := make(map[string]money.Money, len(in.Winners))
+	for _, w := range in.Winners {
+		if total, ok := owed[w.BidderID]; ok {
+			owed[w.BidderID] = total.Add(w.Amount)
+			continue
+		}
+		winnerIDs = append(winnerIDs, w.BidderID)
+		owed[w.BidderID] = w.Amount
+	}
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := releaseHolds(ctx, s.walletRepo, in.AuctionID, winnerIDs...); err != nil {
+			return err
+		}
+		if err := releaseHolds(ctx, s.walletRepo, e.RegistrationReference(in.AuctionID)); err != nil {
+			return err
+		}
+
+		for _, winnerID := range winnerIDs {
+			held, err := s.walletRepo.HeldAmount(ctx, winnerID, in.AuctionID)
+			if err != nil {
+				return err
+			}
+			if !held.IsPositive() {
+				continue
+			}
+			due, _, err := convertMoney(ctx, s.fxRepo, owed[winnerID], held.Currency)
+			if err != nil {
+				return err
+			}
+			if charge := money.Min(held, due); charge.IsPositive() {
+				err = transferFunds(ctx, s.walletRepo,
+					winnerID, e.AccountHeld, in.SellerID, e.AccountAvailable,
+					charge, e.LedgerCharge, in.AuctionID)
+				if err != nil {
+					return err
+				}
+			}
+			if err := setHold(ctx, s.walletRepo, winnerID, in.AuctionID, money.Zero(held.Currency)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.ErrCannotSettleAuction
+	}
+	return nil
+}
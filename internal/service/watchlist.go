@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/repo"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type WatchlistService struct {
+	watchlistRepo repo.Watchlist
+	auctionRepo   repo.Auctions
+	inviteRepo    repo.Invites
+	breaker       *circuitbreaker.CircuitBreaker
+	retryer       *retry.Retryer
+}
+
+func NewWatchlistService(
+	wRepo repo.Watchlist,
+	aRepo repo.Auctions,
+	inviteRepo repo.Invites,
+	breaker *circuitbreaker.CircuitBreaker,
+	retryer *retry.Retryer,
+) *WatchlistService {
+	return &WatchlistService{
+		watchlistRepo: wRepo,
+		auctionRepo:   aRepo,
+		inviteRepo:    inviteRepo,
+		breaker:       breaker,
+		retryer:       retryer,
+	}
+}
+
+// Watch subscribes the user to an auction they can see, so private auctions
+// only notify the seller and invited bidders.
+func (s *WatchlistService) Watch(ctx context.Context, userID, auctionID string) error {
+	auction, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var auction e.Auction
+		err := s.retryer.Do(ctx, "get_auction", func() error {
+			var e error
+			auction, e = s.auctionRepo.GetByID(ctx, auctionID)
+			return e
+		})
+		return auction, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotWatchAuction)
+	}
+	if err := checkCanView(ctx, s.inviteRepo, auction.(e.Auction), userID); err != nil {
+		return err
+	}
+
+	_, cbErr = s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "watch_auction", func() error {
+			return s.watchlistRepo.Add(ctx, userID, auctionID)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundAuction, se.ErrCannotWatchAuction)
+	}
+	return nil
+}
+
+func (s *WatchlistService) Unwatch(ctx context.Context, userID, auctionID string) error {
+	_, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		return nil, s.retryer.Do(ctx, "unwatch_auction", func() error {
+			return s.watchlistRepo.Remove(ctx, userID, auctionID)
+		})
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return se.HandleRepoNotFound(cbErr, se.ErrNotFoundWatch, se.ErrCannotUnwatchAuction)
+	}
+	return nil
+}
+
+func (s *WatchlistService) GetWatchlist(ctx context.Context, userID string) ([]e.WatchlistItem, error) {
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var items []e.WatchlistItem
+		err := s.retryer.Do(ctx, "get_watchlist", func() error {
+			var e error
+			items, e = s.watchlistRepo.ListByUser(ctx, userID)
+			return e
+		})
+		return items, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return nil, se.ErrCannotGetWatchlist
+	}
+
+	items, _ := result.([]e.WatchlistItem)
+	return items, nil
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	e "auction-platform/internal/entity"
+	"auction-platform/internal/infrastruct/circuitbreaker"
+	"auction-platform/internal/infrastruct/retry"
+	"auction-platform/internal/infrastruct/webhook"
+	"auction-platform/internal/metrics"
+	"auction-platform/internal/repo"
+	rd "auction-platform/internal/repo/dto"
+	re "auction-platform/internal/repo/errors"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
+	errutils "auction-platform/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
+)
+
+type WebhookService struct {
+	webhookRepo     repo.Webhooks
+	sender          *webhook.Sender
+	breaker         *circuitbreaker.CircuitBreaker
+	breakerSettings gobreaker.Settings
+	retryer         *retry.Retryer
+	deliveryRetryer *retry.Retryer
+	metrics         *metrics.Metrics
+}
+
+func NewWebhookService(
+	wRepo repo.Webhooks,
+	sender *webhook.Sender,
+	breaker *circuitbreaker.CircuitBreaker,
+	breakerSettings gobreaker.Settings,
+	retryer *retry.Retryer,
+	deliveryRetryer *retry.Retryer,
+	m *metrics.Metrics,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo:     wRepo,
+		sender:          sender,
+		breaker:         breaker,
+		breakerSettings: breakerSettings,
+		retryer:         retryer,
+		deliveryRetryer: deliveryRetryer,
+		metrics:         m,
+	}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, in sd.CreateWebhookInput) (e.Webhook, error) {
+	if err := webhook.CheckTarget(ctx, in.URL); err != nil {
+		return e.Webhook{}, se.ErrInvalidWebhookURL
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return e.Webhook{}, se.ErrCannotCreateWebhook
+	}
+
+	repoIn := rd.CreateWebhookInput{
+		OwnerID:    in.OwnerID,
+		URL:        in.URL,
+		Secret:     secret,
+		EventTypes: in.EventTypes,
+	}
+
+	result, cbErr := s.breaker.Execute("postgres", func() (any, error) {
+		var w e.Webhook
+		err := s.retryer.Do(ctx, "create_webhook", func() error {
+			var e error
+			w, e = s.webhookRepo.Create(ctx, repoIn)
+			return e
+		})
+		return w, err
+	})
+	if cbErr != nil {
+		log.Error(errutils.WrapPathErr(cbErr))
+		return e.Webhook{}, se.ErrCannotCreateWebhook
+	}
+
+	return result.(e.Webhook), nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context, ownerID string) ([]e.Webhook, error) {
+	webhooks, err := s.webhookRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return nil, se.ErrCannotGetWebhooks
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, ownerID string, webhookID int64) error {
+	if err := s.webhookRepo.Delete(ctx, webhookID, ownerID); err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return se.HandleRepoNotFound(err, se.ErrNotFoundWebhook, se.ErrCannotDeleteWebhook)
+	}
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, ownerID string, webhookID int64, limit int) ([]e.WebhookDelivery, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	w, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return nil, se.HandleRepoNotFound(err, se.ErrNotFoundWebhook, se.ErrCannotGetWebhooks)
+	}
+	if w.OwnerID != ownerID {
+		return nil, se.ErrNotFoundWebhook
+	}
+
+	deliveries, err := s.webhookRepo.ListDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		log.Error(errutils.WrapPathErr(err))
+		return nil, se.ErrCannotGetWebhooks
+	}
+	return deliveries, nil
+}
+
+func (s *WebhookService) Dispatch(ctx context.Context, eventType e.WebhookEventType, data any) error {
+	webhooks, err := s.webhookRepo.ListActiveByEvent(ctx, eventType)
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(webhook.Envelope{
+		Event:      string(eventType),
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(webhooks))
+	for i, w := range webhooks {
+		wg.Add(1)
+		go func(i int, w e.Webhook) {
+			defer wg.Done()
+			errs[i] = s.deliver(ctx, w, eventType, body)
+		}(i, w)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (s *WebhookService) deliver(ctx context.Context, w e.Webhook, eventType e.WebhookEventType, body []byte) error {
+	delivery, err := s.webhookRepo.CreateDelivery(ctx, rd.CreateWebhookDeliveryInput{
+		WebhookID: w.WebhookID,
+		EventType: eventType,
+		Payload:   body,
+	})
+	if err != nil {
+		return errutils.WrapPathErr(err)
+	}
+
+	breakerName := fmt.Sprintf("webhook_%d", w.WebhookID)
+	s.breaker.RegisterIfAbsent(breakerName, s.breakerSettings)
+
+	var (
+		attempts     int
+		responseCode int
+	)
+	_, sendErr := s.breaker.Execute(breakerName, func() (any, error) {
+		return nil, s.deliveryRetryer.Do(ctx, "webhook_delivery", func() error {
+			attempts++
+			var e error
+			responseCode, e = s.sender.Send(ctx, webhook.Request{
+				URL:        w.URL,
+				Secret:     w.Secret,
+				Event:      string(eventType),
+				DeliveryID: delivery.DeliveryID,
+				Body:       body,
+			})
+			return e
+		})
+	})
+
+	finish := rd.FinishWebhookDeliveryInput{
+		DeliveryID:   delivery.DeliveryID,
+		Status:       e.WebhookDeliverySuccess,
+		Attempts:     attempts,
+		ResponseCode: responseCode,
+	}
+	if sendErr != nil {
+		finish.Status = e.WebhookDeliveryFailed
+		finish.Error = sendErr.Error()
+		log.Warnf("Webhook delivery failed [%d] webhook=%d: %v", delivery.DeliveryID, w.WebhookID, sendErr)
+	}
+	s.metrics.WebhookDeliveries.WithLabelValues(string(eventType), string(finish.Status)).Inc()
+
+	if err := s.webhookRepo.FinishDelivery(ctx, finish); err != nil && !errors.Is(err, re.ErrNotFound) {
+		return errutils.WrapPathErr(err)
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
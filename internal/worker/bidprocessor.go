@@ -10,23 +10,30 @@ import (
 	kd "auction-platform/internal/infrastruct/kafka/dto"
 	"auction-platform/internal/metrics"
 	"auction-platform/internal/repo"
-	re "auction-platform/internal/repo/errors"
 	"auction-platform/internal/service"
+	sd "auction-platform/internal/service/dto"
+	se "auction-platform/internal/service/errors"
 
 	log "github.com/sirupsen/logrus"
 )
 
 type BidProcessor struct {
-	bidService  service.Bids
-	auctionRepo repo.Auctions
-	bidRepo     repo.Bids
-	producer    *kafkaclient.Producer
-	metrics     *metrics.Metrics
-	endTopic    string
+	auctionService service.Auctions
+	bidService     service.Bids
+	walletService  service.Wallets
+	orderService   service.Orders
+	auctionRepo    repo.Auctions
+	bidRepo        repo.Bids
+	producer       *kafkaclient.Producer
+	metrics        *metrics.Metrics
+	endTopic       string
 }
 
 func NewBidProcessor(
+	aServ service.Auctions,
 	bServ service.Bids,
+	wServ service.Wallets,
+	oServ service.Orders,
 	aRepo repo.Auctions,
 	bRepo repo.Bids,
 	producer *kafkaclient.Producer,
@@ -34,12 +41,15 @@ func NewBidProcessor(
 	endTopic string,
 ) *BidProcessor {
 	return &BidProcessor{
-		bidService:  bServ,
-		auctionRepo: aRepo,
-		bidRepo:     bRepo,
-		producer:    producer,
-		metrics:     m,
-		endTopic:    endTopic,
+		auctionService: aServ,
+		bidService:     bServ,
+		walletService:  wServ,
+		orderService:   oServ,
+		auctionRepo:    aRepo,
+		bidRepo:        bRepo,
+		producer:       producer,
+		metrics:        m,
+		endTopic:       endTopic,
 	}
 }
 
@@ -67,26 +77,76 @@ func (p *BidProcessor) checkExpired(ctx context.Context) {
 	}
 
 	for _, auction := range expired {
-		p.finishAuction(ctx, auction)
+		if p.finishAuction(ctx, auction) {
+			p.relistAuction(ctx, auction)
+		}
 	}
 }
 
-func (p *BidProcessor) finishAuction(ctx context.Context, auction e.Auction) {
-	highestBid, err := p.bidService.GetHighestBid(ctx, auction.AuctionID)
+// finishAuction allocates the lot to the winning bids, charges their holds,
+// opens an order per winner and publishes one event listing all of them. The
+// auction keeps the top winner as winner_id and the clearing price as its
+// final price. In a reverse auction the winners are suppliers: nothing is
+// charged and the auction's owner buys from each of them. It reports whether
+// the auction finished unsold, with no bids or none that met the reserve.
+func (p *BidProcessor) finishAuction(ctx context.Context, auction e.Auction) (unsold bool) {
+	leading, err := p.bidService.WinningBids(ctx, auction)
+	if err != nil {
+		log.Errorf("Failed to get winning bids for auction %s: %v", auction.AuctionID, err)
+		return false
+	}
+	allocs := auction.Award(leading)
+
 	winnerID := ""
-	finalPrice := auction.StartPrice
+	finalPrice := auction.ClearingPrice(allocs)
+	settled := make([]sd.SettleWinner, 0, len(allocs))
+	winners := make([]kd.AuctionWinner, 0, len(allocs))
+	for _, a := range allocs {
+		if !auction.IsReverse() {
+			settled = append(settled, sd.SettleWinner{BidderID: a.Bid.BidderID, Amount: a.Amount()})
+		}
+		winners = append(winners, kd.AuctionWinner{
+			BidderID: a.Bid.BidderID,
+			Units:    a.Units,
+			Price:    a.Price,
+			Amount:   a.Amount(),
+		})
+	}
+	if len(allocs) > 0 {
+		winnerID = allocs[0].Bid.BidderID
+	}
 
-	if err == nil {
-		winnerID = highestBid.BidderID
-		finalPrice = highestBid.Amount
-	} else if !errors.Is(err, re.ErrNotFound) {
-		log.Errorf("Failed to get highest bid for auction %s: %v", auction.AuctionID, err)
-		return
+	err = p.walletService.SettleAuction(ctx, sd.SettleAuctionInput{
+		AuctionID: auction.AuctionID,
+		Winners:   settled,
+		SellerID:  auction.SellerID,
+	})
+	if err != nil {
+		log.Errorf("Failed to settle auction %s: %v", auction.AuctionID, err)
+		return false
+	}
+
+	for _, a := range allocs {
+		buyerID, sellerID := a.Bid.BidderID, auction.SellerID
+		if auction.IsReverse() {
+			buyerID, sellerID = auction.SellerID, a.Bid.BidderID
+		}
+		_, err = p.orderService.CreateWinOrder(ctx, sd.CreateWinOrderInput{
+			AuctionID: auction.AuctionID,
+			BuyerID:   buyerID,
+			SellerID:  sellerID,
+			Amount:    a.Amount(),
+			Quantity:  a.Units,
+		})
+		if err != nil && !errors.Is(err, se.ErrOrderAlreadyExists) {
+			log.Errorf("Failed to create order for auction %s buyer %s: %v", auction.AuctionID, a.Bid.BidderID, err)
+			return false
+		}
 	}
 
 	if err := p.auctionRepo.FinishAuction(ctx, auction.AuctionID, winnerID, finalPrice); err != nil {
 		log.Errorf("Failed to finish auction %s: %v", auction.AuctionID, err)
-		return
+		return false
 	}
 
 	totalBids, _ := p.bidService.CountByAuction(ctx, auction.AuctionID)
@@ -95,6 +155,10 @@ func (p *BidProcessor) finishAuction(ctx context.Context, auction e.Auction) {
 		AuctionID:  auction.AuctionID,
 		WinnerID:   winnerID,
 		FinalPrice: finalPrice,
+		Currency:   string(finalPrice.Currency),
+		Quantity:   auction.Quantity,
+		Pricing:    string(auction.Pricing),
+		Winners:    winners,
 		TotalBids:  totalBids,
 	}
 	p.producer.Publish(ctx, p.endTopic, auction.AuctionID, event)
@@ -102,6 +166,29 @@ func (p *BidProcessor) finishAuction(ctx context.Context, auction e.Auction) {
 	p.metrics.AuctionsFinished.Inc()
 	p.metrics.ActiveAuctions.Dec()
 
-	log.Infof("Auction finished [%s] winner=%s price=%.2f bids=%d",
-		auction.AuctionID, winnerID, finalPrice, totalBids)
+	log.Infof("Auction finished [%s] winners=%d price=%s bids=%d",
+		auction.AuctionID, len(winners), finalPrice, totalBids)
+	return len(allocs) == 0
+}
+
+// relistAuction puts an unsold auction up again when its relist policy has
+// relistings left.
+func (p *BidProcessor) relistAuction(ctx context.Context, auction e.Auction) {
+	if !auction.CanRelist() {
+		return
+	}
+
+	relisted, err := p.auctionService.RelistAuction(ctx, auction.AuctionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, se.ErrUserBanned):
+			log.Infof("Not relisting auction %s: seller %s is banned", auction.AuctionID, auction.SellerID)
+		case !errors.Is(err, se.ErrNotRelistable):
+			log.Errorf("Failed to relist auction %s: %v", auction.AuctionID, err)
+		}
+		return
+	}
+
+	log.Infof("Auction relisted [%s] from=%s round=%d/%d start=%s",
+		relisted.AuctionID, auction.AuctionID, relisted.RelistCount, relisted.Relist.MaxRelists, relisted.StartPrice)
 }
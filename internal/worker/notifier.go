@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"auction-platform/internal/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type EndingSoonNotifier struct {
+	notificationService service.Notifications
+	window              time.Duration
+	interval            time.Duration
+}
+
+func NewEndingSoonNotifier(
+	nServ service.Notifications,
+	window time.Duration,
+	interval time.Duration,
+) *EndingSoonNotifier {
+	return &EndingSoonNotifier{
+		notificationService: nServ,
+		window:              window,
+		interval:            interval,
+	}
+}
+
+func (n *EndingSoonNotifier) Start(ctx context.Context) {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	log.Info("Ending soon notifier started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := n.notificationService.NotifyEndingSoon(ctx, n.window); err != nil {
+				log.Errorf("Failed to send ending soon notifications: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"auction-platform/internal/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type OrderDeadlineWatcher struct {
+	orderService service.Orders
+	interval     time.Duration
+}
+
+func NewOrderDeadlineWatcher(oServ service.Orders, interval time.Duration) *OrderDeadlineWatcher {
+	return &OrderDeadlineWatcher{
+		orderService: oServ,
+		interval:     interval,
+	}
+}
+
+func (w *OrderDeadlineWatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Info("Order deadline watcher started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.orderService.ProcessDeadlines(ctx); err != nil {
+				log.Errorf("Failed to process order deadlines: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_auctions_seller_id;
+DROP INDEX IF EXISTS idx_bids_bidder_id;
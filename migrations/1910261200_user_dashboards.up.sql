@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_bids_bidder_id ON bids(bidder_id, auction_id);
+CREATE INDEX IF NOT EXISTS idx_auctions_seller_id ON auctions(seller_id, created_at DESC);
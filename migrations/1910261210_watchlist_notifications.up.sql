@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS watchlist (
+    user_id VARCHAR(100) NOT NULL,
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, auction_id)
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    notification_id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(100) NOT NULL,
+    auction_id VARCHAR(100) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    message TEXT NOT NULL,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_watchlist_auction_id ON watchlist(auction_id);
+CREATE INDEX idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE UNIQUE INDEX idx_notifications_once ON notifications(user_id, auction_id, type)
+    WHERE type IN ('ENDING_SOON', 'WON', 'LOST');
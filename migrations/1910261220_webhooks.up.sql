@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    webhook_id BIGSERIAL PRIMARY KEY,
+    owner_id VARCHAR(100) NOT NULL,
+    url VARCHAR(2000) NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(webhook_id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    response_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_webhooks_owner_id ON webhooks(owner_id);
+CREATE INDEX idx_webhooks_event_types ON webhooks USING GIN(event_types) WHERE is_active;
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS user_bans (
+    user_id VARCHAR(100) PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    banned_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    key_id BIGSERIAL PRIMARY KEY,
+    owner_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    rate_limit_rps DOUBLE PRECISION NOT NULL,
+    rate_limit_burst INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    rotated_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_api_keys_owner_id ON api_keys(owner_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ledger_entries;
+DROP SEQUENCE IF EXISTS ledger_transfer_seq;
+DROP TABLE IF EXISTS accounts;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS accounts (
+    account_id BIGSERIAL PRIMARY KEY,
+    owner_id VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    balance DECIMAL(14,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (owner_id, type),
+    CHECK (type = 'EXTERNAL' OR balance >= 0)
+);
+
+CREATE SEQUENCE IF NOT EXISTS ledger_transfer_seq;
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    entry_id BIGSERIAL PRIMARY KEY,
+    transfer_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL REFERENCES accounts(account_id),
+    amount DECIMAL(14,2) NOT NULL CHECK (amount <> 0),
+    kind VARCHAR(20) NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_ledger_entries_account ON ledger_entries(account_id, created_at DESC);
+CREATE INDEX idx_ledger_entries_reference ON ledger_entries(reference) WHERE reference <> '';
+CREATE INDEX idx_ledger_entries_transfer ON ledger_entries(transfer_id);
\ No newline at end of file
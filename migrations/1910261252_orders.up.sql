@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS orders (
+    order_id BIGSERIAL PRIMARY KEY,
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id),
+    buyer_id VARCHAR(100) NOT NULL,
+    seller_id VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL CHECK (amount > 0),
+    amount_paid DECIMAL(12,2) NOT NULL DEFAULT 0,
+    payment_due_at TIMESTAMPTZ,
+    offer_expires_at TIMESTAMPTZ,
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (auction_id, kind)
+);
+
+CREATE INDEX idx_orders_buyer_id ON orders(buyer_id, created_at DESC);
+CREATE INDEX idx_orders_seller_id ON orders(seller_id, created_at DESC);
+CREATE INDEX idx_orders_payment_due ON orders(payment_due_at) WHERE status = 'PENDING';
+CREATE INDEX idx_orders_offer_expires ON orders(offer_expires_at) WHERE status = 'OFFERED';
\ No newline at end of file
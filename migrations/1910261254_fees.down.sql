@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_seller_paid_at;
+DROP TABLE IF EXISTS fee_lines;
+ALTER TABLE auctions DROP COLUMN IF EXISTS category;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS category VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS fee_lines (
+    fee_line_id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    seller_id VARCHAR(100) NOT NULL,
+    fee_type VARCHAR(20) NOT NULL,
+    basis DECIMAL(12,2) NOT NULL,
+    rate DECIMAL(7,4) NOT NULL DEFAULT 0,
+    amount DECIMAL(12,2) NOT NULL CHECK (amount >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_fee_lines_order_id ON fee_lines(order_id);
+CREATE INDEX idx_orders_seller_paid_at ON orders(seller_id, paid_at) WHERE status = 'PAID';
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS fx_rate;
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+
+DROP TABLE IF EXISTS fx_rates;
+
+ALTER TABLE bids DROP COLUMN IF EXISTS currency;
+ALTER TABLE auctions DROP COLUMN IF EXISTS currency;
\ No newline at end of file
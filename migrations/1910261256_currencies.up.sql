@@ -0,0 +1,13 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+CREATE TABLE IF NOT EXISTS fx_rates (
+    currency VARCHAR(3) PRIMARY KEY,
+    rate NUMERIC(20,10) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO fx_rates (currency, rate) VALUES ('USD', 1) ON CONFLICT DO NOTHING;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS fx_rate NUMERIC(20,10) NOT NULL DEFAULT 1;
\ No newline at end of file
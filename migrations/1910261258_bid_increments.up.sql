@@ -0,0 +1 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS increments JSONB;
\ No newline at end of file
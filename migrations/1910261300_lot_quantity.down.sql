@@ -0,0 +1,9 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_auction_id_buyer_id_kind_key;
+ALTER TABLE orders ADD CONSTRAINT orders_auction_id_kind_key UNIQUE (auction_id, kind);
+ALTER TABLE orders DROP COLUMN IF EXISTS quantity;
+
+DROP INDEX IF EXISTS idx_bids_auction_bidder;
+
+ALTER TABLE bids DROP COLUMN IF EXISTS quantity;
+ALTER TABLE auctions DROP COLUMN IF EXISTS pricing;
+ALTER TABLE auctions DROP COLUMN IF EXISTS quantity;
\ No newline at end of file
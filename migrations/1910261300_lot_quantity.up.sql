@@ -0,0 +1,9 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS pricing VARCHAR(20) NOT NULL DEFAULT 'PAY_AS_BID';
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0);
+
+CREATE INDEX IF NOT EXISTS idx_bids_auction_bidder ON bids(auction_id, bidder_id, created_at DESC);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0);
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_auction_id_kind_key;
+ALTER TABLE orders ADD CONSTRAINT orders_auction_id_buyer_id_kind_key UNIQUE (auction_id, buyer_id, kind);
\ No newline at end of file
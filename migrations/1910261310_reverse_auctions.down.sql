@@ -0,0 +1,7 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_auction_id_buyer_id_seller_id_kind_key;
+ALTER TABLE orders ADD CONSTRAINT orders_auction_id_buyer_id_kind_key UNIQUE (auction_id, buyer_id, kind);
+
+DROP TABLE IF EXISTS auction_invites;
+
+ALTER TABLE auctions DROP COLUMN IF EXISTS invite_only;
+ALTER TABLE auctions DROP COLUMN IF EXISTS auction_type;
\ No newline at end of file
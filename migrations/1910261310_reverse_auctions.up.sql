@@ -0,0 +1,12 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS auction_type VARCHAR(20) NOT NULL DEFAULT 'FORWARD';
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS invite_only BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS auction_invites (
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    bidder_id VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (auction_id, bidder_id)
+);
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_auction_id_buyer_id_kind_key;
+ALTER TABLE orders ADD CONSTRAINT orders_auction_id_buyer_id_seller_id_kind_key UNIQUE (auction_id, buyer_id, seller_id, kind);
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS auction_invited_groups;
+DROP TABLE IF EXISTS bidder_group_members;
+DROP TABLE IF EXISTS bidder_groups;
+
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS invite_only BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE auctions SET invite_only = TRUE WHERE visibility = 'PRIVATE';
+ALTER TABLE auctions DROP COLUMN IF EXISTS visibility;
\ No newline at end of file
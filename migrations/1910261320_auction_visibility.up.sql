@@ -0,0 +1,27 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'PUBLIC';
+UPDATE auctions SET visibility = 'PRIVATE' WHERE invite_only;
+ALTER TABLE auctions DROP COLUMN IF EXISTS invite_only;
+
+CREATE TABLE IF NOT EXISTS bidder_groups (
+    group_id BIGSERIAL PRIMARY KEY,
+    seller_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (seller_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS bidder_group_members (
+    group_id BIGINT NOT NULL REFERENCES bidder_groups(group_id) ON DELETE CASCADE,
+    bidder_id VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, bidder_id)
+);
+
+CREATE TABLE IF NOT EXISTS auction_invited_groups (
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    group_id BIGINT NOT NULL REFERENCES bidder_groups(group_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (auction_id, group_id)
+);
+
+CREATE INDEX idx_bidder_group_members_bidder ON bidder_group_members(bidder_id);
\ No newline at end of file
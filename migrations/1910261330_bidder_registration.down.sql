@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS auction_registrations;
+
+ALTER TABLE auctions DROP COLUMN IF EXISTS eligibility;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS eligibility JSONB NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS auction_registrations (
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    bidder_id VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    deposit DECIMAL(14,2) NOT NULL DEFAULT 0,
+    reviewed_by VARCHAR(100) NOT NULL DEFAULT '',
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (auction_id, bidder_id)
+);
+
+CREATE INDEX idx_auction_registrations_status ON auction_registrations(auction_id, status);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fraud_flags;
+DROP TABLE IF EXISTS bid_retractions;
+DROP TABLE IF EXISTS user_devices;
\ No newline at end of file
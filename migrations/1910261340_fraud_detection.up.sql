@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS user_devices (
+    user_id VARCHAR(100) NOT NULL,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    device_id VARCHAR(200) NOT NULL DEFAULT '',
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, ip, device_id)
+);
+
+CREATE INDEX idx_user_devices_ip ON user_devices(ip);
+CREATE INDEX idx_user_devices_device ON user_devices(device_id);
+
+CREATE TABLE IF NOT EXISTS bid_retractions (
+    bid_id VARCHAR(100) PRIMARY KEY,
+    auction_id VARCHAR(100) NOT NULL,
+    bidder_id VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bid_retractions_bidder ON bid_retractions(bidder_id);
+
+CREATE TABLE IF NOT EXISTS fraud_flags (
+    flag_id BIGSERIAL PRIMARY KEY,
+    bid_id VARCHAR(100) NOT NULL UNIQUE,
+    auction_id VARCHAR(100) NOT NULL,
+    bidder_id VARCHAR(100) NOT NULL,
+    seller_id VARCHAR(100) NOT NULL,
+    score INT NOT NULL,
+    signals TEXT[] NOT NULL DEFAULT '{}',
+    held BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    reviewed_by VARCHAR(100) NOT NULL DEFAULT '',
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_fraud_flags_status ON fraud_flags(status, created_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS seller_blocked_bidders;
+
+ALTER TABLE user_bans DROP COLUMN IF EXISTS expires_at;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE user_bans ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS seller_blocked_bidders (
+    seller_id VARCHAR(100) NOT NULL,
+    bidder_id VARCHAR(100) NOT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (seller_id, bidder_id)
+);
\ No newline at end of file
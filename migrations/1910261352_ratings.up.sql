@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ratings (
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    rater_id VARCHAR(100) NOT NULL,
+    ratee_id VARCHAR(100) NOT NULL,
+    role VARCHAR(10) NOT NULL,
+    score SMALLINT NOT NULL CHECK (score BETWEEN 1 AND 5),
+    comment VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (auction_id, rater_id, ratee_id)
+);
+
+CREATE INDEX idx_ratings_ratee ON ratings(ratee_id, role);
\ No newline at end of file
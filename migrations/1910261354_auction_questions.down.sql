@@ -0,0 +1 @@
+DROP TABLE IF EXISTS auction_questions;
\ No newline at end of file
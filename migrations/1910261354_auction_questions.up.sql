@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS auction_questions (
+    question_id BIGSERIAL PRIMARY KEY,
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    asker_id VARCHAR(100) NOT NULL,
+    question VARCHAR(1000) NOT NULL,
+    answer VARCHAR(2000) NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    answered_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_auction_questions_auction ON auction_questions(auction_id, created_at);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS auction_media;
\ No newline at end of file
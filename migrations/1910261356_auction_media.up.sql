@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS auction_media (
+    media_id BIGSERIAL PRIMARY KEY,
+    auction_id VARCHAR(100) NOT NULL REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    storage_key VARCHAR(500) NOT NULL,
+    thumbnail_key VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT auction_media_position UNIQUE (auction_id, position) DEFERRABLE INITIALLY DEFERRED
+);
\ No newline at end of file
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS auction_templates;
+
+DROP INDEX IF EXISTS idx_auctions_relisted_from;
+
+ALTER TABLE auctions DROP COLUMN IF EXISTS relisted_from;
+ALTER TABLE auctions DROP COLUMN IF EXISTS relist_count;
+ALTER TABLE auctions DROP COLUMN IF EXISTS relist_policy;
+ALTER TABLE auctions DROP COLUMN IF EXISTS duration_min;
+ALTER TABLE auctions DROP COLUMN IF EXISTS reserve_price;
\ No newline at end of file
@@ -0,0 +1,17 @@
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS reserve_price DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (reserve_price >= 0);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS duration_min INT NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS relist_policy JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS relist_count INT NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS relisted_from VARCHAR(100) REFERENCES auctions(auction_id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auctions_relisted_from ON auctions(relisted_from) WHERE relisted_from IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS auction_templates (
+    template_id BIGSERIAL PRIMARY KEY,
+    seller_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    settings JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (seller_id, name)
+);
\ No newline at end of file
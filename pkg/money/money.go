@@ -0,0 +1,281 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of decimal places every amount is kept at. It matches
+// the DECIMAL(12,2) money columns; currencies with fewer decimals only use
+// whole multiples of their unit.
+const Scale = 2
+
+const scaleFactor = 100
+
+type Currency string
+
+const DefaultCurrency Currency = "USD"
+
+var currencyDecimals = map[Currency]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CHF": 2,
+	"CAD": 2,
+	"AUD": 2,
+	"CNY": 2,
+	"RUB": 2,
+	"JPY": 0,
+	"KRW": 0,
+}
+
+var (
+	ErrInvalidAmount       = errors.New("invalid money amount")
+	ErrTooPrecise          = errors.New("amount has more decimal places than the currency allows")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+func (c Currency) IsSupported() bool {
+	_, ok := currencyDecimals[c]
+	return ok
+}
+
+func (c Currency) Decimals() int {
+	if d, ok := currencyDecimals[c]; ok {
+		return d
+	}
+	return Scale
+}
+
+// Money is an exact amount in a currency. Minor holds the amount in
+// hundredths of the major unit regardless of the currency, so JPY 500 is
+// Minor 50000. Add, Sub and Cmp panic on operands in different currencies;
+// an empty currency, as in the zero Money, matches any.
+type Money struct {
+	Minor    int64
+	Currency Currency
+}
+
+func New(minor int64, c Currency) Money {
+	return Money{Minor: minor, Currency: c}
+}
+
+func Zero(c Currency) Money {
+	return Money{Currency: c}
+}
+
+// FromFloat rounds f to the nearest hundredth. Use it only for configuration
+// values, never for amounts supplied by users.
+func FromFloat(f float64, c Currency) Money {
+	return Money{Minor: int64(math.Round(f * scaleFactor)), Currency: c}
+}
+
+// Parse reads a decimal string such as "12.5" exactly and rejects more
+// decimal places than the currency allows.
+func Parse(s string, c Currency) (Money, error) {
+	minor, err := parseMinor(s)
+	if err != nil {
+		return Money{}, err
+	}
+	m := Money{Minor: minor, Currency: c}
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+func parseMinor(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidAmount
+	}
+	if strings.ContainsAny(whole+frac, "eE+-") {
+		return 0, ErrInvalidAmount
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > Scale {
+		return 0, ErrTooPrecise
+	}
+	frac += strings.Repeat("0", Scale-len(frac))
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil || units > math.MaxInt64/2 {
+		return 0, ErrInvalidAmount
+	}
+	if neg {
+		units = -units
+	}
+	return units, nil
+}
+
+// Validate checks the currency is supported and the amount fits its precision.
+func (m Money) Validate() error {
+	if !m.Currency.IsSupported() {
+		return ErrUnsupportedCurrency
+	}
+	step := int64(math.Pow10(Scale - m.Currency.Decimals()))
+	if m.Minor%step != 0 {
+		return ErrTooPrecise
+	}
+	return nil
+}
+
+// currencyWith returns the currency shared by m and o.
+func (m Money) currencyWith(o Money) Currency {
+	switch {
+	case o.Currency == "" || o.Currency == m.Currency:
+		return m.Currency
+	case m.Currency == "":
+		return o.Currency
+	}
+	panic(fmt.Sprintf("money: currency mismatch: %s and %s", m.Currency, o.Currency))
+}
+
+func (m Money) Add(o Money) Money {
+	return Money{Minor: m.Minor + o.Minor, Currency: m.currencyWith(o)}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{Minor: m.Minor - o.Minor, Currency: m.currencyWith(o)}
+}
+
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency}
+}
+
+func (m Money) Mul(n int64) Money {
+	return Money{Minor: m.Minor * n, Currency: m.Currency}
+}
+
+func (m Money) Cmp(o Money) int {
+	m.currencyWith(o)
+	switch {
+	case m.Minor < o.Minor:
+		return -1
+	case m.Minor > o.Minor:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) IsZero() bool     { return m.Minor == 0 }
+func (m Money) IsPositive() bool { return m.Minor > 0 }
+func (m Money) IsNegative() bool { return m.Minor < 0 }
+
+func Min(a, b Money) Money {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// Percent returns p percent of m, rounded half away from zero to the
+// currency's precision.
+func (m Money) Percent(p float64) Money {
+	step := math.Pow10(Scale - m.Currency.Decimals())
+	units := math.Round(float64(m.Minor)*p/100/step) * step
+	return Money{Minor: int64(units), Currency: m.Currency}
+}
+
+// Convert returns m in currency to at rate units of to per unit of m's
+// currency, rounded half away from zero to the target's precision.
+func (m Money) Convert(to Currency, rate float64) Money {
+	step := math.Pow10(Scale - to.Decimals())
+	units := math.Round(float64(m.Minor)*rate/step) * step
+	return Money{Minor: int64(units), Currency: to}
+}
+
+// Float64 is for metrics and other approximate uses only.
+func (m Money) Float64() float64 {
+	return float64(m.Minor) / scaleFactor
+}
+
+// String formats the amount with the currency's number of decimals.
+func (m Money) String() string {
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	whole, frac := minor/scaleFactor, minor%scaleFactor
+
+	decimals := m.Currency.Decimals()
+	if decimals == 0 && frac == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, whole, frac)
+}
+
+// MarshalJSON writes the amount as a plain JSON number, as the float fields it
+// replaces did.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string. The currency is left
+// as is, or set to DefaultCurrency when empty.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "null" {
+		return nil
+	}
+	minor, err := parseMinor(s)
+	if err != nil {
+		return err
+	}
+	m.Minor = minor
+	if m.Currency == "" {
+		m.Currency = DefaultCurrency
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns. The currency is left as is,
+// or set to DefaultCurrency when empty.
+func (m *Money) Scan(src any) error {
+	var minor int64
+	switch v := src.(type) {
+	case nil:
+		minor = 0
+	case string:
+		parsed, err := parseMinor(v)
+		if err != nil {
+			return fmt.Errorf("money: scan %q: %w", v, err)
+		}
+		minor = parsed
+	case []byte:
+		return m.Scan(string(v))
+	case int64:
+		minor = v * scaleFactor
+	case float64:
+		minor = int64(math.Round(v * scaleFactor))
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+
+	m.Minor = minor
+	if m.Currency == "" {
+		m.Currency = DefaultCurrency
+	}
+	return nil
+}
+
+// Value implements driver.Valuer as an exact decimal string.
+func (m Money) Value() (driver.Value, error) {
+	return Money{Minor: m.Minor}.String(), nil
+}
@@ -0,0 +1,203 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		c       Currency
+		want    int64
+		wantErr error
+	}{
+		{name: "whole", in: "12", c: "USD", want: 1200},
+		{name: "one decimal", in: "12.5", c: "USD", want: 1250},
+		{name: "two decimals", in: "12.34", c: "USD", want: 1234},
+		{name: "trailing zeros", in: "12.3400", c: "USD", want: 1234},
+		{name: "leading dot", in: ".5", c: "USD", want: 50},
+		{name: "trailing dot", in: "7.", c: "USD", want: 700},
+		{name: "spaces", in: " 3.10 ", c: "USD", want: 310},
+		{name: "plus sign", in: "+5", c: "USD", want: 500},
+		{name: "minus sign", in: "-5.25", c: "USD", want: -525},
+		{name: "yen whole", in: "500", c: "JPY", want: 50000},
+		{name: "double sign plus minus", in: "+-5", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "double sign minus plus", in: "-+5", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "double minus", in: "--5", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "sign in fraction", in: "1.-5", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "empty", in: "", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "lone dot", in: ".", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "exponent", in: "1e3", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "letters", in: "abc", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "overflow", in: "99999999999999999999", c: "USD", wantErr: ErrInvalidAmount},
+		{name: "too precise", in: "1.005", c: "USD", wantErr: ErrTooPrecise},
+		{name: "yen cents", in: "500.5", c: "JPY", wantErr: ErrTooPrecise},
+		{name: "unsupported currency", in: "1", c: "XXX", wantErr: ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in, tt.c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.Minor != tt.want || got.Currency != tt.c) {
+				t.Errorf("Parse(%q) = %+v, want %d %s", tt.in, got, tt.want, tt.c)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{New(1250, "USD"), "12.50"},
+		{New(5, "USD"), "0.05"},
+		{New(-5, "USD"), "-0.05"},
+		{New(0, "USD"), "0.00"},
+		{New(50000, "JPY"), "500"},
+		{New(-50000, "JPY"), "-500"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestArithmeticCurrency(t *testing.T) {
+	usd := New(100, "USD")
+
+	if got := usd.Add(Money{Minor: 50}); got != New(150, "USD") {
+		t.Errorf("Add with zero-currency operand = %+v", got)
+	}
+	if got := (Money{}).Sub(usd); got != New(-100, "USD") {
+		t.Errorf("Sub from zero Money = %+v", got)
+	}
+	if got := Min(usd, New(40, "USD")); got != New(40, "USD") {
+		t.Errorf("Min = %+v", got)
+	}
+
+	tests := []struct {
+		name string
+		op   func()
+	}{
+		{"add", func() { usd.Add(New(1, "EUR")) }},
+		{"sub", func() { usd.Sub(New(1, "EUR")) }},
+		{"cmp", func() { usd.Cmp(New(1, "EUR")) }},
+		{"min", func() { Min(usd, New(1, "EUR")) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s across currencies did not panic", tt.name)
+				}
+			}()
+			tt.op()
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		p    float64
+		want int64
+	}{
+		{name: "exact", m: New(10000, "USD"), p: 5, want: 500},
+		{name: "half rounds up", m: New(1005, "USD"), p: 10, want: 101},
+		{name: "below half rounds down", m: New(1004, "USD"), p: 10, want: 100},
+		{name: "negative half rounds away from zero", m: New(-5, "USD"), p: 10, want: -1},
+		{name: "yen rounds to whole units", m: New(123400, "JPY"), p: 5, want: 6200},
+		{name: "zero percent", m: New(1234, "USD"), p: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.Percent(tt.p)
+			if got.Minor != tt.want || got.Currency != tt.m.Currency {
+				t.Errorf("Percent(%v) = %+v, want %d %s", tt.p, got, tt.want, tt.m.Currency)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		json string
+	}{
+		{name: "dollars", m: New(1250, "USD"), json: "12.50"},
+		{name: "negative", m: New(-3, "USD"), json: "-0.03"},
+		{name: "yen", m: New(50000, "JPY"), json: "500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.m)
+			if err != nil || string(b) != tt.json {
+				t.Fatalf("Marshal = %s, %v, want %s", b, err, tt.json)
+			}
+			got := Money{Currency: tt.m.Currency}
+			if err := json.Unmarshal(b, &got); err != nil || got != tt.m {
+				t.Errorf("round trip = %+v, %v, want %+v", got, err, tt.m)
+			}
+		})
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`"7.5"`), &m); err != nil || m != New(750, DefaultCurrency) {
+		t.Errorf("Unmarshal string = %+v, %v", m, err)
+	}
+	if err := json.Unmarshal([]byte(`"+-1"`), &m); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Unmarshal double sign error = %v", err)
+	}
+}
+
+func TestScanValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    int64
+		wantErr bool
+	}{
+		{name: "nil", src: nil, want: 0},
+		{name: "string", src: "12.34", want: 1234},
+		{name: "bytes", src: []byte("-0.50"), want: -50},
+		{name: "int64", src: int64(7), want: 700},
+		{name: "float64", src: 2.5, want: 250},
+		{name: "bad string", src: "1.2.3", wantErr: true},
+		{name: "unsupported type", src: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := m.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%v) error = %v", tt.src, err)
+			}
+			if !tt.wantErr && (m.Minor != tt.want || m.Currency != DefaultCurrency) {
+				t.Errorf("Scan(%v) = %+v, want %d", tt.src, m, tt.want)
+			}
+		})
+	}
+
+	m := Money{Currency: "JPY"}
+	if err := m.Scan("500.00"); err != nil || m != New(50000, "JPY") {
+		t.Errorf("Scan keeps currency: %+v, %v", m, err)
+	}
+	v, err := m.Value()
+	if err != nil || v != "500.00" {
+		t.Errorf("Value = %v, %v, want 500.00", v, err)
+	}
+
+	var back Money
+	if err := back.Scan(v); err != nil || back.Minor != m.Minor {
+		t.Errorf("Value round trip = %+v, %v", back, err)
+	}
+}
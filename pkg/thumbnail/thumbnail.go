@@ -0,0 +1,90 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	// Registers the GIF decoder for image.Decode.
+	_ "image/gif"
+)
+
+// MaxPixels bounds the decoded size of a source image so a small, highly
+// compressed upload cannot exhaust memory.
+const MaxPixels = 50_000_000
+
+var (
+	ErrUnsupported = errors.New("thumbnail: unsupported image format")
+	ErrTooLarge    = errors.New("thumbnail: image dimensions too large")
+)
+
+// Make scales the image down to fit within maxSide pixels on its longer side
+// with a box filter, never scaling up. JPEG sources produce a JPEG thumbnail,
+// everything else a PNG so transparency survives.
+func Make(data []byte, maxSide int) ([]byte, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, "", ErrUnsupported
+		}
+		return nil, "", err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	thumb := scale(src, maxSide)
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80})
+		return buf.Bytes(), "image/jpeg", err
+	}
+	err = png.Encode(&buf, thumb)
+	return buf.Bytes(), "image/png", err
+}
+
+func scale(src image.Image, maxSide int) *image.RGBA {
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := sw, sh
+	if sw >= sh && sw > maxSide {
+		dw, dh = maxSide, max(1, sh*maxSide/sw)
+	} else if sh > sw && sh > maxSide {
+		dw, dh = max(1, sw*maxSide/sh), maxSide
+	}
+	if dw == sw && dh == sh {
+		return rgba
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, bl, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r, g, bl, a = r+int(p[0]), g+int(p[1]), bl+int(p[2]), a+int(p[3])
+					n++
+				}
+			}
+			d := dst.Pix[y*dst.Stride+x*4:]
+			d[0], d[1], d[2], d[3] = uint8(r/n), uint8(g/n), uint8(bl/n), uint8(a/n)
+		}
+	}
+	return dst
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strings"
 
+	"auction-platform/pkg/money"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -32,6 +34,10 @@ func NewCustomValidator() *CustomValidator {
 		return name
 	})
 
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.Interface().(money.Money).Float64()
+	}, money.Money{})
+
 	return cv
 }
 